@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"github.com/isfir/wowsims-turtle/sim/core"
 	"github.com/isfir/wowsims-turtle/sim/core/proto"
@@ -11,6 +12,11 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
+var (
+	combatLogFile   string
+	combatLogFormat string
+)
+
 var simCmd = &cobra.Command{
 	Use:   "sim",
 	Short: "simulate items & settings",
@@ -21,6 +27,8 @@ func init() {
 	simCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
 	simCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
 	simCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	simCmd.Flags().StringVar(&combatLogFile, "combatlog", "", "location to write the combat log of the first iteration to")
+	simCmd.Flags().StringVar(&combatLogFormat, "combatlogformat", "jsonl", "format of the combat log, either 'jsonl' or 'wow' for the in-game combat log format")
 	simCmd.MarkFlagRequired("infile")
 }
 
@@ -35,6 +43,15 @@ func simMain(cmd *cobra.Command, args []string) {
 	if err != nil {
 		log.Fatalf("failed to load input json file: %s", err)
 	}
+	if combatLogFile != "" {
+		if combatLogFormat != "jsonl" && combatLogFormat != "wow" {
+			log.Fatalf("unknown combat log format %q", combatLogFormat)
+		}
+		if input.SimOptions == nil {
+			input.SimOptions = &proto.SimOptions{}
+		}
+		input.SimOptions.RecordCombatLog = true
+	}
 
 	var output []byte
 	reporter := make(chan *proto.ProgressMetrics, 10)
@@ -51,6 +68,11 @@ func simMain(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	if combatLogFile != "" {
+		writeCombatLog(finalResult.CombatLog)
+		finalResult.CombatLog = nil
+	}
+
 	output, err = protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(finalResult)
 	if err != nil {
 		log.Fatalf("failed to marshal final results: %s", err)
@@ -68,3 +90,24 @@ func simMain(cmd *cobra.Command, args []string) {
 		}
 	}
 }
+
+func writeCombatLog(events []*proto.CombatLogEvent) {
+	var output string
+	if combatLogFormat == "wow" {
+		output = core.CombatLogText(events, time.Now(), nil)
+	} else {
+		var err error
+		output, err = core.CombatLogJSONLines(events)
+		if err != nil {
+			log.Fatalf("failed to marshal combat log: %s", err)
+		}
+	}
+
+	err := os.WriteFile(combatLogFile, []byte(output), 0666)
+	if err != nil {
+		log.Fatalf("failed to write combat log file: %s", err)
+	}
+	if verbose {
+		fmt.Printf("Wrote combat log: `%s` successfully.\n", combatLogFile)
+	}
+}
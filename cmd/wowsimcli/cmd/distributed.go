@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core"
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+var (
+	workerHost string
+
+	workerAddrs  []string
+	shardCount   int32
+	workerSlots  int
+	shardRetries int
+	shardTimeout time.Duration
+)
+
+var workerCmd = &cobra.Command{
+	Use:   "worker",
+	Short: "serve sim shards to a coordinator",
+	Long:  "serve sim shards to a coordinator. Shards are RaidSimRequests posted in binary protobuf format to /raidSim, like the web server's API, and each runs on a single core",
+	Run:   workerMain,
+}
+
+var coordinateCmd = &cobra.Command{
+	Use:   "coordinate",
+	Short: "split a sim over several workers and merge the results",
+	Long:  "split a sim over several workers and merge the results. Shards always cover the same seeds and are merged in order, so the result only depends on the input and the shard count",
+	Run:   coordinateMain,
+}
+
+func init() {
+	workerCmd.Flags().StringVar(&workerHost, "host", "localhost:3334", "address to listen on, e.g. 0.0.0.0:3334 to accept workers from the LAN")
+	workerCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+
+	coordinateCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
+	coordinateCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
+	coordinateCmd.Flags().StringSliceVar(&workerAddrs, "workers", nil, "comma separated worker addresses, e.g. localhost:3334,192.168.1.20:3334")
+	coordinateCmd.Flags().Int32Var(&shardCount, "shards", 0, "number of shards to split the iterations into, defaults to 4 per worker slot")
+	coordinateCmd.Flags().IntVar(&workerSlots, "slots", 8, "number of shards each worker sims at once, usually its number of cores")
+	coordinateCmd.Flags().IntVar(&shardRetries, "retries", 3, "number of times a failed shard is retried before giving up")
+	coordinateCmd.Flags().DurationVar(&shardTimeout, "timeout", 0, "maximum time a worker can take for a shard, 0 for no limit")
+	coordinateCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	coordinateCmd.MarkFlagRequired("infile")
+	coordinateCmd.MarkFlagRequired("workers")
+}
+
+func workerMain(cmd *cobra.Command, args []string) {
+	http.HandleFunc("/raidSim", handleShard)
+
+	log.Printf("Worker listening on %s", workerHost)
+	log.Fatal(http.ListenAndServe(workerHost, nil))
+}
+
+// Sims a single shard. Shards run on one core so the result doesn't depend on the
+// worker's hardware, and coordinators send several shards per worker to use the rest.
+func handleShard(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	request := &proto.RaidSimRequest{}
+	if err := googleProto.Unmarshal(body, request); err != nil {
+		log.Printf("Failed to parse request: %s", err.Error())
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	start := time.Now()
+	result := core.RunRaidSim(request)
+	if verbose {
+		log.Printf("Simmed %d iterations from seed %d in %s", request.SimOptions.Iterations, request.SimOptions.RandomSeed, time.Since(start))
+	}
+
+	outbytes, err := googleProto.Marshal(result)
+	if err != nil {
+		log.Printf("[ERROR] Failed to marshal result: %s", err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Add("Content-Type", "application/x-protobuf")
+	w.Write(outbytes)
+}
+
+func coordinateMain(cmd *cobra.Command, args []string) {
+	data, err := os.ReadFile(infile)
+	if err != nil {
+		log.Fatalf("failed to load input json file %q: %v", infile, err)
+	}
+	input := &proto.RaidSimRequest{}
+
+	err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, input)
+	if err != nil {
+		log.Fatalf("failed to load input json file: %s", err)
+	}
+
+	finalResult, err := coordinateSim(input, workerAddrs, defaultShardCount())
+	if err != nil {
+		log.Fatalf("failed to run sim: %s", err)
+	}
+
+	output, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(finalResult)
+	if err != nil {
+		log.Fatalf("failed to marshal final results: %s", err)
+	}
+
+	if outfile == "" {
+		fmt.Print(string(output))
+	} else {
+		err = os.WriteFile(outfile, output, 0666)
+		if err != nil {
+			log.Fatalf("failed to write output file:: %s", err)
+		}
+		if verbose {
+			fmt.Printf("Wrote output file: `%s` successfully.\n", outfile)
+		}
+	}
+}
+
+// The shard count from the flags, or 4 shards per worker slot so slots finishing early
+// can pick up more work.
+func defaultShardCount() int32 {
+	if shardCount > 0 {
+		return shardCount
+	}
+	return int32(4 * workerSlots * len(workerAddrs))
+}
+
+type simShard struct {
+	idx      int
+	request  *proto.RaidSimRequest
+	attempts int
+}
+
+// Splits the request into shards, runs them on the workers and merges the results in
+// shard order. Each worker is sent up to workerSlots shards at once. Failed shards are
+// retried on any worker, and a worker which keeps failing stops taking shards.
+func coordinateSim(input *proto.RaidSimRequest, workers []string, shards int32) (*proto.RaidSimResult, error) {
+	splitRes := core.SplitSimRequestForConcurrency(input, shards)
+	if splitRes.ErrorResult != "" {
+		return nil, fmt.Errorf("%s", splitRes.ErrorResult)
+	}
+
+	pending := make(chan *simShard, len(splitRes.Requests))
+	for i, req := range splitRes.Requests {
+		pending <- &simShard{idx: i, request: req}
+	}
+
+	results := make([]*proto.RaidSimResult, len(splitRes.Requests))
+	remaining := len(splitRes.Requests)
+	var mu sync.Mutex
+	var fatalErr error
+	done := make(chan struct{})
+
+	finish := func(err error) {
+		if fatalErr == nil {
+			fatalErr = err
+			close(done)
+		}
+	}
+
+	client := &http.Client{Timeout: shardTimeout}
+	var wg sync.WaitGroup
+	for _, worker := range workers {
+		// Failures in a row on this worker, shared by its slots.
+		failures := 0
+		for slot := 0; slot < workerSlots; slot++ {
+			wg.Add(1)
+			go func(worker string) {
+				defer wg.Done()
+				for {
+					mu.Lock()
+					retired := failures > shardRetries
+					mu.Unlock()
+					if retired {
+						return
+					}
+
+					var shard *simShard
+					select {
+					case <-done:
+						return
+					case shard = <-pending:
+					}
+
+					result, err := runShard(client, worker, shard.request)
+					mu.Lock()
+					if err != nil {
+						shard.attempts++
+						failures++
+						log.Printf("Shard %d failed on %s: %s", shard.idx, worker, err)
+						if shard.attempts > shardRetries {
+							finish(fmt.Errorf("shard %d failed %d times, last error: %w", shard.idx, shard.attempts, err))
+						} else {
+							pending <- shard
+						}
+						if failures == shardRetries+1 {
+							log.Printf("Worker %s failed %d times in a row, not sending it more shards", worker, failures)
+						}
+						mu.Unlock()
+						continue
+					}
+					failures = 0
+
+					if result.Error != nil {
+						finish(fmt.Errorf("shard %d: %s", shard.idx, result.Error.Message))
+					} else {
+						results[shard.idx] = result
+						remaining--
+						if verbose {
+							log.Printf("Shard %d done on %s, %d left", shard.idx, worker, remaining)
+						}
+						if remaining == 0 {
+							finish(nil)
+						}
+					}
+					mu.Unlock()
+				}
+			}(worker)
+		}
+	}
+	wg.Wait()
+
+	if remaining > 0 && fatalErr == nil {
+		fatalErr = fmt.Errorf("no workers left with %d shards remaining", remaining)
+	}
+	if fatalErr != nil {
+		return nil, fatalErr
+	}
+	return core.CombineConcurrentSimResults(results, false), nil
+}
+
+func runShard(client *http.Client, worker string, request *proto.RaidSimRequest) (*proto.RaidSimResult, error) {
+	body, err := googleProto.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	url := worker
+	if !strings.Contains(url, "://") {
+		url = "http://" + url
+	}
+	resp, err := client.Post(url+"/raidSim", "application/x-protobuf", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %s", resp.Status)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	result := &proto.RaidSimResult{}
+	if err := googleProto.Unmarshal(respBody, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/isfir/wowsims-turtle/sim/core"
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+func startTestWorker(t *testing.T) string {
+	server := httptest.NewServer(http.HandlerFunc(handleShard))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestCoordinateSimIsDeterministic(t *testing.T) {
+	workers := []string{startTestWorker(t), startTestWorker(t), startTestWorker(t)}
+	request := testRaidSimRequest()
+
+	// The same shards simmed locally, one after another.
+	splitRes := core.SplitSimRequestForConcurrency(request, 6)
+	localResults := make([]*proto.RaidSimResult, len(splitRes.Requests))
+	for i, shard := range splitRes.Requests {
+		localResults[i] = core.RunRaidSim(shard)
+	}
+	expected := core.CombineConcurrentSimResults(localResults, false)
+
+	defer func(slots int) { workerSlots = slots }(workerSlots)
+	for _, run := range []struct {
+		workers []string
+		slots   int
+	}{
+		{workers[:1], 1},
+		{workers[:1], 4},
+		{workers, 2},
+	} {
+		workerSlots = run.slots
+		result, err := coordinateSim(request, run.workers, 6)
+		if err != nil {
+			t.Fatalf("Unexpected error with %d workers and %d slots: %s", len(run.workers), run.slots, err)
+		}
+		if !googleProto.Equal(result, expected) {
+			t.Fatalf("Result with %d workers and %d slots differs from the local one: %.3f dps vs %.3f dps",
+				len(run.workers), run.slots, result.RaidMetrics.Dps.Avg, expected.RaidMetrics.Dps.Avg)
+		}
+	}
+}
+
+func TestCoordinateSimRetriesOnOtherWorkers(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	defer func(slots int) { workerSlots = slots }(workerSlots)
+	workerSlots = 1
+	request := testRaidSimRequest()
+	expected, err := coordinateSim(request, []string{startTestWorker(t)}, 4)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	result, err := coordinateSim(request, []string{failing.URL, startTestWorker(t)}, 4)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !googleProto.Equal(result, expected) {
+		t.Fatalf("Result with a failing worker differs: %.3f dps vs %.3f dps", result.RaidMetrics.Dps.Avg, expected.RaidMetrics.Dps.Avg)
+	}
+
+	if _, err := coordinateSim(request, []string{failing.URL}, 4); err == nil {
+		t.Fatalf("Expected an error when every worker fails")
+	}
+}
@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/isfir/wowsims-turtle/sim/core"
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	googleProto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var gridfile string
+
+var sweepCmd = &cobra.Command{
+	Use:   "sweep",
+	Short: "simulate every combination of a grid of settings",
+	Long:  "simulate every combination of a grid of settings, and write a CSV table with a row for each player of each combination",
+	Run:   sweepMain,
+}
+
+func init() {
+	sweepCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
+	sweepCmd.Flags().StringVar(&gridfile, "gridfile", "", "location of grid file, e.g. {\"axes\": [{\"path\": \"raid.parties[0].players[0].consumes.flask\", \"values\": [\"FlaskUnknown\", \"FlaskOfSupremePower\"]}]}")
+	sweepCmd.Flags().StringVar(&outfile, "outfile", "", "location of output CSV file, defaults to stdout")
+	sweepCmd.Flags().StringSliceVar(&workerAddrs, "workers", nil, "comma separated worker addresses to run each combination on, like the coordinate command. Runs locally if empty")
+	sweepCmd.Flags().Int32Var(&shardCount, "shards", 0, "number of shards to split each combination into when using workers, defaults to 4 per worker slot")
+	sweepCmd.Flags().IntVar(&workerSlots, "slots", 8, "number of shards each worker sims at once, usually its number of cores")
+	sweepCmd.Flags().IntVar(&shardRetries, "retries", 3, "number of times a failed shard is retried before giving up")
+	sweepCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	sweepCmd.MarkFlagRequired("infile")
+	sweepCmd.MarkFlagRequired("gridfile")
+}
+
+// SweepAxis is a field of the RaidSimRequest and the values it takes in the sweep.
+// Values are in protojson format, e.g. enum names or numbers.
+type SweepAxis struct {
+	Path   string            `json:"path"`
+	Values []json.RawMessage `json:"values"`
+}
+
+type SweepInput struct {
+	Axes []SweepAxis `json:"axes"`
+}
+
+func sweepMain(cmd *cobra.Command, args []string) {
+	data, err := os.ReadFile(infile)
+	if err != nil {
+		log.Fatalf("failed to load input json file %q: %v", infile, err)
+	}
+	input := &proto.RaidSimRequest{}
+	err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, input)
+	if err != nil {
+		log.Fatalf("failed to load input json file: %s", err)
+	}
+
+	gridData, err := os.ReadFile(gridfile)
+	if err != nil {
+		log.Fatalf("failed to load grid json file: %s", err)
+	}
+	grid := &SweepInput{}
+	if err := json.Unmarshal(gridData, grid); err != nil {
+		log.Fatalf("failed to parse grid json file: %s", err)
+	}
+
+	var out io.Writer = os.Stdout
+	if outfile != "" {
+		f, err := os.Create(outfile)
+		if err != nil {
+			log.Fatalf("failed to create output file: %s", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if err := Sweep(input, grid, workerAddrs, defaultShardCount(), out); err != nil {
+		log.Fatalf("sweep failed: %s", err)
+	}
+	if verbose && outfile != "" {
+		fmt.Printf("Wrote output file: `%s` successfully.\n", outfile)
+	}
+}
+
+// Sweep sims the cartesian product of the grid's axes, writing the results as CSV.
+// Each combination is split into shards over the workers, or simmed locally if there
+// are no workers.
+func Sweep(input *proto.RaidSimRequest, grid *SweepInput, workers []string, shards int32, out io.Writer) error {
+	numPoints := 1
+	for _, axis := range grid.Axes {
+		if len(axis.Values) == 0 {
+			return fmt.Errorf("axis %s has no values", axis.Path)
+		}
+		numPoints *= len(axis.Values)
+	}
+
+	w := csv.NewWriter(out)
+	header := []string{}
+	for _, axis := range grid.Axes {
+		header = append(header, axis.Path)
+	}
+	header = append(header, "player", "dps", "dps_stderr", "tps", "hps", "oom_seconds")
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	// Each axis picks one of its values, with the last axis changing fastest.
+	choices := make([]int, len(grid.Axes))
+	for point := 0; point < numPoints; point++ {
+		request := googleProto.Clone(input).(*proto.RaidSimRequest)
+		axisValues := make([]string, len(grid.Axes))
+		for i, axis := range grid.Axes {
+			value := axis.Values[choices[i]]
+			if err := setFieldByPath(request.ProtoReflect(), axis.Path, value); err != nil {
+				return err
+			}
+			axisValues[i] = strings.Trim(string(value), "\"")
+		}
+
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Sweep point %d / %d: %s\n", point+1, numPoints, strings.Join(axisValues, ", "))
+		}
+		result, err := runSweepPoint(request, workers, shards)
+		if err != nil {
+			return fmt.Errorf("point %s: %w", strings.Join(axisValues, ", "), err)
+		}
+
+		for _, party := range result.RaidMetrics.Parties {
+			for _, player := range party.Players {
+				if player.Dps.GetAggregatorData().GetN() == 0 {
+					// Empty raid slot.
+					continue
+				}
+				row := append(append([]string{}, axisValues...),
+					player.Name,
+					formatFloat(player.Dps.Avg),
+					formatFloat(player.Dps.Stderr),
+					formatFloat(player.Threat.Avg),
+					formatFloat(player.Hps.Avg),
+					formatFloat(player.SecondsOomAvg),
+				)
+				if err := w.Write(row); err != nil {
+					return err
+				}
+			}
+		}
+		w.Flush()
+
+		for i := len(choices) - 1; i >= 0; i-- {
+			choices[i]++
+			if choices[i] < len(grid.Axes[i].Values) {
+				break
+			}
+			choices[i] = 0
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+func runSweepPoint(request *proto.RaidSimRequest, workers []string, shards int32) (*proto.RaidSimResult, error) {
+	var result *proto.RaidSimResult
+	if len(workers) > 0 {
+		var err error
+		result, err = coordinateSim(request, workers, shards)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		result = core.RunRaidSimConcurrent(request)
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("%s", result.Error.Message)
+	}
+	return result, nil
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', 3, 64)
+}
+
+// Sets the field at a path like "raid.parties[0].players[0].consumes.flask" to a
+// value in protojson format. Field names can be either the proto or the JSON names.
+func setFieldByPath(msg protoreflect.Message, path string, value json.RawMessage) error {
+	msg, fd, err := core.ResolveFieldPath(msg, path)
+	if err != nil {
+		return err
+	}
+
+	// Parse the value as the field of an otherwise empty message, so protojson
+	// handles enum names and nested messages.
+	parsed := msg.New()
+	fragment := fmt.Sprintf("{%q: %s}", fd.JSONName(), value)
+	if err := protojson.Unmarshal([]byte(fragment), parsed.Interface()); err != nil {
+		return fmt.Errorf("invalid value %s for %s: %w", value, path, err)
+	}
+	msg.Set(fd, parsed.Get(fd))
+	return nil
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	"github.com/isfir/wowsims-turtle/sim"
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+	"github.com/isfir/wowsims-turtle/sim/core/stats"
+)
+
+func init() {
+	sim.RegisterAll()
+}
+
+// A small sim of a single ungeared warrior, quick enough to run many times.
+func testRaidSimRequest() *proto.RaidSimRequest {
+	return &proto.RaidSimRequest{
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{{Players: []*proto.Player{{
+				Name:       "Warrior",
+				Class:      proto.Class_ClassWarrior,
+				Race:       proto.Race_RaceHuman,
+				Equipment:  &proto.EquipmentSpec{},
+				Consumes:   &proto.Consumes{},
+				Rotation:   &proto.APLRotation{},
+				BonusStats: &proto.UnitStats{Stats: stats.Stats{stats.AttackPower: 1000}.ToFloatArray()},
+				Spec:       &proto.Player_Warrior{Warrior: &proto.Warrior{Options: &proto.Warrior_Options{}}},
+			}}}},
+		},
+		Encounter: &proto.Encounter{
+			Duration: 60,
+			Targets:  []*proto.Target{{Stats: stats.Stats{stats.Armor: 3731}.ToFloatArray()}},
+		},
+		SimOptions: &proto.SimOptions{Iterations: 40, RandomSeed: 101},
+	}
+}
+
+func TestSetFieldByPath(t *testing.T) {
+	request := testRaidSimRequest()
+	msg := request.ProtoReflect()
+
+	for path, value := range map[string]string{
+		"raid.parties[0].players[0].consumes.flask":                  `"FlaskOfSupremePower"`,
+		"raid.parties[0].players[0].consumes.miscConsumes.jujuEmber": `true`,
+		"raid.buffs.battle_shout":                                    `2`,
+		"encounter.duration":                                         `120`,
+	} {
+		if err := setFieldByPath(msg, path, json.RawMessage(value)); err != nil {
+			t.Fatalf("Unexpected error for %s: %s", path, err)
+		}
+	}
+
+	player := request.Raid.Parties[0].Players[0]
+	if player.Consumes.Flask != proto.Flask_FlaskOfSupremePower {
+		t.Fatalf("Expected flask to be set, got %v", player.Consumes.Flask)
+	}
+	if !player.Consumes.GetMiscConsumes().GetJujuEmber() {
+		t.Fatalf("Expected Juju Ember to be set, got %v", player.Consumes)
+	}
+	if request.Raid.GetBuffs().GetBattleShout() != proto.TristateEffect_TristateEffectImproved {
+		t.Fatalf("Expected improved Battle Shout, got %v", request.Raid.GetBuffs())
+	}
+	if request.Encounter.Duration != 120 {
+		t.Fatalf("Expected a duration of 120, got %v", request.Encounter.Duration)
+	}
+
+	for path, value := range map[string]string{
+		"raid.parties[0].players[0].consumes.flask":     `"NotAFlask"`,
+		"raid.parties[0].players[0].consumes.not_field": `1`,
+		"raid.parties[1].players[0].consumes.flask":     `1`,
+		"encounter.duration":                            `"long"`,
+	} {
+		if err := setFieldByPath(msg, path, json.RawMessage(value)); err == nil {
+			t.Fatalf("Expected an error for %s = %s", path, value)
+		}
+	}
+}
+
+func TestSweep(t *testing.T) {
+	grid := &SweepInput{Axes: []SweepAxis{
+		{Path: "raid.parties[0].players[0].race", Values: []json.RawMessage{[]byte(`"RaceHuman"`), []byte(`"RaceOrc"`)}},
+		{Path: "encounter.duration", Values: []json.RawMessage{[]byte(`60`), []byte(`120`), []byte(`180`)}},
+	}}
+
+	var out bytes.Buffer
+	if err := Sweep(testRaidSimRequest(), grid, nil, 0, &out); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	rows, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read CSV: %s", err)
+	}
+
+	if len(rows) != 7 {
+		t.Fatalf("Expected a header and 6 rows, got %d rows", len(rows))
+	}
+	if rows[0][0] != "raid.parties[0].players[0].race" || rows[0][2] != "player" || rows[0][3] != "dps" {
+		t.Fatalf("Unexpected header %v", rows[0])
+	}
+	// The last axis changes fastest.
+	expected := [][2]string{{"RaceHuman", "60"}, {"RaceHuman", "120"}, {"RaceHuman", "180"}, {"RaceOrc", "60"}, {"RaceOrc", "120"}, {"RaceOrc", "180"}}
+	for i, row := range rows[1:] {
+		if row[0] != expected[i][0] || row[1] != expected[i][1] || row[2] != "Warrior" {
+			t.Fatalf("Unexpected row %d: %v", i, row)
+		}
+		if row[3] == "0.000" {
+			t.Fatalf("Expected some dps in row %d: %v", i, row)
+		}
+	}
+
+	grid.Axes[0].Values = nil
+	if err := Sweep(testRaidSimRequest(), grid, nil, 0, &out); err == nil {
+		t.Fatalf("Expected an error for an axis without values")
+	}
+}
@@ -51,6 +51,90 @@ func StatWeightsAsync(request *proto.StatWeightsRequest, progress chan *proto.Pr
 	}()
 }
 
+/**
+ * Returns the dps each buff and debuff in the request adds, for the raid and each player.
+ */
+func BuffValues(request *proto.BuffValuesRequest) *proto.BuffValuesResult {
+	return runBuffValues(request, nil, simsignals.CreateSignals())
+}
+
+func BuffValuesAsync(request *proto.BuffValuesRequest, progress chan *proto.ProgressMetrics, requestId string) {
+	signals, err := simsignals.RegisterWithId(requestId)
+	if err != nil {
+		progress <- &proto.ProgressMetrics{
+			FinalBuffValuesResult: &proto.BuffValuesResult{
+				Error: &proto.ErrorOutcome{
+					Message: "Couldn't register for signal API: " + err.Error(),
+				},
+			},
+		}
+		return
+	}
+	go func() {
+		defer simsignals.UnregisterId(requestId)
+		result := runBuffValues(request, progress, signals)
+		progress <- &proto.ProgressMetrics{
+			FinalBuffValuesResult: result,
+		}
+	}()
+}
+
+/**
+ * Runs two requests with the same seeds and returns their paired dps difference.
+ */
+func Compare(request *proto.CompareRequest) *proto.CompareResult {
+	return runCompare(request, nil, simsignals.CreateSignals())
+}
+
+func CompareAsync(request *proto.CompareRequest, progress chan *proto.ProgressMetrics, requestId string) {
+	signals, err := simsignals.RegisterWithId(requestId)
+	if err != nil {
+		progress <- &proto.ProgressMetrics{
+			FinalCompareResult: &proto.CompareResult{
+				Error: &proto.ErrorOutcome{
+					Message: "Couldn't register for signal API: " + err.Error(),
+				},
+			},
+		}
+		return
+	}
+	go func() {
+		defer simsignals.UnregisterId(requestId)
+		result := runCompare(request, progress, signals)
+		progress <- &proto.ProgressMetrics{
+			FinalCompareResult: result,
+		}
+	}()
+}
+
+/**
+ * Sims the player at each amount of a stat in a range and returns the dps curve.
+ */
+func StatScaling(request *proto.StatScalingRequest) *proto.StatScalingResult {
+	return runStatScaling(request, nil, simsignals.CreateSignals())
+}
+
+func StatScalingAsync(request *proto.StatScalingRequest, progress chan *proto.ProgressMetrics, requestId string) {
+	signals, err := simsignals.RegisterWithId(requestId)
+	if err != nil {
+		progress <- &proto.ProgressMetrics{
+			FinalStatScalingResult: &proto.StatScalingResult{
+				Error: &proto.ErrorOutcome{
+					Message: "Couldn't register for signal API: " + err.Error(),
+				},
+			},
+		}
+		return
+	}
+	go func() {
+		defer simsignals.UnregisterId(requestId)
+		result := runStatScaling(request, progress, signals)
+		progress <- &proto.ProgressMetrics{
+			FinalStatScalingResult: result,
+		}
+	}()
+}
+
 // Get data for all requests needed for stat weights.
 func StatWeightRequests(request *proto.StatWeightsRequest) *proto.StatWeightRequestsData {
 	return buildStatWeightRequests(request)
@@ -131,6 +215,24 @@ func RunBulkSimAsync(request *proto.BulkSimRequest, progress chan *proto.Progres
 	}()
 }
 
+/**
+ * Starts a single-iteration sim which pauses whenever the chosen player needs to act.
+ */
+func StartInteractiveSim(request *proto.InteractiveSimStartRequest) *proto.InteractiveSimState {
+	return startInteractiveSim(request)
+}
+
+/**
+ * Submits one action to an interactive sim and resumes it until the next decision.
+ */
+func StepInteractiveSim(request *proto.InteractiveSimStepRequest) *proto.InteractiveSimState {
+	return stepInteractiveSim(request)
+}
+
+func GetInteractiveSimState(request *proto.InteractiveSimStateRequest) *proto.InteractiveSimState {
+	return getInteractiveSimState(request)
+}
+
 var runningInWasm = false
 
 func SetRunningInWasm() {
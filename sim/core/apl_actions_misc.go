@@ -28,7 +28,8 @@ func (rot *APLRotation) newActionChangeTarget(config *proto.APLActionChangeTarge
 	}
 }
 func (action *APLActionChangeTarget) IsReady(sim *Simulation) bool {
-	return action.unit.CurrentTarget != action.newTarget.Get()
+	newTarget := action.newTarget.Get()
+	return action.unit.CurrentTarget != newTarget && newTarget.IsEnabled()
 }
 func (action *APLActionChangeTarget) Execute(sim *Simulation) {
 	if sim.Log != nil {
@@ -219,6 +219,34 @@ func (value *APLValueCurrentEnergy) String() string {
 	return "Current Energy"
 }
 
+type APLValueCurrentFocus struct {
+	DefaultAPLValueImpl
+	unit UnitReference
+}
+
+func (rot *APLRotation) newValueCurrentFocus(config *proto.APLValueCurrentFocus) APLValue {
+	unit := rot.GetSourceUnit(config.SourceUnit)
+	if unit.Get() == nil {
+		return nil
+	}
+	if !unit.Get().HasFocusBar() {
+		rot.ValidationWarning("%s does not use Focus", unit.Get().Label)
+		return nil
+	}
+	return &APLValueCurrentFocus{
+		unit: unit,
+	}
+}
+func (value *APLValueCurrentFocus) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *APLValueCurrentFocus) GetFloat(_ *Simulation) float64 {
+	return value.unit.Get().CurrentFocus()
+}
+func (value *APLValueCurrentFocus) String() string {
+	return "Current Focus"
+}
+
 type APLValueCurrentComboPoints struct {
 	DefaultAPLValueImpl
 	unit *Unit
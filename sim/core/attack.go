@@ -372,7 +372,7 @@ func (wa *WeaponAttack) swing(sim *Simulation) time.Duration {
 			sim.rescheduleWeaponAttack(wa.swingAt) // Required to fix extra attack procs triggered during swing
 		}
 
-		if !sim.Options.Interactive && wa.unit.Rotation != nil {
+		if !sim.IsInteractive(wa.unit) && wa.unit.Rotation != nil {
 			wa.unit.Rotation.DoNextAction(sim)
 		}
 	} else {
@@ -84,6 +84,9 @@ type Aura struct {
 
 	ExclusiveEffects []*ExclusiveEffect
 
+	// Damage modifier of this aura whose contribution is tracked, if any.
+	damageAttribution *DamageAttribution
+
 	// Lifecycle callbacks.
 	OnInit          OnInit
 	OnReset         OnReset
@@ -179,6 +182,9 @@ func (aura *Aura) Refresh(sim *Simulation) {
 	if sim.Log != nil && aura.IsActive() && !aura.ActionID.IsEmptyAction() {
 		aura.Unit.Log(sim, "Aura refreshed: %s", aura.ActionID)
 	}
+	if sim.combatLog != nil && aura.IsActive() {
+		aura.logCombatEvent(sim, proto.CombatLogEventType_CombatLogEventAuraRefreshed, 0)
+	}
 }
 
 func (aura *Aura) GetStacks() int32 {
@@ -209,6 +215,9 @@ func (aura *Aura) SetStacks(sim *Simulation, newStacks int32) {
 		aura.Unit.Log(sim, "%s stacks: %d --> %d", aura.ActionID, oldStacks, newStacks)
 	}
 	aura.stacks = newStacks
+	if sim.combatLog != nil {
+		aura.logCombatEvent(sim, proto.CombatLogEventType_CombatLogEventAuraStacks, oldStacks)
+	}
 	if aura.OnStacksChange != nil {
 		aura.OnStacksChange(aura, sim, oldStacks, newStacks)
 	}
@@ -544,6 +553,16 @@ restart:
 	at.minExpires = NeverExpires
 }
 
+func (at *auraTracker) expireTemporary(sim *Simulation) {
+restart:
+	for _, aura := range at.activeAuras {
+		if !aura.IsPermanent() {
+			aura.Deactivate(sim)
+			goto restart
+		}
+	}
+}
+
 func (at *auraTracker) doneIteration(sim *Simulation) {
 	// deactivate all auras, even permanent ones
 restart:
@@ -559,7 +578,7 @@ restart:
 	}
 
 	for _, aura := range at.auras {
-		aura.metrics.doneIteration()
+		aura.metrics.doneIteration(sim)
 	}
 }
 
@@ -653,6 +672,9 @@ func (aura *Aura) Activate(sim *Simulation) {
 	if sim.Log != nil && !aura.ActionID.IsEmptyAction() {
 		aura.Unit.Log(sim, "Aura gained: %s", aura.ActionID)
 	}
+	if sim.combatLog != nil {
+		aura.logCombatEvent(sim, proto.CombatLogEventType_CombatLogEventAuraApplied, 0)
+	}
 
 	// don't invoke possible callbacks until the internal state is consistent
 	if aura.OnGain != nil {
@@ -672,9 +694,15 @@ func (aura *Aura) Deactivate(sim *Simulation) {
 		oldTime := sim.CurrentTime
 		sim.CurrentTime = min(sim.CurrentTime, aura.expires)
 		aura.metrics.Uptime += sim.CurrentTime - max(aura.startTime, 0)
+		if sim.timelineBucketSize > 0 {
+			aura.metrics.timeline.addSpan(sim, aura.startTime, sim.CurrentTime)
+		}
 		if sim.Log != nil {
 			aura.Unit.Log(sim, "Aura faded: %s", aura.ActionID)
 		}
+		if sim.combatLog != nil {
+			aura.logCombatEvent(sim, proto.CombatLogEventType_CombatLogEventAuraRemoved, 0)
+		}
 		sim.CurrentTime = oldTime
 	}
 
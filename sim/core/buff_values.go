@@ -0,0 +1,144 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+	"github.com/isfir/wowsims-turtle/sim/core/simsignals"
+	googleProto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type buffToggle struct {
+	field   string
+	request *proto.RaidSimRequest
+}
+
+// Returns a request for each buff, debuff or consumable field which is set in the base
+// request, with only that field cleared.
+func buildBuffToggles(request *proto.RaidSimRequest) []buffToggle {
+	var toggles []buffToggle
+
+	addToggles := func(path string, getBuffs func(*proto.RaidSimRequest) protoreflect.Message) {
+		buffs := getBuffs(request)
+		fields := buffs.Descriptor().Fields()
+		for i := 0; i < fields.Len(); i++ {
+			fd := fields.Get(i)
+			if !buffs.Has(fd) {
+				continue
+			}
+
+			toggled := googleProto.Clone(request).(*proto.RaidSimRequest)
+			getBuffs(toggled).Clear(fd)
+			toggles = append(toggles, buffToggle{
+				field:   path + "." + string(fd.Name()),
+				request: toggled,
+			})
+		}
+	}
+
+	addToggles("raid.buffs", func(r *proto.RaidSimRequest) protoreflect.Message {
+		return r.Raid.GetBuffs().ProtoReflect()
+	})
+	addToggles("raid.debuffs", func(r *proto.RaidSimRequest) protoreflect.Message {
+		return r.Raid.GetDebuffs().ProtoReflect()
+	})
+	for partyIdx, party := range request.Raid.GetParties() {
+		addToggles(fmt.Sprintf("raid.parties[%d].buffs", partyIdx), func(r *proto.RaidSimRequest) protoreflect.Message {
+			return r.Raid.Parties[partyIdx].GetBuffs().ProtoReflect()
+		})
+		for playerIdx := range party.Players {
+			addToggles(fmt.Sprintf("raid.parties[%d].players[%d].buffs", partyIdx, playerIdx), func(r *proto.RaidSimRequest) protoreflect.Message {
+				return r.Raid.Parties[partyIdx].Players[playerIdx].GetBuffs().ProtoReflect()
+			})
+			addToggles(fmt.Sprintf("raid.parties[%d].players[%d].consumes", partyIdx, playerIdx), func(r *proto.RaidSimRequest) protoreflect.Message {
+				return r.Raid.Parties[partyIdx].Players[playerIdx].GetConsumes().ProtoReflect()
+			})
+		}
+	}
+
+	return toggles
+}
+
+func computeBuffValue(field string, base *proto.RaidSimResult, toggled *proto.RaidSimResult) *proto.BuffValue {
+	// The deltas are base minus toggled, so the toggled result is the one compared against.
+	raidDiff := pairedDifference(toggled.RaidMetrics.Dps, base.RaidMetrics.Dps, 0.95)
+	value := &proto.BuffValue{
+		Field:              field,
+		RaidDpsDelta:       raidDiff.Mean,
+		RaidDpsDeltaStderr: raidDiff.Stderr,
+	}
+
+	for partyIdx, party := range base.RaidMetrics.Parties {
+		for playerIdx, player := range party.Players {
+			if player.Dps.GetAggregatorData().GetN() == 0 {
+				// Empty raid slot.
+				continue
+			}
+
+			diff := pairedDifference(toggled.RaidMetrics.Parties[partyIdx].Players[playerIdx].Dps, player.Dps, 0.95)
+			value.Players = append(value.Players, &proto.PlayerDpsDelta{
+				Name:           player.Name,
+				DpsDelta:       diff.Mean,
+				DpsDeltaStderr: diff.Stderr,
+			})
+		}
+	}
+
+	return value
+}
+
+// Rerun the sim with each buff and consumable turned off and compute their values.
+func runBuffValues(request *proto.BuffValuesRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) *proto.BuffValuesResult {
+	if request.RaidSimRequest == nil {
+		return &proto.BuffValuesResult{Error: &proto.ErrorOutcome{Message: "No raid sim request!"}}
+	}
+
+	baseRequest := googleProto.Clone(request.RaidSimRequest).(*proto.RaidSimRequest)
+	if baseRequest.SimOptions == nil {
+		baseRequest.SimOptions = &proto.SimOptions{}
+	}
+	baseRequest.SimOptions.SaveAllValues = true
+	// Every sim needs the same seed, so that their iterations can be paired.
+	if baseRequest.SimOptions.RandomSeed == 0 {
+		baseRequest.SimOptions.RandomSeed = time.Now().UnixNano()
+	}
+	// Keeps the toggled sims' rolls lined up with the base sim's.
+	baseRequest.SimOptions.UseLabeledRands = true
+
+	toggles := buildBuffToggles(baseRequest)
+
+	sp := &simSeriesProgress{
+		progress:        progress,
+		iterationsTotal: baseRequest.SimOptions.Iterations * int32(len(toggles)+1),
+		simsTotal:       int32(len(toggles) + 1),
+	}
+
+	simFunc := runSimConcurrent
+	// Don't use go threads in wasm, it just adds more overhead and makes the worker more unresponsive.
+	if IsRunningInWasm() || baseRequest.SimOptions.IsTest {
+		simFunc = RunSim
+	}
+
+	baseProgress := make(chan *proto.ProgressMetrics, 100)
+	go simFunc(baseRequest, baseProgress, signals)
+	baseResult := sp.waitForResult(baseProgress)
+	if baseResult.Error != nil {
+		return &proto.BuffValuesResult{Error: baseResult.Error}
+	}
+
+	result := &proto.BuffValuesResult{}
+	for _, toggle := range toggles {
+		toggledProgress := make(chan *proto.ProgressMetrics, 100)
+		go simFunc(toggle.request, toggledProgress, signals)
+		toggledResult := sp.waitForResult(toggledProgress)
+		if toggledResult.Error != nil {
+			return &proto.BuffValuesResult{Error: toggledResult.Error}
+		}
+
+		result.Values = append(result.Values, computeBuffValue(toggle.field, baseResult, toggledResult))
+	}
+
+	return result
+}
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+)
+
+func TestBuildBuffToggles(t *testing.T) {
+	request := &proto.RaidSimRequest{
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{
+				{
+					Players: []*proto.Player{
+						{Buffs: &proto.IndividualBuffs{}},
+						{
+							Buffs:    &proto.IndividualBuffs{BlessingOfKings: true, Innervates: 2},
+							Consumes: &proto.Consumes{Flask: proto.Flask_FlaskOfTheTitans},
+						},
+					},
+					Buffs: &proto.PartyBuffs{},
+				},
+			},
+			Buffs: &proto.RaidBuffs{
+				GiftOfTheWild: proto.TristateEffect_TristateEffectImproved,
+			},
+			Debuffs: &proto.Debuffs{
+				CurseOfShadow: true,
+			},
+		},
+	}
+
+	toggles := buildBuffToggles(request)
+
+	var fields []string
+	for _, toggle := range toggles {
+		fields = append(fields, toggle.field)
+	}
+	want := []string{
+		"raid.buffs.gift_of_the_wild",
+		"raid.debuffs.curse_of_shadow",
+		"raid.parties[0].players[1].buffs.blessing_of_kings",
+		"raid.parties[0].players[1].buffs.innervates",
+		"raid.parties[0].players[1].consumes.flask",
+	}
+	if !slices.Equal(fields, want) {
+		t.Fatalf("Expected toggled fields %v, got %v", want, fields)
+	}
+
+	// Only the toggled field is cleared, and the base request is unchanged.
+	kings := toggles[2].request.Raid.Parties[0].Players[1].Buffs
+	if kings.BlessingOfKings || kings.Innervates != 2 || !toggles[2].request.Raid.Debuffs.CurseOfShadow {
+		t.Fatalf("Unexpected toggled request: %v", toggles[2].request)
+	}
+	if flask := toggles[4].request.Raid.Parties[0].Players[1].Consumes.Flask; flask != proto.Flask_FlaskUnknown {
+		t.Fatalf("Expected the flask to be toggled off, got %v", flask)
+	}
+	if !request.Raid.Parties[0].Players[1].Buffs.BlessingOfKings {
+		t.Fatalf("Base request was modified")
+	}
+}
+
+func TestComputeBuffValue(t *testing.T) {
+	newResult := func(avg float64, values []float64) *proto.RaidSimResult {
+		dps := &proto.DistributionMetrics{Avg: avg, AllValues: values, AggregatorData: &proto.AggregatorData{N: int32(len(values))}}
+		return &proto.RaidSimResult{
+			RaidMetrics: &proto.RaidMetrics{
+				Dps: dps,
+				Parties: []*proto.PartyMetrics{
+					{Players: []*proto.UnitMetrics{{Name: "Player", Dps: dps}, {Dps: &proto.DistributionMetrics{}}}},
+				},
+			},
+		}
+	}
+
+	// Every iteration loses exactly 5 dps, so there is no error despite the spread.
+	base := newResult(102.5, []float64{100, 110, 90, 110})
+	value := computeBuffValue("raid.buffs.gift_of_the_wild", base, newResult(97.5, []float64{95, 105, 85, 105}))
+	if value.RaidDpsDelta != 5 || value.RaidDpsDeltaStderr != 0 {
+		t.Fatalf("Expected delta 5 with no error, got %0.3f +/- %0.3f", value.RaidDpsDelta, value.RaidDpsDeltaStderr)
+	}
+	if len(value.Players) != 1 || value.Players[0].Name != "Player" || value.Players[0].DpsDelta != 5 {
+		t.Fatalf("Expected a single player losing 5 dps, got %v", value.Players)
+	}
+
+	// Differences are 5, 5, 0, 5: stdev sqrt(75/16 - 3.75^2) = 2.165, over sqrt(4).
+	value = computeBuffValue("raid.buffs.gift_of_the_wild", base, newResult(98.75, []float64{95, 105, 90, 105}))
+	if value.RaidDpsDelta != 3.75 || math.Abs(value.RaidDpsDeltaStderr-math.Sqrt(75.0/16-3.75*3.75)/2) > 1e-9 {
+		t.Fatalf("Unexpected delta %0.3f +/- %0.3f", value.RaidDpsDelta, value.RaidDpsDeltaStderr)
+	}
+}
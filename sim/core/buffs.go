@@ -84,9 +84,6 @@ var BuffSpellValues = map[BuffName]stats.Stats{
 	DevotionAura: {
 		stats.BonusArmor: 735,
 	},
-	GraceOfAir: {
-		stats.Agility: TernaryFloat64(IncludeAQ, 77, 67),
-	},
 	FireResistanceAura: {
 		stats.FireResistance: 60,
 	},
@@ -99,9 +96,6 @@ var BuffSpellValues = map[BuffName]stats.Stats{
 	FrostResistanceTotem: {
 		stats.FrostResistance: 60,
 	},
-	ManaSpring: {
-		stats.MP5: 25,
-	},
 	MarkOfTheWild: {
 		stats.BonusArmor:       285,
 		stats.Stamina:          12,
@@ -127,9 +121,6 @@ var BuffSpellValues = map[BuffName]stats.Stats{
 	ShadowResistanceAura: {
 		stats.ShadowResistance: 60,
 	},
-	StrengthOfEarth: {
-		stats.Strength: TernaryFloat64(IncludeAQ, 77, 61),
-	},
 	ScrollOfAgility: {
 		stats.Agility: 17,
 	},
@@ -340,7 +331,7 @@ func applyBuffEffects(agent Agent, playerFaction proto.Faction, raidBuffs *proto
 	}
 
 	if raidBuffs.StoneskinTotem != proto.TristateEffect_TristateEffectMissing && isHorde {
-		MakePermanent(StoneskinTotemAura(&character.Unit, GetTristateValueInt32(raidBuffs.StoneskinTotem, 0, 2)))
+		MakePermanent(StoneskinTotemAura(&character.Unit, "Stoneskin", GetTristateValueInt32(raidBuffs.StoneskinTotem, 0, 2)))
 	}
 
 	if raidBuffs.RetributionAura != proto.TristateEffect_TristateEffectMissing && isAlliance {
@@ -357,12 +348,12 @@ func applyBuffEffects(agent Agent, playerFaction proto.Faction, raidBuffs *proto
 
 	if raidBuffs.StrengthOfEarthTotem != proto.TristateEffect_TristateEffectMissing && isHorde {
 		multiplier := GetTristateValueFloat(raidBuffs.StrengthOfEarthTotem, 1, 1.15)
-		MakePermanent(StrengthOfEarthTotemAura(&character.Unit, multiplier))
+		MakePermanent(StrengthOfEarthTotemAura(&character.Unit, "Strength of Earth Totem", TernaryInt32(IncludeAQ, 5, 4), multiplier))
 	}
 
 	if raidBuffs.GraceOfAirTotem > 0 && isHorde {
 		multiplier := GetTristateValueFloat(raidBuffs.GraceOfAirTotem, 1, 1.15)
-		MakePermanent(GraceOfAirTotemAura(&character.Unit, multiplier))
+		MakePermanent(GraceOfAirTotemAura(&character.Unit, "Grace of Air Totem", TernaryInt32(IncludeAQ, 3, 2), multiplier))
 	}
 
 	if individualBuffs.BlessingOfWisdom > 0 && isAlliance {
@@ -372,11 +363,8 @@ func applyBuffEffects(agent Agent, playerFaction proto.Faction, raidBuffs *proto
 		}
 		character.AddStats(updateStats)
 	} else if raidBuffs.ManaSpringTotem > 0 && isHorde {
-		updateStats := BuffSpellValues[ManaSpring]
-		if raidBuffs.ManaSpringTotem == proto.TristateEffect_TristateEffectImproved {
-			updateStats = updateStats.Multiply(1.25)
-		}
-		character.AddStats(updateStats)
+		multiplier := GetTristateValueFloat(raidBuffs.ManaSpringTotem, 1, 1.25)
+		MakePermanent(ManaSpringTotemAura(&character.Unit, "Mana Spring Totem", ManaSpringTotemRanks, multiplier))
 	}
 
 	if raidBuffs.BattleSquawk > 0 {
@@ -474,6 +462,7 @@ func applyPetBuffEffects(petAgent PetAgent, playerFaction proto.Faction, raidBuf
 }
 
 func SanctityAuraAura(character *Character) *Aura {
+	holyMultiplier := 1.1
 	return character.GetOrRegisterAura(Aura{
 		Label:    "Sanctity Aura",
 		ActionID: ActionID{SpellID: 20218},
@@ -482,12 +471,12 @@ func SanctityAuraAura(character *Character) *Aura {
 			aura.Activate(sim)
 		},
 		OnGain: func(aura *Aura, sim *Simulation) {
-			character.PseudoStats.SchoolDamageDealtMultiplier[stats.SchoolIndexHoly] *= 1.1
+			character.PseudoStats.SchoolDamageDealtMultiplier[stats.SchoolIndexHoly] *= holyMultiplier
 		},
 		OnExpire: func(aura *Aura, sim *Simulation) {
-			character.PseudoStats.SchoolDamageDealtMultiplier[stats.SchoolIndexHoly] /= 1.1
+			character.PseudoStats.SchoolDamageDealtMultiplier[stats.SchoolIndexHoly] /= holyMultiplier
 		},
-	})
+	}).AttachDamageAttribution(DamageAttribution{Multiplier: holyMultiplier, School: SpellSchoolHoly})
 }
 
 func BlessingOfKingsAura(character *Character) *Aura {
@@ -573,25 +562,28 @@ func DevotionAuraAura(unit *Unit, points int32) *Aura {
 	})
 }
 
-func StoneskinTotemAura(unit *Unit, points int32) *Aura {
+// Registers the Stoneskin Totem buff. Only the value of the highest rank is known, so
+// every rank uses it.
+func StoneskinTotemAura(unit *Unit, label string, points int32) *Aura {
 	meleeDamageReduction := -30.0
 	meleeDamageReduction *= 1 + .1*float64(points)
 	meleeDamageReduction = math.Floor(meleeDamageReduction)
 
-	return unit.GetOrRegisterAura(Aura{
-		Label:    "Stoneskin",
+	aura := unit.GetOrRegisterAura(Aura{
+		Label:    label,
 		ActionID: ActionID{SpellID: 10408},
-		Duration: NeverExpires,
-		OnReset: func(aura *Aura, sim *Simulation) {
-			aura.Activate(sim)
-		},
-		OnGain: func(aura *Aura, sim *Simulation) {
-			aura.Unit.PseudoStats.BonusDamageTakenAfterModifiers[DefenseTypeMelee] += meleeDamageReduction
+		Duration: time.Minute * 2,
+	})
+	aura.NewExclusiveEffect("StoneskinTotem", false, ExclusiveEffect{
+		Priority: -meleeDamageReduction,
+		OnGain: func(ee *ExclusiveEffect, sim *Simulation) {
+			ee.Aura.Unit.PseudoStats.BonusDamageTakenAfterModifiers[DefenseTypeMelee] += meleeDamageReduction
 		},
-		OnExpire: func(aura *Aura, sim *Simulation) {
-			aura.Unit.PseudoStats.BonusDamageTakenAfterModifiers[DefenseTypeMelee] += meleeDamageReduction
+		OnExpire: func(ee *ExclusiveEffect, sim *Simulation) {
+			ee.Aura.Unit.PseudoStats.BonusDamageTakenAfterModifiers[DefenseTypeMelee] -= meleeDamageReduction
 		},
 	})
+	return aura
 }
 
 func RetributionAura(character *Character, points int32) *Aura {
@@ -1338,59 +1330,106 @@ const ReplenishmentAuraDuration = time.Second * 15
 	})
 } */
 
-func StrengthOfEarthTotemAura(unit *Unit, multiplier float64) *Aura {
-	rank := TernaryInt32(IncludeAQ, 5, 4)
-	spellID := []int32{0, 8075, 8160, 8161, 10442, 25361}[rank]
-	duration := time.Minute * 2
-	updateStats := BuffSpellValues[StrengthOfEarth].Multiply(multiplier).Floor()
+const (
+	StrengthOfEarthTotemRanks = 5
+	GraceOfAirTotemRanks      = 3
+	ManaSpringTotemRanks      = 4
+)
 
-	aura := unit.GetOrRegisterAura(Aura{
-		Label:      "Strength of Earth Totem",
-		ActionID:   ActionID{SpellID: spellID},
-		Duration:   duration,
-		BuildPhase: CharacterBuildPhaseBuffs,
-		OnGain: func(aura *Aura, sim *Simulation) {
-			if aura.Unit.Env.MeasuringStats && aura.Unit.Env.State != Finalized {
-				unit.AddStats(updateStats)
+var (
+	StrengthOfEarthTotemBuffSpellId = [StrengthOfEarthTotemRanks + 1]int32{0, 8075, 8160, 8161, 10442, 25361}
+	StrengthOfEarthTotemBonusStr    = [StrengthOfEarthTotemRanks + 1]float64{0, 10, 20, 36, 61, 77}
+
+	GraceOfAirTotemBuffSpellId = [GraceOfAirTotemRanks + 1]int32{0, 8835, 10627, 25359}
+	GraceOfAirTotemBonusAgi    = [GraceOfAirTotemRanks + 1]float64{0, 43, 67, 77}
+
+	ManaSpringTotemBuffSpellId = [ManaSpringTotemRanks + 1]int32{0, 5675, 10495, 10496, 10497}
+	ManaSpringTotemManaRestore = [ManaSpringTotemRanks + 1]float64{0, 4, 6, 8, 10} // Every 2 seconds
+)
+
+// Adds the stats of a totem buff through an exclusive effect, so buffs from several
+// totems of the same kind don't stack and only the strongest one applies.
+func makeExclusiveTotemBuff(aura *Aura, category string, updateStats stats.Stats) {
+	totalStats := 0.0
+	for _, amount := range updateStats {
+		totalStats += amount
+	}
+
+	aura.NewExclusiveEffect(category, false, ExclusiveEffect{
+		Priority: totalStats,
+		OnGain: func(ee *ExclusiveEffect, sim *Simulation) {
+			if ee.Aura.Unit.Env.MeasuringStats && ee.Aura.Unit.Env.State != Finalized {
+				ee.Aura.Unit.AddStats(updateStats)
 			} else {
-				unit.AddStatsDynamic(sim, updateStats)
+				ee.Aura.Unit.AddStatsDynamic(sim, updateStats)
 			}
 		},
-		OnExpire: func(aura *Aura, sim *Simulation) {
-			if aura.Unit.Env.MeasuringStats && aura.Unit.Env.State != Finalized {
-				unit.AddStats(updateStats.Multiply(-1))
+		OnExpire: func(ee *ExclusiveEffect, sim *Simulation) {
+			if ee.Aura.Unit.Env.MeasuringStats && ee.Aura.Unit.Env.State != Finalized {
+				ee.Aura.Unit.AddStats(updateStats.Multiply(-1))
 			} else {
-				unit.AddStatsDynamic(sim, updateStats.Multiply(-1))
+				ee.Aura.Unit.AddStatsDynamic(sim, updateStats.Multiply(-1))
 			}
 		},
 	})
+}
+
+func StrengthOfEarthTotemAura(unit *Unit, label string, rank int32, multiplier float64) *Aura {
+	updateStats := stats.Stats{stats.Strength: StrengthOfEarthTotemBonusStr[rank]}.Multiply(multiplier).Floor()
+
+	aura := unit.GetOrRegisterAura(Aura{
+		Label:      label,
+		ActionID:   ActionID{SpellID: StrengthOfEarthTotemBuffSpellId[rank]},
+		Duration:   time.Minute * 2,
+		BuildPhase: CharacterBuildPhaseBuffs,
+	})
+	makeExclusiveTotemBuff(aura, "StrengthOfEarthTotem", updateStats)
 	return aura
 }
 
-func GraceOfAirTotemAura(unit *Unit, multiplier float64) *Aura {
-	rank := TernaryInt32(IncludeAQ, 3, 2)
-	spellID := []int32{0, 8835, 10627, 25359}[rank]
-	duration := time.Minute * 2
-	updateStats := BuffSpellValues[GraceOfAir].Multiply(multiplier).Floor()
+func GraceOfAirTotemAura(unit *Unit, label string, rank int32, multiplier float64) *Aura {
+	updateStats := stats.Stats{stats.Agility: GraceOfAirTotemBonusAgi[rank]}.Multiply(multiplier).Floor()
 
 	aura := unit.GetOrRegisterAura(Aura{
-		Label:      "Grace of Air Totem",
-		ActionID:   ActionID{SpellID: spellID},
-		Duration:   duration,
+		Label:      label,
+		ActionID:   ActionID{SpellID: GraceOfAirTotemBuffSpellId[rank]},
+		Duration:   time.Minute * 2,
 		BuildPhase: CharacterBuildPhaseBuffs,
-		OnGain: func(aura *Aura, sim *Simulation) {
-			if aura.Unit.Env.MeasuringStats && aura.Unit.Env.State != Finalized {
-				unit.AddStats(updateStats)
-			} else {
-				unit.AddStatsDynamic(sim, updateStats)
-			}
+	})
+	makeExclusiveTotemBuff(aura, "GraceOfAirTotem", updateStats)
+	return aura
+}
+
+func ManaSpringTotemAura(unit *Unit, label string, rank int32, multiplier float64) *Aura {
+	updateStats := stats.Stats{stats.MP5: ManaSpringTotemManaRestore[rank] * 2.5 * multiplier}
+
+	aura := unit.GetOrRegisterAura(Aura{
+		Label:      label,
+		ActionID:   ActionID{SpellID: ManaSpringTotemBuffSpellId[rank]},
+		Duration:   time.Minute,
+		BuildPhase: CharacterBuildPhaseBuffs,
+	})
+	makeExclusiveTotemBuff(aura, "ManaSpringTotem", updateStats)
+	return aura
+}
+
+var TranquilAirTotemBuffSpellId = int32(25909)
+
+const TranquilAirTotemThreatMultiplier = 0.8
+
+func TranquilAirTotemAura(unit *Unit, label string) *Aura {
+	aura := unit.GetOrRegisterAura(Aura{
+		Label:    label,
+		ActionID: ActionID{SpellID: TranquilAirTotemBuffSpellId},
+		Duration: time.Minute * 2,
+	})
+	aura.NewExclusiveEffect("TranquilAirTotem", false, ExclusiveEffect{
+		Priority: 1 - TranquilAirTotemThreatMultiplier,
+		OnGain: func(ee *ExclusiveEffect, sim *Simulation) {
+			ee.Aura.Unit.PseudoStats.ThreatMultiplier *= TranquilAirTotemThreatMultiplier
 		},
-		OnExpire: func(aura *Aura, sim *Simulation) {
-			if aura.Unit.Env.MeasuringStats && aura.Unit.Env.State != Finalized {
-				unit.AddStats(updateStats.Multiply(-1))
-			} else {
-				unit.AddStatsDynamic(sim, updateStats.Multiply(-1))
-			}
+		OnExpire: func(ee *ExclusiveEffect, sim *Simulation) {
+			ee.Aura.Unit.PseudoStats.ThreatMultiplier /= TranquilAirTotemThreatMultiplier
 		},
 	})
 	return aura
@@ -1526,6 +1565,16 @@ func BattleSquawkAura(character *Unit, stackcount int32) *Aura {
 // }
 
 func CreateExtraAttackAuraCommon(character *Character, buffActionID ActionID, auraLabel string, rank int32, getBonusAP func(aura *Aura, rank int32) float64) *Aura {
+	procAura, apBuffAura := registerExtraAttackAura(character, buffActionID, Aura{Label: auraLabel}, rank, getBonusAP)
+	MakePermanent(procAura)
+	return apBuffAura
+}
+
+// Registers the aura which procs the extra attacks from the given config, and the AP
+// buff aura, without activating them. The proc aura only procs while it's the active
+// effect of all its exclusive effects.
+func registerExtraAttackAura(character *Character, buffActionID ActionID, config Aura, rank int32, getBonusAP func(aura *Aura, rank int32) float64) (*Aura, *Aura) {
+	auraLabel := config.Label
 	var bonusAP float64
 
 	apBuffAura := character.GetOrRegisterAura(Aura{
@@ -1559,36 +1608,39 @@ func CreateExtraAttackAuraCommon(character *Character, buffActionID ActionID, au
 
 	apBuffAura.Icd = &icd
 
-	MakePermanent(character.GetOrRegisterAura(Aura{
-		Label: auraLabel,
-		OnSpellHitDealt: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
-			// charges are removed by every auto or next melee, whether it lands or not
-			//  this directly contradicts https://github.com/magey/classic-warrior/wiki/Windfury-Totem#triggered-by-melee-spell-while-an-on-next-swing-attack-is-queued
-			//  but can be seen in both "vanilla" and "sod" era logs
-			if apBuffAura.IsActive() && spell.ProcMask.Matches(ProcMaskMeleeWhiteHit) {
-				apBuffAura.RemoveStack(sim)
-			}
+	config.OnSpellHitDealt = func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
+		// charges are removed by every auto or next melee, whether it lands or not
+		//  this directly contradicts https://github.com/magey/classic-warrior/wiki/Windfury-Totem#triggered-by-melee-spell-while-an-on-next-swing-attack-is-queued
+		//  but can be seen in both "vanilla" and "sod" era logs
+		if apBuffAura.IsActive() && spell.ProcMask.Matches(ProcMaskMeleeWhiteHit) {
+			apBuffAura.RemoveStack(sim)
+		}
+
+		if !result.Landed() || !spell.ProcMask.Matches(ProcMaskMeleeMH) || spell.Flags.Matches(SpellFlagSuppressEquipProcs) {
+			return
+		}
 
-			if !result.Landed() || !spell.ProcMask.Matches(ProcMaskMeleeMH) || spell.Flags.Matches(SpellFlagSuppressEquipProcs) {
+		for _, ee := range aura.ExclusiveEffects {
+			if !ee.IsActive() {
 				return
 			}
+		}
 
-			if icd.IsReady(sim) && sim.RandomFloat(auraLabel) < 0.2 {
-				icd.Use(sim)
-				apBuffAura.Activate(sim)
-				// aura is up _before_ the triggering swing lands, so if triggered by an auto attack, the aura fades right after the extra attack lands.
-				if spell.ProcMask == ProcMaskMeleeMHAuto {
-					apBuffAura.SetStacks(sim, 1)
-				} else {
-					apBuffAura.SetStacks(sim, 2)
-				}
-
-				aura.Unit.AutoAttacks.ExtraMHAttackProc(sim, 1, buffActionID, spell)
+		if icd.IsReady(sim) && sim.RandomFloat(auraLabel) < 0.2 {
+			icd.Use(sim)
+			apBuffAura.Activate(sim)
+			// aura is up _before_ the triggering swing lands, so if triggered by an auto attack, the aura fades right after the extra attack lands.
+			if spell.ProcMask == ProcMaskMeleeMHAuto {
+				apBuffAura.SetStacks(sim, 1)
+			} else {
+				apBuffAura.SetStacks(sim, 2)
 			}
-		},
-	}))
 
-	return apBuffAura
+			aura.Unit.AutoAttacks.ExtraMHAttackProc(sim, 1, buffActionID, spell)
+		}
+	}
+
+	return character.GetOrRegisterAura(config), apBuffAura
 }
 
 func GetWildStrikesAP(aura *Aura, rank int32) float64 {
@@ -1611,8 +1663,30 @@ func ApplyWindfury(character *Character) *Aura {
 	spellId := WindfuryBuffSpellId[rank]
 	buffActionID := ActionID{SpellID: spellId}
 
-	return CreateExtraAttackAuraCommon(character, buffActionID, "Windfury", rank, GetWindfuryAP)
+	apBuffAura := CreateExtraAttackAuraCommon(character, buffActionID, "Windfury", rank, GetWindfuryAP)
+	character.GetAura("Windfury").NewExclusiveEffect("WindfuryTotem", false, ExclusiveEffect{
+		Priority: WindfuryBuffBonusAP[rank],
+	})
+	return apBuffAura
+}
 
+var WindfuryTotemBuffSpellId = [WindfuryRanks + 1]int32{0, 8514, 10607, 10611}
+
+// Registers the buff of a Windfury Totem of the given rank, which is pulsed by the totem.
+// Only the strongest Windfury Totem buff on a unit procs, including the one of the
+// Windfury imbue.
+func WindfuryTotemAura(character *Character, label string, rank int32, duration time.Duration) *Aura {
+	buffActionID := ActionID{SpellID: WindfuryBuffSpellId[rank]}
+
+	procAura, _ := registerExtraAttackAura(character, buffActionID, Aura{
+		Label:    label,
+		ActionID: ActionID{SpellID: WindfuryTotemBuffSpellId[rank]},
+		Duration: duration,
+	}, rank, GetWindfuryAP)
+	procAura.NewExclusiveEffect("WindfuryTotem", false, ExclusiveEffect{
+		Priority: WindfuryBuffBonusAP[rank],
+	})
+	return procAura
 }
 
 ///////////////////////////////////////////////////////////////////////////
@@ -1766,6 +1840,7 @@ func ApplySlipkiksSavvy(unit *Unit) {
 func ApplySaygesFortunes(character *Character, fortune proto.SaygesFortune) {
 	var label string
 	var spellID int32
+	damageMultiplier := 1.10
 
 	config := BuffConfig{
 		Category: "SaygesFortune",
@@ -1776,10 +1851,10 @@ func ApplySaygesFortunes(character *Character, fortune proto.SaygesFortune) {
 		label = "Sayge's Dark Fortune of Damage"
 		spellID = 23768
 		config.ExtraOnGain = func(aura *Aura, sim *Simulation) {
-			aura.Unit.PseudoStats.DamageDealtMultiplier *= 1.10
+			aura.Unit.PseudoStats.DamageDealtMultiplier *= damageMultiplier
 		}
 		config.ExtraOnExpire = func(aura *Aura, sim *Simulation) {
-			aura.Unit.PseudoStats.DamageDealtMultiplier /= 1.10
+			aura.Unit.PseudoStats.DamageDealtMultiplier /= damageMultiplier
 		}
 	case proto.SaygesFortune_SaygesAgility:
 		label = "Sayge's Dark Fortune of Agility"
@@ -1818,6 +1893,9 @@ func ApplySaygesFortunes(character *Character, fortune proto.SaygesFortune) {
 	}))
 
 	makeExclusiveBuff(aura, config)
+	if fortune == proto.SaygesFortune_SaygesDamage {
+		aura.AttachDamageAttribution(DamageAttribution{Multiplier: damageMultiplier})
+	}
 }
 
 // Equip: Restore 11 mana per 5 seconds to all party members within 30 yards by 2%.
@@ -1,12 +1,15 @@
 package core
 
 import (
+	"cmp"
 	"fmt"
 	"math"
 	"runtime"
 	"runtime/debug"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -50,9 +53,13 @@ func BulkSim(signals simsignals.Signals, request *proto.BulkSimRequest, progress
 }
 
 type singleBulkSim struct {
-	req *proto.RaidSimRequest
-	cl  *raidSimRequestChangeLog
-	eq  *equipmentSubstitution
+	req        *proto.RaidSimRequest
+	cl         *raidSimRequestChangeLog
+	eq         *equipmentSubstitution
+	iterations int32
+
+	// Result which this sim adds more iterations to, if any.
+	prev *itemSubstitutionSimResult
 }
 
 func (b *bulkSimRunner) Run(signals simsignals.Signals, progress chan *proto.ProgressMetrics) (result *proto.BulkSimResult) {
@@ -129,29 +136,11 @@ func (b *bulkSimRunner) Run(signals simsignals.Signals, progress chan *proto.Pro
 			})
 		}
 	}
-	baseItems := player.Equipment.Items
-
-	allCombos := generateAllEquipmentSubstitutions(signals, baseItems, b.Request.BulkSettings.Combinations, distinctItemSlotCombos)
-
-	var validCombos []singleBulkSim
-	count := 0
-	for sub := range allCombos {
-		count++
-		if count > 1000000 {
-			panic("over 1 million combos, abandoning attempt")
-		}
-		substitutedRequest, changeLog := createNewRequestWithSubstitution(b.Request.BaseSettings, sub, b.Request.BulkSettings.AutoEnchant)
-		if isValidEquipment(substitutedRequest.Raid.Parties[0].Players[0].Equipment) {
-			validCombos = append(validCombos, singleBulkSim{req: substitutedRequest, cl: changeLog, eq: sub})
-		}
-	}
 
 	// TODO(Riotdog-GehennasEU): Make this configurable?
 	maxResults := 30
 
-	var rankedResults []*itemSubstitutionSimResult
-	var baseResult *itemSubstitutionSimResult
-	newIters := int64(iterations)
+	newIters := iterations
 	if b.Request.BulkSettings.FastMode {
 		newIters /= 100
 
@@ -162,52 +151,70 @@ func (b *bulkSimRunner) Run(signals simsignals.Signals, progress chan *proto.Pro
 		if newIters > 1000 {
 			newIters = 1000
 		}
-	}
 
-	maxIterations := newIters * int64(len(validCombos))
-	if maxIterations > math.MaxInt32 {
-		return &proto.BulkSimResult{
-			Error: &proto.ErrorOutcome{Message: fmt.Sprintf("number of total iterations %d too large", maxIterations)},
+		// Racing adds iterations to previous results, continuing from their seeds, so
+		// the seed can't be left for each sim to pick.
+		if b.Request.BaseSettings.SimOptions == nil {
+			b.Request.BaseSettings.SimOptions = &proto.SimOptions{}
+		}
+		if b.Request.BaseSettings.SimOptions.RandomSeed == 0 {
+			b.Request.BaseSettings.SimOptions.RandomSeed = time.Now().UnixNano()
 		}
 	}
 
-	for {
-		var tempBase *itemSubstitutionSimResult
-		var errorOutcome *proto.ErrorOutcome
-		// TODO: we could theoretically make getRankedResults accept a channel of validCombos that stream in to it and launches sims as it gets them...
-		rankedResults, tempBase, errorOutcome = b.getRankedResults(signals, validCombos, newIters, progress)
-
-		if errorOutcome != nil {
-			return &proto.BulkSimResult{Error: errorOutcome}
-		}
-		// keep replacing the base result with more refined base until we don't have base in the ranked results anymore.
-		if tempBase != nil {
-			baseResult = tempBase
+	consumesSearch := b.Request.BulkSettings.GetConsumesSearch()
+	searchConsumes := len(consumesSearch.GetOptions()) > 0
+	talentSearch := b.Request.BulkSettings.GetTalentSearch()
+	if talentSearch != nil && (len(items) > 0 || searchConsumes) {
+		return &proto.BulkSimResult{
+			Error: &proto.ErrorOutcome{Message: "bulksim: can't search talents together with items or consumables"},
 		}
+	}
 
-		// If we aren't doing fast mode, or if halving our results will be less than the maxResults, be done.
-		if !b.Request.BulkSettings.FastMode || len(rankedResults) <= maxResults*2 {
-			break
+	var rankedResults []*itemSubstitutionSimResult
+	var baseResult *itemSubstitutionSimResult
+	var errorOutcome *proto.ErrorOutcome
+	if talentSearch != nil {
+		rankedResults, baseResult, errorOutcome = b.searchTalents(signals, player, talentSearch, newIters, progress)
+	} else {
+		// Combos are generated lazily and only turned into requests once a sim is
+		// ready to start, so memory use doesn't grow with the number of combos.
+		var combos *bulkComboStream
+		if searchConsumes {
+			if len(items) > 0 {
+				return &proto.BulkSimResult{
+					Error: &proto.ErrorOutcome{Message: "bulksim: can't search items and consumables at the same time"},
+				}
+			}
+			if player.Consumes == nil {
+				player.Consumes = &proto.Consumes{}
+			}
+			fields, err := prepareConsumesSearch(player.Consumes, consumesSearch)
+			if err != nil {
+				return &proto.BulkSimResult{Error: &proto.ErrorOutcome{Message: "bulksim: " + err.Error()}}
+			}
+			combos = b.generateConsumesCombos(signals, fields, newIters)
+		} else {
+			combos = b.generateValidCombos(signals, player.Equipment.Items, distinctItemSlotCombos, newIters)
 		}
 
-		// we have reached max accuracy now
-		if newIters >= int64(iterations) {
-			break
+		// Fast mode races the kept results, so also keep enough to fill the top N.
+		keepResults := maxResults
+		if b.Request.BulkSettings.FastMode {
+			keepResults = max(maxResults, int(b.Request.BulkSettings.RaceTopN))
 		}
 
-		// Increase accuracy
-		newIters *= 2
-		newNumCombos := len(rankedResults) / 2
-		validCombos = validCombos[:newNumCombos]
-		rankedResults = rankedResults[:newNumCombos]
-		for i, comb := range rankedResults {
-			validCombos[i] = singleBulkSim{
-				req: comb.Request,
-				cl:  comb.ChangeLog,
-				eq:  comb.Substitution,
-			}
+		rankedResults, baseResult, errorOutcome = b.getRankedResults(signals, combos.sims, keepResults, progress)
+		if combos.err != nil {
+			return &proto.BulkSimResult{Error: &proto.ErrorOutcome{Message: combos.err.Error()}}
 		}
 	}
+	if errorOutcome == nil && b.Request.BulkSettings.FastMode {
+		errorOutcome = b.raceResults(signals, rankedResults, iterations, progress)
+	}
+	if errorOutcome != nil {
+		return &proto.BulkSimResult{Error: errorOutcome}
+	}
 
 	if baseResult == nil {
 		return &proto.BulkSimResult{
@@ -221,29 +228,32 @@ func (b *bulkSimRunner) Run(signals simsignals.Signals, progress chan *proto.Pro
 		rankedResults = rankedResults[:maxResults]
 	}
 
-	bum := baseResult.Result.GetRaidMetrics().GetParties()[0].GetPlayers()[0]
-	bum.Actions = nil
-	bum.Auras = nil
-	bum.Resources = nil
-	bum.Pets = nil
-
 	result = &proto.BulkSimResult{
-		EquippedGearResult: &proto.BulkComboResult{
-			UnitMetrics: bum,
-		},
+		EquippedGearResult: baseResult.toProto(),
 	}
-
 	for _, r := range rankedResults {
-		um := r.Result.GetRaidMetrics().GetParties()[0].GetPlayers()[0]
-		um.Actions = nil
-		um.Auras = nil
-		um.Resources = nil
-		um.Pets = nil
-
-		result.Results = append(result.Results, &proto.BulkComboResult{
-			ItemsAdded:  r.ChangeLog.AddedItems,
-			UnitMetrics: um,
-		})
+		result.Results = append(result.Results, r.toProto())
+	}
+
+	if searchConsumes {
+		result.EquippedGearResult.Consumes = player.Consumes
+		for _, r := range result.Results {
+			if r.Consumes == nil {
+				r.Consumes = player.Consumes
+			}
+			if r.GoldCost > 0 {
+				r.DpsPerGold = (r.UnitMetrics.Dps.Avg - baseResult.Score()) / r.GoldCost
+			}
+		}
+	}
+
+	if talentSearch != nil {
+		result.EquippedGearResult.TalentLoadout = &proto.TalentLoadout{TalentsString: player.TalentsString}
+		for _, r := range result.Results {
+			if r.TalentLoadout == nil {
+				r.TalentLoadout = result.EquippedGearResult.TalentLoadout
+			}
+		}
 	}
 
 	if progress != nil {
@@ -255,7 +265,144 @@ func (b *bulkSimRunner) Run(signals simsignals.Signals, progress chan *proto.Pro
 	return result
 }
 
-func (b *bulkSimRunner) getRankedResults(signals simsignals.Signals, validCombos []singleBulkSim, iterations int64, progress chan *proto.ProgressMetrics) ([]*itemSubstitutionSimResult, *itemSubstitutionSimResult, *proto.ErrorOutcome) {
+// raceResults spends more iterations on the best combos until the top N are separated
+// from each other and from the rest at the requested confidence level, or until they've
+// all been simmed for maxIterations. Combos whose confidence interval is entirely below
+// the Nth best combo's are eliminated. Results are merged in place and re-ranked.
+func (b *bulkSimRunner) raceResults(signals simsignals.Signals, results []*itemSubstitutionSimResult, maxIterations int32, progress chan *proto.ProgressMetrics) *proto.ErrorOutcome {
+	confidence := b.Request.BulkSettings.ConfidenceLevel
+	if confidence <= 0 || confidence >= 1 {
+		confidence = 0.95
+	}
+	z := math.Sqrt2 * math.Erfinv(confidence)
+	topN := max(int(b.Request.BulkSettings.RaceTopN), 1)
+
+	survivors := slices.Clone(results)
+	for {
+		sortResultsByScore(survivors)
+		if len(survivors) == 0 {
+			break
+		}
+
+		// Eliminate combos which are clearly worse than the Nth best.
+		nth := survivors[min(topN, len(survivors))-1]
+		survivors = slices.DeleteFunc(survivors, func(r *itemSubstitutionSimResult) bool {
+			return r.upperBound(z) < nth.lowerBound(z)
+		})
+
+		if resultsSeparated(survivors, topN, z) {
+			break
+		}
+
+		// Double the iterations of every survivor which hasn't reached the max yet.
+		var resims []*itemSubstitutionSimResult
+		for _, r := range survivors {
+			if r.Iterations() < maxIterations {
+				resims = append(resims, r)
+			}
+		}
+		if len(resims) == 0 {
+			break
+		}
+
+		_, _, errorOutcome := b.getRankedResults(signals, b.resimCombos(resims, maxIterations).sims, 0, progress)
+		if errorOutcome != nil {
+			return errorOutcome
+		}
+	}
+
+	sortResultsByScore(results)
+	return nil
+}
+
+// resultsSeparated returns true if only the top N results are left and each of them is
+// clearly better than the next.
+func resultsSeparated(ranked []*itemSubstitutionSimResult, topN int, z float64) bool {
+	if len(ranked) > topN {
+		return false
+	}
+	for i := 1; i < len(ranked); i++ {
+		if ranked[i-1].lowerBound(z) <= ranked[i].upperBound(z) {
+			return false
+		}
+	}
+	return true
+}
+
+func sortResultsByScore(results []*itemSubstitutionSimResult) {
+	slices.SortStableFunc(results, func(a, b *itemSubstitutionSimResult) int {
+		return cmp.Compare(b.Score(), a.Score())
+	})
+}
+
+// bulkComboStream is a stream of combos to sim. err is set once the stream is
+// closed if the combos couldn't all be generated.
+type bulkComboStream struct {
+	sims chan singleBulkSim
+	err  error
+}
+
+// generateValidCombos streams a sim for every valid equipment substitution,
+// starting with the base equipment set.
+func (b *bulkSimRunner) generateValidCombos(signals simsignals.Signals, baseItems []*proto.ItemSpec, distinctItemSlotCombos []*itemWithSlot, iterations int32) *bulkComboStream {
+	stream := &bulkComboStream{
+		sims: make(chan singleBulkSim),
+	}
+	maxCombos := min(1000000, math.MaxInt32/int64(iterations))
+
+	allCombos := generateAllEquipmentSubstitutions(signals, baseItems, b.Request.BulkSettings.Combinations, distinctItemSlotCombos)
+	go func() {
+		defer close(stream.sims)
+
+		count := int64(0)
+		for sub := range allCombos {
+			count++
+			if count > maxCombos {
+				stream.err = fmt.Errorf("over %d combos, abandoning attempt", maxCombos)
+				signals.Abort.Trigger()
+				break
+			}
+			substitutedRequest, changeLog := createNewRequestWithSubstitution(b.Request.BaseSettings, sub, b.Request.BulkSettings.AutoEnchant)
+			if isValidEquipment(substitutedRequest.Raid.Parties[0].Players[0].Equipment) {
+				stream.sims <- singleBulkSim{req: substitutedRequest, cl: changeLog, eq: sub, iterations: iterations}
+			}
+		}
+	}()
+
+	return stream
+}
+
+// resimCombos streams the combos of previous results again, doubling their iterations
+// up to maxIterations. Each iteration uses the seed after the previous one's, so the new
+// iterations start at the seed after the last iteration already simmed, and their results
+// are merged into the previous results.
+func (b *bulkSimRunner) resimCombos(results []*itemSubstitutionSimResult, maxIterations int32) *bulkComboStream {
+	stream := &bulkComboStream{
+		sims: make(chan singleBulkSim),
+	}
+
+	go func() {
+		defer close(stream.sims)
+		for _, r := range results {
+			req, _ := createNewRequestWithSubstitution(b.Request.BaseSettings, r.Substitution, b.Request.BulkSettings.AutoEnchant)
+			req.SimOptions.RandomSeed += int64(r.Iterations())
+			stream.sims <- singleBulkSim{
+				req:        req,
+				cl:         r.ChangeLog,
+				eq:         r.Substitution,
+				iterations: min(r.Iterations(), maxIterations-r.Iterations()),
+				prev:       r,
+			}
+		}
+	}()
+
+	return stream
+}
+
+// getRankedResults sims every combo from the stream as it arrives and returns
+// the results ranked by score. If keepResults is positive, only that many of
+// the best results are kept, in addition to the base result.
+func (b *bulkSimRunner) getRankedResults(signals simsignals.Signals, combos chan singleBulkSim, keepResults int, progress chan *proto.ProgressMetrics) ([]*itemSubstitutionSimResult, *itemSubstitutionSimResult, *proto.ErrorOutcome) {
 	concurrency := runtime.NumCPU() + 1
 	if concurrency <= 0 {
 		concurrency = 2
@@ -268,39 +415,41 @@ func (b *bulkSimRunner) getRankedResults(signals simsignals.Signals, validCombos
 
 	results := make(chan *itemSubstitutionSimResult, 10)
 
-	numCombinations := int32(len(validCombos))
-	totalIterationsUpperBound := int64(numCombinations) * iterations
-
+	var numCombinations int32
+	var totalIterations int32
 	var totalCompletedIterations int32
 	var totalCompletedSims int32
 
 	reporterSignal := simsignals.CreateSignals()
 
-	// reporter for all sims combined.
-	go func() {
-		for !signals.Abort.IsTriggered() && !reporterSignal.Abort.IsTriggered() {
-			complIters := atomic.LoadInt32(&totalCompletedIterations)
-			complSims := atomic.LoadInt32(&totalCompletedSims)
-
-			// stop reporting
-			if complIters == int32(totalIterationsUpperBound) || numCombinations == complSims {
-				return
-			}
-
-			progress <- &proto.ProgressMetrics{
-				TotalSims:           numCombinations,
-				CompletedSims:       complSims,
-				CompletedIterations: complIters,
-				TotalIterations:     int32(totalIterationsUpperBound),
+	// reporter for all sims combined. The totals grow as combos are generated.
+	if progress != nil {
+		go func() {
+			for !signals.Abort.IsTriggered() && !reporterSignal.Abort.IsTriggered() {
+				progress <- &proto.ProgressMetrics{
+					TotalSims:           atomic.LoadInt32(&numCombinations),
+					CompletedSims:       atomic.LoadInt32(&totalCompletedSims),
+					CompletedIterations: atomic.LoadInt32(&totalCompletedIterations),
+					TotalIterations:     atomic.LoadInt32(&totalIterations),
+				}
+				time.Sleep(time.Second)
 			}
-			time.Sleep(time.Second)
-		}
-	}()
+		}()
+	}
 
 	// launcher for all combos (limited by concurrency max)
 	go func() {
-		for _, singleCombo := range validCombos {
+		var wg sync.WaitGroup
+		for singleCombo := range combos {
 			<-tickets
+			if signals.Abort.IsTriggered() {
+				tickets <- struct{}{}
+				continue
+			}
+			atomic.AddInt32(&numCombinations, 1)
+			atomic.AddInt32(&totalIterations, singleCombo.iterations)
+			wg.Add(1)
+
 			singleSimProgress := make(chan *proto.ProgressMetrics)
 			// watches this progress and pushes up to main reporter.
 			go func(prog chan *proto.ProgressMetrics) {
@@ -316,63 +465,167 @@ func (b *bulkSimRunner) getRankedResults(signals simsignals.Signals, validCombos
 			}(singleSimProgress)
 			// actually run the sim in here.
 			go func(sub singleBulkSim) {
-				// overwrite the requests iterations with the input for this function.
-				sub.req.SimOptions.Iterations = int32(iterations)
-				results <- &itemSubstitutionSimResult{
-					Request:      sub.req,
-					Result:       b.SingleRaidSimRunner(sub.req, singleSimProgress, false, signals),
-					Substitution: sub.eq,
-					ChangeLog:    sub.cl,
-				}
+				defer wg.Done()
+				sub.req.SimOptions.Iterations = sub.iterations
+				results <- newItemSubstitutionSimResult(sub, b.SingleRaidSimRunner(sub.req, singleSimProgress, false, signals))
 				atomic.AddInt32(&totalCompletedSims, 1)
 				tickets <- struct{}{} // when done, allow for new sim to be launched.
 			}(singleCombo)
 		}
+		wg.Wait()
+		close(results)
 	}()
 
-	rankedResults := make([]*itemSubstitutionSimResult, numCombinations)
+	var rankedResults []*itemSubstitutionSimResult
 	var baseResult *itemSubstitutionSimResult
+	var errorOutcome *proto.ErrorOutcome
 
-	for i := range rankedResults {
-		result := <-results
-		if result.Result == nil || result.Result.Error != nil {
-			reporterSignal.Abort.Trigger() // cancel reporter
-			return nil, nil, result.Result.Error
+	for result := range results {
+		if errorOutcome != nil {
+			// Keep draining so the remaining sims can finish.
+			continue
+		}
+		if result.Error != nil {
+			errorOutcome = result.Error
+			signals.Abort.Trigger()
+			continue
 		}
-		if !result.Substitution.HasItemReplacements() {
+		if !result.Substitution.HasItemReplacements() && !result.Substitution.HasConsumeReplacements() && !result.Substitution.HasTalentReplacements() {
 			baseResult = result
 		}
-		rankedResults[i] = result
+
+		// Insert in ranked order, dropping the worst result if we're over the limit.
+		idx := sort.Search(len(rankedResults), func(i int) bool {
+			return rankedResults[i].Score() < result.Score()
+		})
+		if keepResults > 0 && idx >= keepResults {
+			continue
+		}
+		rankedResults = slices.Insert(rankedResults, idx, result)
+		if keepResults > 0 && len(rankedResults) > keepResults {
+			rankedResults = rankedResults[:keepResults]
+		}
 	}
 	reporterSignal.Abort.Trigger() // cancel reporter
 
-	sort.Slice(rankedResults, func(i, j int) bool {
-		return rankedResults[i].Score() > rankedResults[j].Score()
-	})
+	if errorOutcome != nil {
+		return nil, nil, errorOutcome
+	}
 	return rankedResults, baseResult, nil
 }
 
-// itemSubstitutionSimResult stores the request and response of a simulation, along with the used
-// equipment susbstitution and a changelog of which items were added and removed from the base
-// equipment set.
+// itemSubstitutionSimResult stores the result of a simulation, along with the used equipment
+// susbstitution and a changelog of which items were added and removed from the base equipment
+// set. Only the player's summary metrics are kept, so that many results fit in memory.
 type itemSubstitutionSimResult struct {
-	Request      *proto.RaidSimRequest
-	Result       *proto.RaidSimResult
+	UnitMetrics  *proto.UnitMetrics
+	Error        *proto.ErrorOutcome
 	Substitution *equipmentSubstitution
 	ChangeLog    *raidSimRequestChangeLog
+
+	// DPS of every iteration simmed for this combo so far.
+	dps aggregator
+}
+
+// newItemSubstitutionSimResult creates the result for a finished sim. If the sim continued
+// a previous result, it's merged into that result instead.
+func newItemSubstitutionSimResult(sub singleBulkSim, result *proto.RaidSimResult) *itemSubstitutionSimResult {
+	r := &itemSubstitutionSimResult{
+		Substitution: sub.eq,
+		ChangeLog:    sub.cl,
+	}
+	if result == nil {
+		r.Error = &proto.ErrorOutcome{Message: "bulksim: sim returned no result"}
+		return r
+	}
+	if result.Error != nil {
+		r.Error = result.Error
+		return r
+	}
+
+	um := result.GetRaidMetrics().GetParties()[0].GetPlayers()[0]
+	um.Actions = nil
+	um.Auras = nil
+	um.Resources = nil
+	um.Pets = nil
+	r.UnitMetrics = um
+
+	// Stdev is the population standard deviation, so this recovers the sum of squares.
+	n := float64(sub.iterations)
+	r.dps = aggregator{
+		n:     int(sub.iterations),
+		sum:   um.Dps.Avg * n,
+		sumSq: n * (um.Dps.Stdev*um.Dps.Stdev + um.Dps.Avg*um.Dps.Avg),
+	}
+
+	if sub.prev == nil {
+		return r
+	}
+	prev := sub.prev
+	total := float64(prev.dps.n + r.dps.n)
+	var rsrc raidSimResultCombiner
+	merged := rsrc.newUnitMetrics(prev.UnitMetrics)
+	rsrc.combineUnitMetrics(merged, prev.UnitMetrics, false, float64(prev.dps.n)/total)
+	rsrc.combineUnitMetrics(merged, um, true, n/total)
+	prev.UnitMetrics = merged
+	prev.dps = *prev.dps.merge(&r.dps)
+	return prev
 }
 
 // Score used to rank results.
 func (r *itemSubstitutionSimResult) Score() float64 {
-	if r.Result == nil || r.Result.Error != nil {
+	if r.UnitMetrics == nil || r.Error != nil || r.dps.n == 0 {
+		return 0
+	}
+	return r.dps.sum / float64(r.dps.n)
+}
+
+func (r *itemSubstitutionSimResult) Iterations() int32 {
+	return int32(r.dps.n)
+}
+
+// Standard error of the mean DPS.
+func (r *itemSubstitutionSimResult) StdErr() float64 {
+	if r.dps.n == 0 {
+		return 0
+	}
+	_, stdev := r.dps.meanAndStdDev()
+	if math.IsNaN(stdev) {
+		// Rounding can make the variance slightly negative when all iterations are equal.
 		return 0
 	}
-	return r.Result.RaidMetrics.Dps.Avg
+	return stdev / math.Sqrt(float64(r.dps.n))
+}
+
+func (r *itemSubstitutionSimResult) lowerBound(z float64) float64 {
+	return r.Score() - z*r.StdErr()
+}
+func (r *itemSubstitutionSimResult) upperBound(z float64) float64 {
+	return r.Score() + z*r.StdErr()
+}
+
+func (r *itemSubstitutionSimResult) toProto() *proto.BulkComboResult {
+	return &proto.BulkComboResult{
+		ItemsAdded:  r.ChangeLog.AddedItems,
+		UnitMetrics: r.UnitMetrics,
+		Iterations:  r.Iterations(),
+		DpsStderr:   r.StdErr(),
+		Consumes:    r.ChangeLog.Consumes,
+		GoldCost:    r.ChangeLog.GoldCost,
+
+		TalentLoadout: r.ChangeLog.TalentLoadout,
+	}
 }
 
 // equipmentSubstitution specifies all items to be used as replacements for the equipped gear.
 type equipmentSubstitution struct {
 	Items []*itemWithSlot
+
+	// Consumables set in place of the player's, for consumable searches.
+	Consumes []*proto.ConsumeOption
+
+	// Talent string used in place of the player's, for talent searches.
+	Talents string
 }
 
 // HasChanges returns true if the equipment substitution has any item replacmenets.
@@ -380,6 +633,14 @@ func (es *equipmentSubstitution) HasItemReplacements() bool {
 	return len(es.Items) > 0
 }
 
+func (es *equipmentSubstitution) HasConsumeReplacements() bool {
+	return len(es.Consumes) > 0
+}
+
+func (es *equipmentSubstitution) HasTalentReplacements() bool {
+	return es.Talents != ""
+}
+
 func (es *equipmentSubstitution) CanonicalHash() string {
 	slotToID := map[proto.ItemSlot]int32{}
 	for _, repl := range es.Items {
@@ -586,6 +847,13 @@ type itemWithSlot struct {
 // equipment set.
 type raidSimRequestChangeLog struct {
 	AddedItems []*proto.ItemSpecWithSlot
+
+	// Resulting consumables and their cost, if any were substituted.
+	Consumes *proto.Consumes
+	GoldCost float64
+
+	// Resulting talents, if they were substituted.
+	TalentLoadout *proto.TalentLoadout
 }
 
 // createNewRequestWithSubstitution creates a copy of the input RaidSimRequest and applis the given
@@ -617,6 +885,18 @@ func createNewRequestWithSubstitution(readonlyInputRequest *proto.RaidSimRequest
 			})
 		}
 	}
+	if substitution.HasConsumeReplacements() {
+		for _, option := range substitution.Consumes {
+			// Options were already validated when preparing the search.
+			_ = applyConsumeOption(player.Consumes, option)
+			changeLog.GoldCost += option.GoldCost
+		}
+		changeLog.Consumes = player.Consumes
+	}
+	if substitution.HasTalentReplacements() {
+		player.TalentsString = substitution.Talents
+		changeLog.TalentLoadout = &proto.TalentLoadout{TalentsString: substitution.Talents}
+	}
 	return request, changeLog
 }
 
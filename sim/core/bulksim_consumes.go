@@ -0,0 +1,137 @@
+package core
+
+import (
+	"fmt"
+	"math"
+
+	goproto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+	"github.com/isfir/wowsims-turtle/sim/core/simsignals"
+)
+
+// consumeField is a Consumes field searched by a consumable bulk sim, with all the
+// options tried for it.
+type consumeField struct {
+	path    string
+	options []*proto.ConsumeOption
+}
+
+// Resolves a field path such as "misc_consumes.juju_flurry" on the given Consumes,
+// creating nested messages if needed.
+func resolveConsumeField(consumes *proto.Consumes, path string) (protoreflect.Message, protoreflect.FieldDescriptor, error) {
+	msg, fd, err := ResolveFieldPath(consumes.ProtoReflect(), path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if fd.IsList() || fd.IsMap() || fd.Kind() == protoreflect.MessageKind {
+		return nil, nil, fmt.Errorf("consumes field %s can't be searched", path)
+	}
+	return msg, fd, nil
+}
+
+// Sets a searched consumes field to the value of an option.
+func applyConsumeOption(consumes *proto.Consumes, option *proto.ConsumeOption) error {
+	msg, fd, err := resolveConsumeField(consumes, option.Field)
+	if err != nil {
+		return err
+	}
+
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		msg.Set(fd, protoreflect.ValueOfEnum(protoreflect.EnumNumber(option.Value)))
+	case protoreflect.BoolKind:
+		msg.Set(fd, protoreflect.ValueOfBool(option.Value != 0))
+	case protoreflect.Int32Kind:
+		msg.Set(fd, protoreflect.ValueOfInt32(option.Value))
+	default:
+		return fmt.Errorf("consumes field %s can't be searched", option.Field)
+	}
+	return nil
+}
+
+// Groups the options of a search by field, in order of first appearance, and
+// clears every searched field from the base consumes.
+func prepareConsumesSearch(baseConsumes *proto.Consumes, search *proto.ConsumesSearch) ([]*consumeField, error) {
+	var fields []*consumeField
+	for _, option := range search.Options {
+		msg, fd, err := resolveConsumeField(baseConsumes, option.Field)
+		if err != nil {
+			return nil, err
+		}
+		msg.Clear(fd)
+		if err := applyConsumeOption(goproto.Clone(baseConsumes).(*proto.Consumes), option); err != nil {
+			return nil, err
+		}
+
+		idx := -1
+		for i, field := range fields {
+			if field.path == option.Field {
+				idx = i
+				break
+			}
+		}
+		if idx == -1 {
+			fields = append(fields, &consumeField{path: option.Field})
+			idx = len(fields) - 1
+		}
+		fields[idx].options = append(fields[idx].options, option)
+	}
+	return fields, nil
+}
+
+// generateConsumesCombos streams a sim for every combination of the searched
+// consumables within the gold cap, starting with the one using none of them.
+func (b *bulkSimRunner) generateConsumesCombos(signals simsignals.Signals, fields []*consumeField, iterations int32) *bulkComboStream {
+	stream := &bulkComboStream{
+		sims: make(chan singleBulkSim),
+	}
+	maxCombos := min(1000000, math.MaxInt32/int64(iterations))
+	goldCap := b.Request.BulkSettings.ConsumesSearch.GoldCap
+
+	go func() {
+		defer close(stream.sims)
+
+		// Each field picks no option at index 0, or one of its options after that.
+		choices := make([]int, len(fields))
+		count := int64(0)
+		for !signals.Abort.IsTriggered() {
+			sub := &equipmentSubstitution{}
+			goldCost := 0.0
+			for i, choice := range choices {
+				if choice > 0 {
+					option := fields[i].options[choice-1]
+					sub.Consumes = append(sub.Consumes, option)
+					goldCost += option.GoldCost
+				}
+			}
+
+			if goldCap <= 0 || goldCost <= goldCap {
+				count++
+				if count > maxCombos {
+					stream.err = fmt.Errorf("over %d combos, abandoning attempt", maxCombos)
+					signals.Abort.Trigger()
+					break
+				}
+				substitutedRequest, changeLog := createNewRequestWithSubstitution(b.Request.BaseSettings, sub, false)
+				stream.sims <- singleBulkSim{req: substitutedRequest, cl: changeLog, eq: sub, iterations: iterations}
+			}
+
+			// Advance to the next combination.
+			i := 0
+			for ; i < len(choices); i++ {
+				choices[i]++
+				if choices[i] <= len(fields[i].options) {
+					break
+				}
+				choices[i] = 0
+			}
+			if i == len(choices) {
+				break
+			}
+		}
+	}()
+
+	return stream
+}
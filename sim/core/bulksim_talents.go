@@ -0,0 +1,111 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+	"github.com/isfir/wowsims-turtle/sim/core/simsignals"
+)
+
+const (
+	defaultTalentSearchSteps = 20
+)
+
+// searchTalents beam searches the talent builds around the player's talents. Each
+// step sims every build one point move away from the current best builds, and
+// keeps the best of them for the next step. The search stops once no move improves
+// on the best builds. All simmed builds are returned, ranked by score.
+func (b *bulkSimRunner) searchTalents(signals simsignals.Signals, player *proto.Player, search *proto.TalentSearch, iterations int32, progress chan *proto.ProgressMetrics) ([]*itemSubstitutionSimResult, *itemSubstitutionSimResult, *proto.ErrorOutcome) {
+	trees, ok := talentTreesByClass[player.Class]
+	if !ok {
+		return nil, nil, &proto.ErrorOutcome{Message: fmt.Sprintf("bulksim: no talent trees for class %s", player.Class)}
+	}
+	baseBuild, err := parseTalentBuild(player.TalentsString, trees)
+	if err != nil {
+		return nil, nil, &proto.ErrorOutcome{Message: "bulksim: " + err.Error()}
+	}
+	if !baseBuild.isValid(trees) {
+		return nil, nil, &proto.ErrorOutcome{Message: fmt.Sprintf("bulksim: talents %s are not a valid build", player.TalentsString)}
+	}
+
+	beamWidth := max(1, int(search.BeamWidth))
+	maxSteps := int(search.MaxSteps)
+	if maxSteps <= 0 {
+		maxSteps = defaultTalentSearchSteps
+	}
+
+	// Sims the given builds, or the player's own talents if there are none.
+	simBuilds := func(builds []talentBuild) ([]*itemSubstitutionSimResult, *itemSubstitutionSimResult, *proto.ErrorOutcome) {
+		stream := &bulkComboStream{
+			sims: make(chan singleBulkSim),
+		}
+		go func() {
+			defer close(stream.sims)
+			if builds == nil {
+				substitutedRequest, changeLog := createNewRequestWithSubstitution(b.Request.BaseSettings, &equipmentSubstitution{}, false)
+				stream.sims <- singleBulkSim{req: substitutedRequest, cl: changeLog, eq: &equipmentSubstitution{}, iterations: iterations}
+			}
+			for _, build := range builds {
+				if signals.Abort.IsTriggered() {
+					break
+				}
+				sub := &equipmentSubstitution{Talents: build.String()}
+				substitutedRequest, changeLog := createNewRequestWithSubstitution(b.Request.BaseSettings, sub, false)
+				stream.sims <- singleBulkSim{req: substitutedRequest, cl: changeLog, eq: sub, iterations: iterations}
+			}
+		}()
+		return b.getRankedResults(signals, stream.sims, 0, progress)
+	}
+
+	_, baseResult, errorOutcome := simBuilds(nil)
+	if errorOutcome != nil {
+		return nil, nil, errorOutcome
+	}
+
+	allResults := []*itemSubstitutionSimResult{baseResult}
+	beam := []*itemSubstitutionSimResult{baseResult}
+	buildsByResult := map[*itemSubstitutionSimResult]talentBuild{baseResult: baseBuild}
+	visited := map[string]bool{baseBuild.String(): true}
+
+	for step := 0; step < maxSteps && !signals.Abort.IsTriggered(); step++ {
+		var candidates []talentBuild
+		for _, r := range beam {
+			for _, neighbor := range buildsByResult[r].neighbors(trees) {
+				key := neighbor.String()
+				if !visited[key] {
+					visited[key] = true
+					candidates = append(candidates, neighbor)
+				}
+			}
+		}
+		if len(candidates) == 0 {
+			break
+		}
+
+		stepResults, _, errorOutcome := simBuilds(candidates)
+		if errorOutcome != nil {
+			return nil, nil, errorOutcome
+		}
+		for _, r := range stepResults {
+			build, _ := parseTalentBuild(r.Substitution.Talents, trees)
+			buildsByResult[r] = build
+		}
+		allResults = append(allResults, stepResults...)
+
+		newBeam := append(append([]*itemSubstitutionSimResult{}, beam...), stepResults...)
+		sortResultsByScore(newBeam)
+		newBeam = newBeam[:min(beamWidth, len(newBeam))]
+
+		improved := slices.ContainsFunc(newBeam, func(r *itemSubstitutionSimResult) bool {
+			return !slices.Contains(beam, r)
+		})
+		beam = newBeam
+		if !improved {
+			break
+		}
+	}
+
+	sortResultsByScore(allResults)
+	return allResults, baseResult, nil
+}
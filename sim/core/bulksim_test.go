@@ -1,6 +1,7 @@
 package core
 
 import (
+	"math"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -108,6 +109,289 @@ func TestBulkSim(t *testing.T) {
 	}
 }
 
+// weaponsDps scores a request by the weapons it uses, so the two-hander and dual wield
+// combos can be told apart.
+func weaponsDps(rsr *proto.RaidSimRequest) float64 {
+	items := rsr.Raid.Parties[0].Players[0].Equipment.Items
+	return float64(items[proto.ItemSlot_ItemSlotMainHand].Id+items[proto.ItemSlot_ItemSlotOffHand].Id) / 1000
+}
+
+// fakeRaidSimRunner returns a runner which skips simming, and reports the dps given by
+// dpsFunc for the player, with the given standard deviation over the requested iterations.
+func fakeRaidSimRunner(stdev float64, dpsFunc func(rsr *proto.RaidSimRequest) float64) raidSimRunner {
+	return func(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, skipPresim bool, signals simsignals.Signals) *proto.RaidSimResult {
+		n := float64(rsr.SimOptions.Iterations)
+		newDist := func(avg float64, stdev float64) *proto.DistributionMetrics {
+			return &proto.DistributionMetrics{
+				Avg:            avg,
+				Stdev:          stdev,
+				Min:            avg - stdev,
+				Max:            avg + stdev,
+				Hist:           map[int32]int32{int32(math.Round(avg/10) * 10): rsr.SimOptions.Iterations},
+				AggregatorData: &proto.AggregatorData{N: rsr.SimOptions.Iterations, SumSq: n * (stdev*stdev + avg*avg)},
+			}
+		}
+
+		return &proto.RaidSimResult{
+			RaidMetrics: &proto.RaidMetrics{
+				Parties: []*proto.PartyMetrics{{
+					Players: []*proto.UnitMetrics{{
+						Name:    "Player",
+						Dps:     newDist(dpsFunc(rsr), stdev),
+						Dpasp:   newDist(0, 0),
+						Threat:  newDist(0, 0),
+						Dtps:    newDist(0, 0),
+						Tmi:     newDist(0, 0),
+						Hps:     newDist(0, 0),
+						Tto:     newDist(0, 0),
+						Actions: []*proto.ActionMetrics{{}},
+					}},
+				}},
+			},
+		}
+	}
+}
+
+func TestBulkSimRanksStreamedCombos(t *testing.T) {
+	addToDatabase(tinyItemDatabase)
+
+	fakeRunSim := fakeRaidSimRunner(0, weaponsDps)
+
+	bulk := &bulkSimRunner{
+		SingleRaidSimRunner: fakeRunSim,
+		Request: &proto.BulkSimRequest{
+			BaseSettings: &proto.RaidSimRequest{
+				Raid: &proto.Raid{
+					Parties: []*proto.Party{{
+						Players: []*proto.Player{{
+							Name:      "Player",
+							Equipment: createEquipmentFromItems(starshardEdge1),
+						}},
+					}},
+				},
+				SimOptions: &proto.SimOptions{},
+			},
+			BulkSettings: &proto.BulkSettings{
+				Items:        []*proto.ItemSpec{pillarOfFortitude.Item, ironmender.Item},
+				Combinations: true,
+			},
+		},
+	}
+
+	got := bulk.Run(simsignals.CreateSignals(), nil)
+	if got.Error != nil {
+		t.Fatalf("BulkSim() returned error: %v", got.Error.Message)
+	}
+
+	// The two-hander with the off-hand is invalid, leaving the base set and 2 combos.
+	if len(got.Results) != 3 {
+		t.Fatalf("BulkSim() returned %d results, want 3", len(got.Results))
+	}
+	for i := 1; i < len(got.Results); i++ {
+		if got.Results[i-1].UnitMetrics.Dps.Avg < got.Results[i].UnitMetrics.Dps.Avg {
+			t.Fatalf("BulkSim() results are not ranked by dps: %v", got.Results)
+		}
+	}
+	if best := got.Results[0].ItemsAdded; len(best) != 1 || best[0].Item.Id != itemIronmender {
+		t.Fatalf("BulkSim() best result added %v, want only the off-hand", best)
+	}
+	if got.EquippedGearResult.UnitMetrics.Dps.Avg != float64(itemStarshardEdge)/1000 {
+		t.Fatalf("BulkSim() equipped gear dps = %f", got.EquippedGearResult.UnitMetrics.Dps.Avg)
+	}
+	if len(got.Results[0].UnitMetrics.Actions) != 0 {
+		t.Fatalf("BulkSim() should only keep summary metrics for each combo")
+	}
+}
+
+func TestBulkSimRacesCombos(t *testing.T) {
+	addToDatabase(tinyItemDatabase)
+
+	// Scored by the weapons again, but with noise so the race needs more iterations to
+	// separate the base set from the two-hander.
+	fakeRunSim := fakeRaidSimRunner(10, weaponsDps)
+
+	bulk := &bulkSimRunner{
+		SingleRaidSimRunner: fakeRunSim,
+		Request: &proto.BulkSimRequest{
+			BaseSettings: &proto.RaidSimRequest{
+				Raid: &proto.Raid{
+					Parties: []*proto.Party{{
+						Players: []*proto.Player{{
+							Name:      "Player",
+							Equipment: createEquipmentFromItems(starshardEdge1),
+						}},
+					}},
+				},
+				SimOptions: &proto.SimOptions{RandomSeed: 1},
+			},
+			BulkSettings: &proto.BulkSettings{
+				Items:              []*proto.ItemSpec{pillarOfFortitude.Item, ironmender.Item},
+				Combinations:       true,
+				FastMode:           true,
+				IterationsPerCombo: 10000,
+				ConfidenceLevel:    0.95,
+				RaceTopN:           2,
+			},
+		},
+	}
+
+	got := bulk.Run(simsignals.CreateSignals(), nil)
+	if got.Error != nil {
+		t.Fatalf("BulkSim() returned error: %v", got.Error.Message)
+	}
+	if len(got.Results) != 3 {
+		t.Fatalf("BulkSim() returned %d results, want 3", len(got.Results))
+	}
+	if best := got.Results[0].ItemsAdded; len(best) != 1 || best[0].Item.Id != itemIronmender {
+		t.Fatalf("BulkSim() best result added %v, want only the off-hand", best)
+	}
+
+	// 2nd and 3rd only differ by 0.73 dps, which needs 3200 iterations to separate at 95%.
+	for _, r := range got.Results {
+		if r.Iterations != 3200 {
+			t.Fatalf("BulkSim() simmed %d iterations for %v, want 3200", r.Iterations, r.ItemsAdded)
+		}
+		if want := 10 / math.Sqrt(3200); math.Abs(r.DpsStderr-want) > 1e-9 {
+			t.Fatalf("BulkSim() dps stderr = %f, want %f", r.DpsStderr, want)
+		}
+
+		// The metrics of every chunk of iterations are merged, rather than only the last one's.
+		dps := r.UnitMetrics.Dps
+		if dps.AggregatorData.N != 3200 || math.Abs(dps.Stdev-10) > 1e-6 || dps.Max-dps.Min != 20 {
+			t.Fatalf("BulkSim() merged dps metrics = %v", dps)
+		}
+		var histCount int32
+		for _, count := range dps.Hist {
+			histCount += count
+		}
+		if histCount != 3200 {
+			t.Fatalf("BulkSim() dps histogram has %d iterations, want 3200", histCount)
+		}
+	}
+	if got.EquippedGearResult.Iterations != 3200 {
+		t.Fatalf("BulkSim() equipped gear simmed %d iterations, want 3200", got.EquippedGearResult.Iterations)
+	}
+}
+
+func TestBulkSimConsumesSearch(t *testing.T) {
+	// Titans adds 10 dps and Supreme Power 15, Juju Flurry adds 4.
+	fakeRunSim := fakeRaidSimRunner(0, func(rsr *proto.RaidSimRequest) float64 {
+		consumes := rsr.Raid.Parties[0].Players[0].Consumes
+		dps := 100.0
+		switch consumes.Flask {
+		case proto.Flask_FlaskOfTheTitans:
+			dps += 10
+		case proto.Flask_FlaskOfSupremePower:
+			dps += 15
+		}
+		if consumes.GetMiscConsumes().GetJujuFlurry() {
+			dps += 4
+		}
+		return dps
+	})
+
+	bulk := &bulkSimRunner{
+		SingleRaidSimRunner: fakeRunSim,
+		Request: &proto.BulkSimRequest{
+			BaseSettings: &proto.RaidSimRequest{
+				Raid: &proto.Raid{
+					Parties: []*proto.Party{{
+						Players: []*proto.Player{{
+							Name:      "Player",
+							Equipment: &proto.EquipmentSpec{},
+							Consumes:  &proto.Consumes{Flask: proto.Flask_FlaskOfTheTitans, Food: proto.Food_FoodDirgesKickChimaerokChops},
+						}},
+					}},
+				},
+				SimOptions: &proto.SimOptions{},
+			},
+			BulkSettings: &proto.BulkSettings{
+				ConsumesSearch: &proto.ConsumesSearch{
+					Options: []*proto.ConsumeOption{
+						{Field: "flask", Value: int32(proto.Flask_FlaskOfTheTitans), GoldCost: 20},
+						{Field: "flask", Value: int32(proto.Flask_FlaskOfSupremePower), GoldCost: 30},
+						{Field: "misc_consumes.juju_flurry", Value: 1, GoldCost: 2},
+					},
+					GoldCap: 25,
+				},
+			},
+		},
+	}
+
+	got := bulk.Run(simsignals.CreateSignals(), nil)
+	if got.Error != nil {
+		t.Fatalf("BulkSim() returned error: %v", got.Error.Message)
+	}
+
+	// Supreme Power is over the cap, leaving no consumables, Juju Flurry, Titans, and both.
+	if len(got.Results) != 4 {
+		t.Fatalf("BulkSim() returned %d results, want 4", len(got.Results))
+	}
+	best := got.Results[0]
+	if best.Consumes.Flask != proto.Flask_FlaskOfTheTitans || !best.Consumes.GetMiscConsumes().GetJujuFlurry() {
+		t.Fatalf("BulkSim() best consumes = %v, want Titans with Juju Flurry", best.Consumes)
+	}
+	if best.Consumes.Food != proto.Food_FoodDirgesKickChimaerokChops {
+		t.Fatalf("BulkSim() should keep consumables which aren't searched, got %v", best.Consumes)
+	}
+	if best.GoldCost != 22 || math.Abs(best.DpsPerGold-14.0/22) > 1e-9 {
+		t.Fatalf("BulkSim() best gold cost = %f, dps per gold = %f", best.GoldCost, best.DpsPerGold)
+	}
+	if got.EquippedGearResult.UnitMetrics.Dps.Avg != 100 || got.EquippedGearResult.Consumes.Flask != proto.Flask_FlaskUnknown {
+		t.Fatalf("BulkSim() base result should use none of the searched consumables, got %v", got.EquippedGearResult)
+	}
+}
+
+func TestBulkSimTalentSearch(t *testing.T) {
+	RegisterTalentTrees(proto.Class_ClassUnknown, testTalentTrees)
+
+	// Points in d are worth more than in a, and c is worth the most but needs a maxed.
+	fakeRunSim := fakeRaidSimRunner(0, func(rsr *proto.RaidSimRequest) float64 {
+		build, _ := parseTalentBuild(rsr.Raid.Parties[0].Players[0].TalentsString, testTalentTrees)
+		return float64(build[0][0] + 10*build[0][2] + 3*build[1][0])
+	})
+
+	search := func(beamWidth int32) *proto.BulkSimResult {
+		bulk := &bulkSimRunner{
+			SingleRaidSimRunner: fakeRunSim,
+			Request: &proto.BulkSimRequest{
+				BaseSettings: &proto.RaidSimRequest{
+					Raid: &proto.Raid{
+						Parties: []*proto.Party{{
+							Players: []*proto.Player{{
+								Name:          "Player",
+								Equipment:     &proto.EquipmentSpec{},
+								TalentsString: "42",
+							}},
+						}},
+					},
+					SimOptions: &proto.SimOptions{},
+				},
+				BulkSettings: &proto.BulkSettings{
+					TalentSearch: &proto.TalentSearch{BeamWidth: beamWidth},
+				},
+			},
+		}
+		got := bulk.Run(simsignals.CreateSignals(), nil)
+		if got.Error != nil {
+			t.Fatalf("BulkSim() returned error: %v", got.Error.Message)
+		}
+		if got.EquippedGearResult.TalentLoadout.TalentsString != "42" || got.EquippedGearResult.UnitMetrics.Dps.Avg != 4 {
+			t.Fatalf("BulkSim() base result = %v", got.EquippedGearResult)
+		}
+		return got
+	}
+
+	// Climbing greedily moves every point it can into d.
+	if best := search(1).Results[0]; best.TalentLoadout.TalentsString != "3-3" || best.UnitMetrics.Dps.Avg != 12 {
+		t.Fatalf("BulkSim() hill climb best = %v", best)
+	}
+	// A wider beam keeps maxing a, which leads to c.
+	if best := search(3).Results[0]; best.TalentLoadout.TalentsString != "501" || best.UnitMetrics.Dps.Avg != 15 {
+		t.Fatalf("BulkSim() beam search best = %v", best)
+	}
+}
+
 func TestGenerateAllEquipmentSubstitutions(t *testing.T) {
 	baseItems := make([]*proto.ItemSpec, len(proto.ItemSlot_name))
 	for i := range baseItems {
@@ -3,6 +3,8 @@ package core
 import (
 	"fmt"
 	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
 )
 
 // A cast corresponds to any action which causes the in-game castbar to be
@@ -229,6 +231,9 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 				spell.Unit.Log(sim, "Casting %s (Cost = %0.03f, Cast Time = %s, Effective Time = %s)",
 					spell.ActionID, max(0, spell.CurCast.Cost), spell.CurCast.CastTime, spell.CurCast.EffectiveTime())
 			}
+			if sim.combatLog != nil {
+				spell.logCombatCast(sim, proto.CombatLogEventType_CombatLogEventCastStart, target)
+			}
 
 			spell.Unit.Hardcast = Hardcast{
 				Expires:  sim.CurrentTime + spell.CurCast.CastTime,
@@ -240,6 +245,9 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 					if sim.Log != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
 						spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
 					}
+					if sim.combatLog != nil {
+						spell.logCombatCast(sim, proto.CombatLogEventType_CombatLogEventCastSuccess, target)
+					}
 
 					if spell.Cost != nil {
 						if !spell.Cost.MeetsRequirement(sim, spell) {
@@ -255,7 +263,7 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 						spell.Unit.OnCastComplete(sim, spell)
 					}
 
-					if !sim.Options.Interactive {
+					if !sim.IsInteractive(spell.Unit) {
 						spell.Unit.Rotation.DoNextAction(sim)
 					}
 				},
@@ -276,6 +284,9 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 				spell.ActionID, max(0, spell.CurCast.Cost), spell.CurCast.CastTime, spell.CurCast.EffectiveTime())
 			spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
 		}
+		if sim.combatLog != nil {
+			spell.logCombatCast(sim, proto.CombatLogEventType_CombatLogEventCastSuccess, target)
+		}
 
 		if spell.Cost != nil {
 			spell.Cost.SpendCost(sim, spell)
@@ -322,6 +333,9 @@ func (spell *Spell) makeCastFuncSimple() CastSuccessFunc {
 				spell.ActionID, 0.0, "0s", "0s")
 			spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
 		}
+		if sim.combatLog != nil {
+			spell.logCombatCast(sim, proto.CombatLogEventType_CombatLogEventCastSuccess, target)
+		}
 
 		spell.applyEffects(sim, target)
 
@@ -340,6 +354,9 @@ func (spell *Spell) makeCastFuncAutosOrProcs() CastSuccessFunc {
 				spell.ActionID, 0.0, "0s", "0s")
 			spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
 		}
+		if sim.combatLog != nil {
+			spell.logCombatCast(sim, proto.CombatLogEventType_CombatLogEventCastSuccess, target)
+		}
 
 		spell.applyEffects(sim, target)
 
@@ -468,7 +468,7 @@ func (character *Character) initialize(agent Agent) {
 				return
 			}
 
-			if sim.Options.Interactive {
+			if sim.IsInteractive(&character.Unit) {
 				if character.GCD.IsReady(sim) {
 					sim.NeedsInput = true
 				}
@@ -510,9 +510,13 @@ func (character *Character) FillPlayerStats(playerStats *proto.PlayerStats) {
 
 	playerStats.Metadata = character.GetMetadata()
 	for _, pet := range character.Pets {
-		playerStats.Pets = append(playerStats.Pets, &proto.PetStats{
+		petStats := &proto.PetStats{
 			Metadata: pet.GetMetadata(),
-		})
+		}
+		if pet.hasAPLRotation {
+			petStats.RotationStats = pet.Rotation.getStats()
+		}
+		playerStats.Pets = append(playerStats.Pets, petStats)
 	}
 
 	if character.Rotation != nil {
@@ -0,0 +1,330 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+	"github.com/isfir/wowsims-turtle/sim/core/stats"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// combatLog records structured combat events, see SimOptions.record_combat_log.
+// It is only set on the Simulation while recording.
+type combatLog struct {
+	events []*proto.CombatLogEvent
+	names  map[*Unit]string
+}
+
+// GUID uniquely identifies this unit in the combat log, using the same prefixes as
+// in-game GUIDs for players, pets and creatures.
+func (unit *Unit) GUID() string {
+	prefix := "0000"
+	switch unit.Type {
+	case PetUnit:
+		prefix = "F140"
+	case EnemyUnit:
+		prefix = "F130"
+	}
+	return fmt.Sprintf("0x%s%012X", prefix, unit.UnitIndex+1)
+}
+
+func (sim *Simulation) addCombatLogEvent(event *proto.CombatLogEvent, source *Unit, target *Unit) {
+	event.Timestamp = sim.CurrentTime.Seconds()
+	if source != nil {
+		event.SourceGuid = source.GUID()
+		event.SourceName = sim.combatLogName(source)
+	}
+	if target != nil {
+		event.TargetGuid = target.GUID()
+		event.TargetName = sim.combatLogName(target)
+	}
+	sim.combatLog.events = append(sim.combatLog.events, event)
+}
+
+// Units are logged by their plain names like in game, rather than by their labels which
+// include the unit index.
+func (sim *Simulation) combatLogName(unit *Unit) string {
+	if name, ok := sim.combatLog.names[unit]; ok {
+		return name
+	}
+
+	name := ""
+	if unit.Type == EnemyUnit {
+		name = sim.Encounter.Targets[unit.Index].name
+	} else if agent := sim.Raid.GetPlayerFromUnit(unit); agent != nil {
+		name = agent.GetCharacter().Name
+	}
+	if name == "" {
+		name = unit.Label
+	}
+
+	if sim.combatLog.names == nil {
+		sim.combatLog.names = make(map[*Unit]string)
+	}
+	sim.combatLog.names[unit] = name
+	return name
+}
+
+func (spell *Spell) schoolProto() proto.SpellSchool {
+	school := spell.SchoolIndex
+	if school >= stats.SchoolIndexMultischool && len(spell.SchoolBaseIndices) > 0 {
+		school = spell.SchoolBaseIndices[0]
+	}
+	if school == stats.SchoolIndexNone {
+		return proto.SpellSchool_SpellSchoolPhysical
+	}
+	return proto.SpellSchool(school - 1)
+}
+
+func (spell *Spell) logCombatCast(sim *Simulation, eventType proto.CombatLogEventType, target *Unit) {
+	if spell.Flags.Matches(SpellFlagNoLogs) || spell.ProcMask.Matches(ProcMaskWhiteHit) {
+		return
+	}
+	sim.addCombatLogEvent(&proto.CombatLogEvent{
+		Type:     eventType,
+		ActionId: spell.ActionID.ToProto(),
+		School:   spell.schoolProto(),
+	}, spell.Unit, target)
+}
+
+func (spell *Spell) logCombatResult(sim *Simulation, result *SpellResult, isPeriodic bool, isHealing bool) {
+	event := &proto.CombatLogEvent{
+		Type:     proto.CombatLogEventType_CombatLogEventDamage,
+		ActionId: spell.ActionID.ToProto(),
+		School:   spell.schoolProto(),
+		Outcome:  result.combatLogOutcome(spell),
+		Periodic: isPeriodic,
+		Amount:   result.Damage,
+	}
+	if isHealing {
+		event.Type = proto.CombatLogEventType_CombatLogEventHeal
+	} else if !result.Landed() {
+		event.Type = proto.CombatLogEventType_CombatLogEventMiss
+	}
+	if result.DidResist() {
+		var resisted float64
+		if result.Outcome.Matches(OutcomePartial1_4) {
+			resisted = 0.25
+		} else if result.Outcome.Matches(OutcomePartial2_4) {
+			resisted = 0.5
+		} else {
+			resisted = 0.75
+		}
+		event.Resisted = result.Damage / (1 - resisted) * resisted
+	}
+	sim.addCombatLogEvent(event, spell.Unit, result.Target)
+}
+
+func (result *SpellResult) combatLogOutcome(spell *Spell) proto.CombatLogOutcome {
+	switch {
+	case result.Outcome.Matches(OutcomeMiss):
+		if spell.SpellSchool.Matches(SpellSchoolPhysical) {
+			return proto.CombatLogOutcome_CombatLogOutcomeMiss
+		}
+		return proto.CombatLogOutcome_CombatLogOutcomeResist
+	case result.Outcome.Matches(OutcomeDodge):
+		return proto.CombatLogOutcome_CombatLogOutcomeDodge
+	case result.Outcome.Matches(OutcomeParry):
+		return proto.CombatLogOutcome_CombatLogOutcomeParry
+	case result.Outcome.Matches(OutcomeGlance):
+		return proto.CombatLogOutcome_CombatLogOutcomeGlance
+	case result.Outcome.Matches(OutcomeBlock):
+		return proto.CombatLogOutcome_CombatLogOutcomeBlock
+	case result.Outcome.Matches(OutcomeCrit):
+		return proto.CombatLogOutcome_CombatLogOutcomeCrit
+	case result.Outcome.Matches(OutcomeCrush):
+		return proto.CombatLogOutcome_CombatLogOutcomeCrush
+	case result.Outcome.Matches(OutcomeHit):
+		return proto.CombatLogOutcome_CombatLogOutcomeHit
+	}
+	return proto.CombatLogOutcome_CombatLogOutcomeNone
+}
+
+func (aura *Aura) logCombatEvent(sim *Simulation, eventType proto.CombatLogEventType, previousStacks int32) {
+	if aura.ActionID.IsEmptyAction() {
+		return
+	}
+	sim.addCombatLogEvent(&proto.CombatLogEvent{
+		Type:           eventType,
+		ActionId:       aura.ActionID.ToProto(),
+		Stacks:         aura.stacks,
+		PreviousStacks: previousStacks,
+	}, aura.Unit, nil)
+}
+
+func (unit *Unit) logCombatResource(sim *Simulation, eventType proto.CombatLogEventType, resourceType proto.ResourceType, amount float64, actionID ActionID) {
+	sim.addCombatLogEvent(&proto.CombatLogEvent{
+		Type:         eventType,
+		ActionId:     actionID.ToProto(),
+		Amount:       amount,
+		ResourceType: resourceType,
+	}, unit, nil)
+}
+
+func (unit *Unit) logCombatEvent(sim *Simulation, eventType proto.CombatLogEventType) {
+	sim.addCombatLogEvent(&proto.CombatLogEvent{Type: eventType}, unit, nil)
+}
+
+// CombatLogJSONLines formats combat events as one protojson object per line.
+func CombatLogJSONLines(events []*proto.CombatLogEvent) (string, error) {
+	var sb strings.Builder
+	for _, event := range events {
+		line, err := protojson.Marshal(event)
+		if err != nil {
+			return "", err
+		}
+		sb.Write(line)
+		sb.WriteString("\n")
+	}
+	return sb.String(), nil
+}
+
+// CombatLogText formats combat events like the vanilla in-game combat log, so they can be
+// read by tools which parse real raid logs. The sim doesn't know spell names, so actionName
+// is used to look them up. If it's nil, or returns an empty string, a placeholder with the ID
+// is used instead.
+func CombatLogText(events []*proto.CombatLogEvent, start time.Time, actionName func(*proto.ActionID) string) string {
+	name := func(id *proto.ActionID) string {
+		if actionName != nil {
+			if n := actionName(id); n != "" {
+				return n
+			}
+		}
+		switch {
+		case id.GetSpellId() != 0:
+			return "Spell " + strconv.Itoa(int(id.GetSpellId()))
+		case id.GetItemId() != 0:
+			return "Item " + strconv.Itoa(int(id.GetItemId()))
+		default:
+			return "Action " + strconv.Itoa(int(id.GetOtherId()))
+		}
+	}
+
+	var sb strings.Builder
+	for _, event := range events {
+		line := combatLogLine(event, name)
+		if line == "" {
+			continue
+		}
+		ts := start.Add(time.Duration(event.Timestamp * float64(time.Second)))
+		sb.WriteString(ts.Format("1/2 15:04:05.000"))
+		sb.WriteString("  ")
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+var combatLogSchoolNames = map[proto.SpellSchool]string{
+	proto.SpellSchool_SpellSchoolPhysical: "Physical",
+	proto.SpellSchool_SpellSchoolArcane:   "Arcane",
+	proto.SpellSchool_SpellSchoolFire:     "Fire",
+	proto.SpellSchool_SpellSchoolFrost:    "Frost",
+	proto.SpellSchool_SpellSchoolHoly:     "Holy",
+	proto.SpellSchool_SpellSchoolNature:   "Nature",
+	proto.SpellSchool_SpellSchoolShadow:   "Shadow",
+}
+
+var combatLogResourceNames = map[proto.ResourceType]string{
+	proto.ResourceType_ResourceTypeMana:        "Mana",
+	proto.ResourceType_ResourceTypeEnergy:      "Energy",
+	proto.ResourceType_ResourceTypeRage:        "Rage",
+	proto.ResourceType_ResourceTypeComboPoints: "Combo Points",
+	proto.ResourceType_ResourceTypeFocus:       "Focus",
+	proto.ResourceType_ResourceTypeHealth:      "Health",
+}
+
+func combatLogLine(event *proto.CombatLogEvent, name func(*proto.ActionID) string) string {
+	source, target := event.SourceName, event.TargetName
+	isAuto := event.GetActionId().GetOtherId() == proto.OtherAction_OtherActionAttack ||
+		event.GetActionId().GetOtherId() == proto.OtherAction_OtherActionShoot
+
+	switch event.Type {
+	case proto.CombatLogEventType_CombatLogEventCastStart:
+		return fmt.Sprintf("%s begins to cast %s.", source, name(event.ActionId))
+	case proto.CombatLogEventType_CombatLogEventCastSuccess:
+		if target == "" || target == source {
+			return fmt.Sprintf("%s casts %s.", source, name(event.ActionId))
+		}
+		return fmt.Sprintf("%s casts %s on %s.", source, name(event.ActionId), target)
+	case proto.CombatLogEventType_CombatLogEventDamage:
+		amount := fmt.Sprintf("%d", int(event.Amount+0.5))
+		suffix := ""
+		if event.Resisted > 0 {
+			suffix = fmt.Sprintf(" (%d resisted)", int(event.Resisted+0.5))
+		}
+		switch event.Outcome {
+		case proto.CombatLogOutcome_CombatLogOutcomeGlance:
+			suffix += " (glancing)"
+		case proto.CombatLogOutcome_CombatLogOutcomeCrush:
+			suffix += " (crushing)"
+		case proto.CombatLogOutcome_CombatLogOutcomeBlock:
+			suffix += " (blocked)"
+		}
+		if event.Periodic {
+			return fmt.Sprintf("%s suffers %s %s damage from %s's %s.%s", target, amount, combatLogSchoolNames[event.School], source, name(event.ActionId), suffix)
+		}
+		verb := "hits"
+		if event.Outcome == proto.CombatLogOutcome_CombatLogOutcomeCrit {
+			verb = "crits"
+		}
+		if isAuto {
+			return fmt.Sprintf("%s %s %s for %s.%s", source, verb, target, amount, suffix)
+		}
+		return fmt.Sprintf("%s's %s %s %s for %s %s damage.%s", source, name(event.ActionId), verb, target, amount, combatLogSchoolNames[event.School], suffix)
+	case proto.CombatLogEventType_CombatLogEventMiss:
+		var outcome string
+		switch event.Outcome {
+		case proto.CombatLogOutcome_CombatLogOutcomeDodge:
+			outcome = "dodged"
+		case proto.CombatLogOutcome_CombatLogOutcomeParry:
+			outcome = "parried"
+		case proto.CombatLogOutcome_CombatLogOutcomeResist:
+			outcome = "resisted"
+		default:
+			if isAuto {
+				return fmt.Sprintf("%s misses %s.", source, target)
+			}
+			return fmt.Sprintf("%s's %s missed %s.", source, name(event.ActionId), target)
+		}
+		if isAuto {
+			return fmt.Sprintf("%s attacks. %s %ss.", source, target, strings.TrimSuffix(outcome, "d"))
+		}
+		return fmt.Sprintf("%s's %s was %s by %s.", source, name(event.ActionId), outcome, target)
+	case proto.CombatLogEventType_CombatLogEventHeal:
+		verb := "heals"
+		if event.Outcome == proto.CombatLogOutcome_CombatLogOutcomeCrit {
+			verb = "critically heals"
+		}
+		if event.Periodic {
+			return fmt.Sprintf("%s gains %d health from %s's %s.", target, int(event.Amount+0.5), source, name(event.ActionId))
+		}
+		return fmt.Sprintf("%s's %s %s %s for %d.", source, name(event.ActionId), verb, target, int(event.Amount+0.5))
+	case proto.CombatLogEventType_CombatLogEventAuraApplied:
+		return fmt.Sprintf("%s gains %s (1).", source, name(event.ActionId))
+	case proto.CombatLogEventType_CombatLogEventAuraStacks:
+		// Only gained stacks are logged in-game, and the first one is logged when the aura is applied.
+		if event.Stacks > event.PreviousStacks && event.Stacks > 1 {
+			return fmt.Sprintf("%s gains %s (%d).", source, name(event.ActionId), event.Stacks)
+		}
+	case proto.CombatLogEventType_CombatLogEventAuraRemoved:
+		return fmt.Sprintf("%s fades from %s.", name(event.ActionId), source)
+	case proto.CombatLogEventType_CombatLogEventResourceGain:
+		// Regen ticks aren't logged in-game, and health gains are already logged as heals.
+		switch event.GetActionId().GetOtherId() {
+		case proto.OtherAction_OtherActionManaRegen, proto.OtherAction_OtherActionEnergyRegen, proto.OtherAction_OtherActionFocusRegen:
+			return ""
+		}
+		if event.ResourceType == proto.ResourceType_ResourceTypeHealth {
+			return ""
+		}
+		return fmt.Sprintf("%s gains %d %s from %s.", source, int(event.Amount+0.5), combatLogResourceNames[event.ResourceType], name(event.ActionId))
+	case proto.CombatLogEventType_CombatLogEventUnitDied:
+		return fmt.Sprintf("%s dies.", source)
+	}
+	// Other events have no equivalent in the in-game log.
+	return ""
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+)
+
+func TestCombatLogRecordsFirstIteration(t *testing.T) {
+	sim := setupFakeTargetSim([]*proto.Target{
+		{Name: "boss", Level: 63},
+		{Name: "add", Level: 63, SpawnTime: 10, DespawnTime: 30},
+	}, nil)
+	sim.Cleanup() // run() resets the sim itself.
+	sim.Options.Iterations = 3
+	sim.Options.RecordCombatLog = true
+
+	result := sim.run()
+
+	var unitEvents []*proto.CombatLogEvent
+	for _, event := range result.CombatLog {
+		if event.Type == proto.CombatLogEventType_CombatLogEventUnitSpawn || event.Type == proto.CombatLogEventType_CombatLogEventUnitDespawn {
+			unitEvents = append(unitEvents, event)
+		}
+	}
+
+	// The add starts despawned, then spawns and despawns once per iteration.
+	if len(unitEvents) != 3 {
+		t.Fatalf("Expected events from the first iteration only, got %v", unitEvents)
+	}
+	add := sim.Encounter.Targets[1]
+	for i, want := range []float64{0, 10, 30} {
+		if unitEvents[i].Timestamp != want || unitEvents[i].SourceGuid != add.GUID() || unitEvents[i].SourceName != "add" {
+			t.Fatalf("Unexpected event %d: %v", i, unitEvents[i])
+		}
+	}
+	if !strings.HasPrefix(add.GUID(), "0xF130") {
+		t.Fatalf("Targets should use creature GUIDs, got %s", add.GUID())
+	}
+}
+
+func TestCombatLogText(t *testing.T) {
+	fireball := ActionID{SpellID: 10151}.ToProto()
+	events := []*proto.CombatLogEvent{
+		{Timestamp: 0, Type: proto.CombatLogEventType_CombatLogEventCastStart, SourceName: "Mage", TargetName: "Boss", ActionId: fireball},
+		{Timestamp: 3, Type: proto.CombatLogEventType_CombatLogEventDamage, SourceName: "Mage", TargetName: "Boss", ActionId: fireball,
+			School: proto.SpellSchool_SpellSchoolFire, Outcome: proto.CombatLogOutcome_CombatLogOutcomeCrit, Amount: 1499.6},
+		{Timestamp: 5, Type: proto.CombatLogEventType_CombatLogEventDamage, SourceName: "Mage", TargetName: "Boss", ActionId: fireball,
+			School: proto.SpellSchool_SpellSchoolFire, Outcome: proto.CombatLogOutcome_CombatLogOutcomeHit, Amount: 75, Resisted: 25, Periodic: true},
+		{Timestamp: 6.5, Type: proto.CombatLogEventType_CombatLogEventMiss, SourceName: "Mage", TargetName: "Boss", ActionId: fireball,
+			School: proto.SpellSchool_SpellSchoolFire, Outcome: proto.CombatLogOutcome_CombatLogOutcomeResist},
+		{Timestamp: 7, Type: proto.CombatLogEventType_CombatLogEventAuraStacks, SourceName: "Boss", ActionId: ActionID{SpellID: 22959}.ToProto(), Stacks: 1},
+		{Timestamp: 8, Type: proto.CombatLogEventType_CombatLogEventMiss, SourceName: "Warrior", TargetName: "Boss",
+			ActionId: ActionID{OtherID: proto.OtherAction_OtherActionAttack}.ToProto(), Outcome: proto.CombatLogOutcome_CombatLogOutcomeDodge},
+	}
+	names := func(id *proto.ActionID) string {
+		if id.GetSpellId() == 10151 {
+			return "Fireball"
+		}
+		return ""
+	}
+
+	got := CombatLogText(events, time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC), names)
+	want := strings.Join([]string{
+		"5/1 20:00:00.000  Mage begins to cast Fireball.",
+		"5/1 20:00:03.000  Mage's Fireball crits Boss for 1500 Fire damage.",
+		"5/1 20:00:05.000  Boss suffers 75 Fire damage from Mage's Fireball. (25 resisted)",
+		"5/1 20:00:06.500  Mage's Fireball was resisted by Boss.",
+		"5/1 20:00:08.000  Warrior attacks. Boss dodges.",
+	}, "\n") + "\n"
+	if got != want {
+		t.Fatalf("Unexpected combat log text:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,127 @@
+package core
+
+import (
+	"math"
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+	"github.com/isfir/wowsims-turtle/sim/core/simsignals"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// Returns the mean of compared - base over the iterations of two results which
+// used the same seeds, with its standard error, confidence interval and the
+// two-sided p-value of it being different from 0.
+func pairedDifference(base *proto.DistributionMetrics, compared *proto.DistributionMetrics, confidence float64) *proto.PairedDifference {
+	var diffs aggregator
+	for i := 0; i < min(len(base.AllValues), len(compared.AllValues)); i++ {
+		diffs.add(compared.AllValues[i] - base.AllValues[i])
+	}
+
+	diff := &proto.PairedDifference{
+		Mean:   compared.Avg - base.Avg,
+		PValue: 1,
+	}
+	if diffs.n == 0 {
+		return diff
+	}
+
+	_, stdev := diffs.meanAndStdDev()
+	if math.IsNaN(stdev) {
+		// Rounding can make the variance slightly negative when all differences are equal.
+		stdev = 0
+	}
+	diff.Stderr = stdev / math.Sqrt(float64(diffs.n))
+
+	z := math.Sqrt2 * math.Erfinv(confidence)
+	diff.CiLow = diff.Mean - z*diff.Stderr
+	diff.CiHigh = diff.Mean + z*diff.Stderr
+
+	if diff.Stderr > 0 {
+		diff.PValue = math.Erfc(math.Abs(diff.Mean/diff.Stderr) / math.Sqrt2)
+	} else if diff.Mean != 0 {
+		diff.PValue = 0
+	}
+	diff.Significant = diff.PValue < 1-confidence
+	return diff
+}
+
+func computeComparison(base *proto.RaidSimResult, compared *proto.RaidSimResult, confidence float64) *proto.CompareResult {
+	result := &proto.CompareResult{
+		RaidDps: pairedDifference(base.RaidMetrics.Dps, compared.RaidMetrics.Dps, confidence),
+	}
+
+	for partyIdx, party := range base.RaidMetrics.Parties {
+		if partyIdx >= len(compared.RaidMetrics.Parties) {
+			break
+		}
+		comparedParty := compared.RaidMetrics.Parties[partyIdx]
+		for playerIdx, player := range party.Players {
+			if playerIdx >= len(comparedParty.Players) || player.Dps.GetAggregatorData().GetN() == 0 {
+				// Empty raid slot.
+				continue
+			}
+			result.Players = append(result.Players, &proto.PlayerComparison{
+				Name: player.Name,
+				Dps:  pairedDifference(player.Dps, comparedParty.Players[playerIdx].Dps, confidence),
+			})
+		}
+	}
+
+	return result
+}
+
+// Run both requests with the same seeds and iterations, and compare their dps.
+func runCompare(request *proto.CompareRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) *proto.CompareResult {
+	if request.BaseRequest == nil || request.ComparedRequest == nil {
+		return &proto.CompareResult{Error: &proto.ErrorOutcome{Message: "Compare needs a base and a compared request!"}}
+	}
+
+	confidence := request.ConfidenceLevel
+	if confidence <= 0 || confidence >= 1 {
+		confidence = 0.95
+	}
+
+	baseRequest := googleProto.Clone(request.BaseRequest).(*proto.RaidSimRequest)
+	if baseRequest.SimOptions == nil {
+		baseRequest.SimOptions = &proto.SimOptions{}
+	}
+	baseRequest.SimOptions.SaveAllValues = true
+	// Both sims need the same seed, so that their iterations can be paired.
+	if baseRequest.SimOptions.RandomSeed == 0 {
+		baseRequest.SimOptions.RandomSeed = time.Now().UnixNano()
+	}
+	// Keeps the compared sim's rolls lined up with the base sim's.
+	baseRequest.SimOptions.UseLabeledRands = true
+
+	comparedRequest := googleProto.Clone(request.ComparedRequest).(*proto.RaidSimRequest)
+	comparedRequest.SimOptions = googleProto.Clone(baseRequest.SimOptions).(*proto.SimOptions)
+
+	sp := &simSeriesProgress{
+		progress:        progress,
+		iterationsTotal: baseRequest.SimOptions.Iterations * 2,
+		simsTotal:       2,
+	}
+
+	simFunc := runSimConcurrent
+	// Don't use go threads in wasm, it just adds more overhead and makes the worker more unresponsive.
+	if IsRunningInWasm() || baseRequest.SimOptions.IsTest {
+		simFunc = RunSim
+	}
+
+	baseProgress := make(chan *proto.ProgressMetrics, 100)
+	go simFunc(baseRequest, baseProgress, signals)
+	baseResult := sp.waitForResult(baseProgress)
+	if baseResult.Error != nil {
+		return &proto.CompareResult{Error: baseResult.Error}
+	}
+
+	comparedProgress := make(chan *proto.ProgressMetrics, 100)
+	go simFunc(comparedRequest, comparedProgress, signals)
+	comparedResult := sp.waitForResult(comparedProgress)
+	if comparedResult.Error != nil {
+		return &proto.CompareResult{Error: comparedResult.Error}
+	}
+
+	return computeComparison(baseResult, comparedResult, confidence)
+}
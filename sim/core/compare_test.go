@@ -0,0 +1,114 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+	"github.com/isfir/wowsims-turtle/sim/core/simsignals"
+)
+
+func TestPairedDifference(t *testing.T) {
+	base := &proto.DistributionMetrics{Avg: 102.5, AllValues: []float64{100, 110, 90, 110}}
+	compared := &proto.DistributionMetrics{Avg: 106.25, AllValues: []float64{105, 115, 90, 115}}
+
+	// Differences are 5, 5, 0, 5: stdev sqrt(75/16 - 3.75^2) = 2.165, over sqrt(4).
+	diff := pairedDifference(base, compared, 0.95)
+	stderr := math.Sqrt(75.0/16-3.75*3.75) / 2
+	if diff.Mean != 3.75 || math.Abs(diff.Stderr-stderr) > 1e-9 {
+		t.Fatalf("Unexpected difference %0.3f +/- %0.3f", diff.Mean, diff.Stderr)
+	}
+	if math.Abs(diff.CiLow-(3.75-1.959964*stderr)) > 1e-5 || math.Abs(diff.CiHigh-(3.75+1.959964*stderr)) > 1e-5 {
+		t.Fatalf("Unexpected interval [%0.3f, %0.3f]", diff.CiLow, diff.CiHigh)
+	}
+	// 3.75 / 1.083 = 3.46 standard errors.
+	if math.Abs(diff.PValue-math.Erfc(3.75/stderr/math.Sqrt2)) > 1e-9 || !diff.Significant {
+		t.Fatalf("Unexpected p-value %0.5f", diff.PValue)
+	}
+
+	// Identical results have no difference at all.
+	if diff := pairedDifference(base, base, 0.95); diff.Mean != 0 || diff.PValue != 1 || diff.Significant {
+		t.Fatalf("Expected no difference, got %v", diff)
+	}
+}
+
+func TestFillDistributionStats(t *testing.T) {
+	dm := &proto.DistributionMetrics{
+		Avg:            5.5,
+		Stdev:          math.Sqrt(8.25),
+		AllValues:      []float64{10, 9, 8, 7, 6, 5, 4, 3, 2, 1},
+		AggregatorData: &proto.AggregatorData{N: 10},
+	}
+	fillDistributionStats(dm, 0, []float64{0.05, 0.5, 0.95})
+
+	stderr := math.Sqrt(8.25) / math.Sqrt(10)
+	if math.Abs(dm.Stderr-stderr) > 1e-9 || dm.ConfidenceLevel != 0.95 {
+		t.Fatalf("Unexpected stderr %0.3f at %0.2f", dm.Stderr, dm.ConfidenceLevel)
+	}
+	if math.Abs(dm.CiHigh-dm.Avg-1.959964*stderr) > 1e-5 || math.Abs(dm.Avg-dm.CiLow-1.959964*stderr) > 1e-5 {
+		t.Fatalf("Unexpected interval [%0.3f, %0.3f]", dm.CiLow, dm.CiHigh)
+	}
+
+	want := []float64{1, 5, 10}
+	for i, p := range dm.Percentiles {
+		if p.Value != want[i] {
+			t.Fatalf("Percentile %0.2f = %0.1f, want %0.1f", p.P, p.Value, want[i])
+		}
+	}
+}
+
+func TestConcurrentPercentiles(t *testing.T) {
+	request := &proto.RaidSimRequest{
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{
+				{
+					Players: []*proto.Player{
+						{
+							Name:      "Tank",
+							Class:     proto.Class_ClassShaman,
+							Consumes:  &proto.Consumes{},
+							Buffs:     &proto.IndividualBuffs{},
+							Spec:      &proto.Player_ElementalShaman{},
+							Equipment: &proto.EquipmentSpec{},
+						},
+					},
+					Buffs: &proto.PartyBuffs{},
+				},
+			},
+			Tanks: []*proto.UnitReference{{Type: proto.UnitReference_Player, Index: 0}},
+		},
+		Encounter: &proto.Encounter{
+			Targets:           []*proto.Target{{Name: "boss", Level: 63, MinBaseDamage: 1000, SwingSpeed: 2, DamageSpread: 0.3}},
+			Duration:          60,
+			DurationVariation: 20,
+		},
+		SimOptions: &proto.SimOptions{
+			Iterations:  30,
+			RandomSeed:  101,
+			IsTest:      true,
+			Percentiles: []float64{0.1, 0.5, 0.9},
+		},
+	}
+
+	single := RunSim(request, nil, simsignals.CreateSignals())
+	concurrent := runSimConcurrent(request, nil, simsignals.CreateSignals())
+	if single.Error != nil || concurrent.Error != nil {
+		t.Fatalf("Sim failed: %v %v", single.Error, concurrent.Error)
+	}
+
+	// The splits cover the same seeds as the single sim, so the merged values give the
+	// same percentiles, and the values are dropped again as they weren't requested.
+	want := single.RaidMetrics.Parties[0].Players[0].Dtps
+	got := concurrent.RaidMetrics.Parties[0].Players[0].Dtps
+	if want.Percentiles[0].Value == want.Percentiles[2].Value {
+		t.Fatalf("Expected the damage taken to vary, got %v", want.Percentiles)
+	}
+	for i, p := range want.Percentiles {
+		if math.Abs(got.Percentiles[i].Value-p.Value) > 1e-6 {
+			t.Fatalf("Percentile %0.2f = %0.3f, want %0.3f", p.P, got.Percentiles[i].Value, p.Value)
+		}
+	}
+	if len(got.AllValues) != 0 {
+		t.Fatalf("Expected no saved values, got %d", len(got.AllValues))
+	}
+}
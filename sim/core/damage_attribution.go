@@ -0,0 +1,180 @@
+package core
+
+import (
+	"slices"
+
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+)
+
+// A multiplicative damage modifier applied by an aura, whose contribution to the
+// damage of each unit is reported in UnitMetrics.damage_attributions.
+//
+// Attribution doesn't change any damage, so the aura still needs to apply the
+// modifier itself.
+type DamageAttribution struct {
+	// Defaults to the ActionID of the aura.
+	ActionID ActionID
+
+	Multiplier float64
+
+	// If set, the multiplier is 1 + MultiplierPerStack * stacks instead.
+	MultiplierPerStack float64
+
+	// Schools affected by the modifier, or SpellSchoolNone for all schools.
+	School SpellSchool
+
+	// Only affects bleeds, i.e. periodic physical damage.
+	Bleed bool
+
+	// Modifies the damage taken by the aura's unit instead of the damage it deals.
+	Taken bool
+
+	aura *Aura
+}
+
+func (da *DamageAttribution) multiplier() float64 {
+	if da.MultiplierPerStack != 0 {
+		return 1 + da.MultiplierPerStack*float64(da.aura.GetStacks())
+	}
+	return da.Multiplier
+}
+
+func (da *DamageAttribution) appliesTo(spell *Spell, isPeriodic bool) bool {
+	if da.Bleed && !(isPeriodic && spell.SpellSchool.Matches(SpellSchoolPhysical)) {
+		return false
+	}
+	return da.School == SpellSchoolNone || spell.SpellSchool.Matches(da.School)
+}
+
+func (aura *Aura) newDamageAttribution(config DamageAttribution) *DamageAttribution {
+	da := &DamageAttribution{}
+	*da = config
+	da.aura = aura
+	if da.ActionID.IsEmptyAction() {
+		da.ActionID = aura.ActionID
+	}
+	return da
+}
+
+// Tracks the contribution of a damage modifier applied by a parent Aura. Only the
+// first attribution attached to an aura is used, so this is safe to call again
+// on auras from GetOrRegisterAura().
+func (parentAura *Aura) AttachDamageAttribution(config DamageAttribution) *Aura {
+	if parentAura.damageAttribution != nil {
+		return parentAura
+	}
+	da := parentAura.newDamageAttribution(config)
+	parentAura.damageAttribution = da
+
+	parentAura.ApplyOnGain(func(aura *Aura, _ *Simulation) {
+		aura.Unit.damageAttributions = append(aura.Unit.damageAttributions, da)
+	}).ApplyOnExpire(func(aura *Aura, _ *Simulation) {
+		aura.Unit.removeDamageAttribution(da)
+	})
+
+	return parentAura
+}
+
+// Tracks the contribution of a damage modifier applied by an ExclusiveEffect, which
+// only counts while the effect is the active one in its category.
+func (ee *ExclusiveEffect) AttachDamageAttribution(config DamageAttribution) *ExclusiveEffect {
+	if ee.damageAttribution != nil {
+		return ee
+	}
+	da := ee.Aura.newDamageAttribution(config)
+	ee.damageAttribution = da
+
+	oldOnGain := ee.OnGain
+	ee.OnGain = func(ee *ExclusiveEffect, sim *Simulation) {
+		if oldOnGain != nil {
+			oldOnGain(ee, sim)
+		}
+		ee.Aura.Unit.damageAttributions = append(ee.Aura.Unit.damageAttributions, da)
+	}
+	oldOnExpire := ee.OnExpire
+	ee.OnExpire = func(ee *ExclusiveEffect, sim *Simulation) {
+		if oldOnExpire != nil {
+			oldOnExpire(ee, sim)
+		}
+		ee.Aura.Unit.removeDamageAttribution(da)
+	}
+
+	return ee
+}
+
+func (unit *Unit) removeDamageAttribution(da *DamageAttribution) {
+	if i := slices.Index(unit.damageAttributions, da); i != -1 {
+		unit.damageAttributions = slices.Delete(unit.damageAttributions, i, i+1)
+	}
+}
+
+// Splits the damage of a result between the active damage modifiers of the caster
+// and the target. Each modifier gets the damage which it added on its own.
+func (spell *Spell) attributeDamage(isPeriodic bool, result *SpellResult) {
+	if !spell.Flags.Matches(SpellFlagIgnoreAttackerModifiers) {
+		for _, da := range spell.Unit.damageAttributions {
+			if !da.Taken && da.appliesTo(spell, isPeriodic) {
+				spell.Unit.Metrics.addAttributedDamage(da.ActionID, result.Damage*(1-1/da.multiplier()))
+			}
+		}
+	}
+
+	if !spell.Flags.Matches(SpellFlagIgnoreTargetModifiers) {
+		for _, da := range result.Target.damageAttributions {
+			if da.Taken && da.appliesTo(spell, isPeriodic) {
+				spell.Unit.Metrics.addAttributedDamage(da.ActionID, result.Damage*(1-1/da.multiplier()))
+			}
+		}
+	}
+}
+
+type DamageAttributionMetrics struct {
+	ID ActionID
+
+	// Metrics for the current iteration.
+	Damage float64
+
+	// Aggregate values. These are updated after each iteration.
+	aggregator
+}
+
+func (unitMetrics *UnitMetrics) damageAttribution(actionID ActionID) *DamageAttributionMetrics {
+	for _, dam := range unitMetrics.damageAttributions {
+		if dam.ID == actionID {
+			return dam
+		}
+	}
+
+	// Modifiers are found as they first apply, so earlier iterations count as 0 damage.
+	dam := &DamageAttributionMetrics{
+		ID:         actionID,
+		aggregator: aggregator{n: unitMetrics.dps.n},
+	}
+	unitMetrics.damageAttributions = append(unitMetrics.damageAttributions, dam)
+	return dam
+}
+
+func (unitMetrics *UnitMetrics) addAttributedDamage(actionID ActionID, damage float64) {
+	unitMetrics.damageAttribution(actionID).Damage += damage
+}
+
+// This should be called when a Sim iteration is complete.
+func (dam *DamageAttributionMetrics) doneIteration(sim *Simulation) {
+	dam.add(dam.Damage / sim.Duration.Seconds())
+}
+
+func (dam *DamageAttributionMetrics) ToProto() *proto.DamageAttributionMetrics {
+	mean, stdev := dam.meanAndStdDev()
+
+	return &proto.DamageAttributionMetrics{
+		Id: dam.ID.ToProto(),
+
+		DpsAvg:   mean,
+		DpsStdev: stdev,
+
+		AggregatorData: &proto.AggregatorData{
+			N:     int32(dam.n),
+			SumSq: dam.sumSq,
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+	"github.com/isfir/wowsims-turtle/sim/core/simsignals"
+)
+
+func TestDamageAttribution(t *testing.T) {
+	sim := NewSim(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{
+			RandomSeed: 100,
+		},
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{
+				{
+					Players: []*proto.Player{
+						{
+							Name:      "Caster",
+							Class:     proto.Class_ClassShaman,
+							Consumes:  &proto.Consumes{},
+							Buffs:     &proto.IndividualBuffs{},
+							Spec:      &proto.Player_ElementalShaman{},
+							Equipment: &proto.EquipmentSpec{},
+						},
+					},
+					Buffs: &proto.PartyBuffs{},
+				},
+			},
+			Debuffs: &proto.Debuffs{
+				CurseOfShadow: true,
+			},
+		},
+		Encounter: &proto.Encounter{
+			Targets: []*proto.Target{
+				{Name: "target", Level: 63, MobType: proto.MobType_MobTypeDemon},
+			},
+			Duration: 180,
+		},
+	}, simsignals.CreateSignals())
+	sim.Reset()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	target := sim.Encounter.TargetUnits[0]
+
+	// 100 * 1.5 (spell) * 1.1 (Curse of Shadow), of which 15 comes from the curse.
+	result := fa.Spell.CalcAndDealDamage(sim, target, 100, fa.Spell.OutcomeAlwaysHit)
+	if !WithinToleranceFloat64(165, result.Damage, 0.001) {
+		t.Fatalf("Expected 165 damage, got %0.3f", result.Damage)
+	}
+
+	attributions := fa.Metrics.damageAttributions
+	if len(attributions) != 1 || attributions[0].ID != (ActionID{SpellID: 17937}) {
+		t.Fatalf("Expected damage attributed to Curse of Shadow only, got %v", attributions)
+	}
+	if !WithinToleranceFloat64(15, attributions[0].Damage, 0.001) {
+		t.Fatalf("Expected 15 attributed damage, got %0.3f", attributions[0].Damage)
+	}
+}
@@ -224,6 +224,7 @@ func ExternalStormstrikeCaster(_ *proto.Debuffs, target *Unit) {
 
 func StormstrikeAura(unit *Unit) *Aura {
 	stormstrikeConfig := unit.Env.Raid.Parties[0].Players[0].GetCharacter().StormstrikeConfig
+	natureMultiplier := 1.20
 
 	aura := unit.GetOrRegisterAura(Aura{
 		Label:     "Stormstrike",
@@ -231,17 +232,17 @@ func StormstrikeAura(unit *Unit) *Aura {
 		Duration:  time.Second * 12,
 		MaxStacks: 2,
 		OnGain: func(aura *Aura, sim *Simulation) {
-			aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexNature] *= 1.20
+			aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexNature] *= natureMultiplier
 		},
 		OnExpire: func(aura *Aura, sim *Simulation) {
-			aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexNature] /= 1.20
+			aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexNature] /= natureMultiplier
 		},
 		OnSpellHitTaken: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
 			if aura.GetStacks() > 0 && spell.SpellSchool.Matches(SpellSchoolNature) && result.Landed() && result.Damage > 0 {
 				aura.RemoveStack(sim)
 			}
 		},
-	})
+	}).AttachDamageAttribution(DamageAttribution{Multiplier: natureMultiplier, School: SpellSchoolNature, Taken: true})
 
 	// External attacks using nature strike
 	if stormstrikeConfig.natureAttackersFrequency > 0 {
@@ -374,7 +375,7 @@ func ImprovedShadowBoltAura(unit *Unit, rank int32) *Aura {
 				aura.RemoveStack(sim)
 			}
 		},
-	})
+	}).AttachDamageAttribution(DamageAttribution{Multiplier: damageMulti, School: SpellSchoolShadow, Taken: true})
 
 	return aura
 }
@@ -383,16 +384,17 @@ var ShadowWeavingSpellIDs = [6]int32{0, 15257, 15331, 15332, 15333, 15334}
 
 func ShadowWeavingAura(unit *Unit, rank int) *Aura {
 	spellId := ShadowWeavingSpellIDs[rank]
+	multiplierPerStack := 0.03
 	return unit.GetOrRegisterAura(Aura{
 		Label:     "Shadow Weaving",
 		ActionID:  ActionID{SpellID: spellId},
 		Duration:  time.Second * 15,
 		MaxStacks: 5,
 		OnStacksChange: func(aura *Aura, sim *Simulation, oldStacks int32, newStacks int32) {
-			aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexShadow] /= 1.0 + 0.03*float64(oldStacks)
-			aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexShadow] *= 1.0 + 0.03*float64(newStacks)
+			aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexShadow] /= 1.0 + multiplierPerStack*float64(oldStacks)
+			aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexShadow] *= 1.0 + multiplierPerStack*float64(newStacks)
 		},
-	})
+	}).AttachDamageAttribution(DamageAttribution{MultiplierPerStack: multiplierPerStack, School: SpellSchoolShadow, Taken: true})
 }
 
 func SchedulePeriodicDebuffApplication(aura *Aura, options PeriodicActionOptions, _ *proto.Raid) {
@@ -536,7 +538,7 @@ func spellSchoolDamageEffect(aura *Aura, school stats.SchoolIndex, multiplier fl
 		OnExpire: func(ee *ExclusiveEffect, sim *Simulation) {
 			ee.Aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[school] /= multiplier
 		},
-	})
+	}).AttachDamageAttribution(DamageAttribution{Multiplier: multiplier, School: SpellSchoolFromIndex(school), Taken: true})
 }
 
 func spellSchoolResistanceEffect(aura *Aura, school stats.SchoolIndex, amount float64, extraPriority float64, exclusive bool) *ExclusiveEffect {
@@ -615,23 +617,24 @@ func bleedDamageAura(target *Unit, config Aura, multiplier float64) *Aura {
 		OnExpire: func(ee *ExclusiveEffect, sim *Simulation) {
 			ee.Aura.Unit.PseudoStats.BleedDamageTakenMultiplier /= multiplier
 		},
-	})
+	}).AttachDamageAttribution(DamageAttribution{Multiplier: multiplier, Bleed: true, Taken: true})
 	return aura
 }
 
 const SpellFirePowerEffectCategory = "spellFirePowerdebuff"
 
 func ImprovedScorchAura(target *Unit) *Aura {
+	multiplierPerStack := 0.03
 	aura := target.GetOrRegisterAura(Aura{
 		Label:     "Improved Scorch",
 		ActionID:  ActionID{SpellID: 12873},
 		Duration:  time.Second * 30,
 		MaxStacks: 5,
 		OnStacksChange: func(aura *Aura, sim *Simulation, oldStacks int32, newStacks int32) {
-			aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexFire] /= 1 + .03*float64(oldStacks)
-			aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexFire] *= 1 + .03*float64(newStacks)
+			aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexFire] /= 1 + multiplierPerStack*float64(oldStacks)
+			aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[stats.SchoolIndexFire] *= 1 + multiplierPerStack*float64(newStacks)
 		},
-	})
+	}).AttachDamageAttribution(DamageAttribution{MultiplierPerStack: multiplierPerStack, School: SpellSchoolFire, Taken: true})
 
 	return aura
 }
@@ -0,0 +1,167 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+)
+
+// Panics if any phase references a target that isn't part of the encounter.
+func (encounter *Encounter) validatePhases() {
+	numTargets := int32(len(encounter.Targets))
+	validate := func(phase *proto.EncounterPhase, indices ...int32) {
+		for _, index := range indices {
+			if index < 0 || index >= numTargets {
+				panic(fmt.Sprintf("[USER_ERROR] Encounter phase %q references target %d, but the encounter only has %d targets", phase.Name, index+1, numTargets))
+			}
+		}
+	}
+
+	for _, phase := range encounter.Phases {
+		validate(phase, phase.InvulnerableTargets...)
+		validate(phase, phase.SpawnTargets...)
+		validate(phase, phase.DespawnTargets...)
+		if phase.SwitchTarget {
+			validate(phase, phase.ActiveTarget)
+		}
+	}
+}
+
+// Health of the primary target as a percentage, based on damage taken in
+// health fights and on elapsed time otherwise.
+func (sim *Simulation) encounterHealthPercent() float64 {
+	if sim.Encounter.EndFightAtHealth > 0 {
+		return 100 * (1 - sim.Encounter.DamageTaken/sim.Encounter.EndFightAtHealth)
+	}
+	return 100 * (1 - float64(sim.CurrentTime)/float64(sim.Duration))
+}
+
+func (sim *Simulation) resetPhases() {
+	sim.nextPhase = 0
+	sim.activePhase = nil
+
+	if len(sim.Encounter.Phases) == 0 {
+		return
+	}
+
+	// Targets spawned by a phase are not part of the fight until that phase starts.
+	for _, phase := range sim.Encounter.Phases {
+		for _, index := range phase.SpawnTargets {
+			sim.Encounter.Targets[index].Despawn(sim)
+		}
+	}
+
+	sim.AddPendingAction(&PendingAction{
+		NextActionAt: 0,
+		Priority:     ActionPriorityPrePull,
+		OnAction: func(sim *Simulation) {
+			sim.advancePhases()
+		},
+	})
+}
+
+func (sim *Simulation) phaseReady(phase *proto.EncounterPhase) bool {
+	if phase.StartHealthPercent > 0 {
+		if sim.encounterHealthPercent() <= phase.StartHealthPercent {
+			return true
+		}
+		// A health-triggered phase without a start time only starts on health.
+		if phase.StartTime <= 0 {
+			return false
+		}
+	}
+	return sim.CurrentTime >= DurationFromSeconds(phase.StartTime)
+}
+
+func (sim *Simulation) advancePhases() {
+	for sim.nextPhase < len(sim.Encounter.Phases) && sim.phaseReady(sim.Encounter.Phases[sim.nextPhase]) {
+		phase := sim.Encounter.Phases[sim.nextPhase]
+		sim.nextPhase++
+		sim.startPhase(phase)
+	}
+}
+
+func (sim *Simulation) startPhase(phase *proto.EncounterPhase) {
+	if sim.Log != nil {
+		sim.Log("Starting encounter phase %q", phase.Name)
+	}
+
+	if sim.activePhase != nil {
+		sim.endPhase(sim.activePhase)
+	}
+	sim.activePhase = phase
+
+	for _, index := range phase.DespawnTargets {
+		sim.Encounter.Targets[index].Despawn(sim)
+	}
+	for _, index := range phase.SpawnTargets {
+		sim.Encounter.Targets[index].Spawn(sim)
+	}
+
+	for _, index := range phase.InvulnerableTargets {
+		sim.Encounter.TargetUnits[index].PseudoStats.Invulnerable = true
+	}
+	if phase.DamageTakenMultiplier != 0 {
+		for _, target := range sim.Encounter.TargetUnits {
+			target.PseudoStats.DamageTakenMultiplier *= phase.DamageTakenMultiplier
+		}
+	}
+
+	if phase.SwitchTarget {
+		newTarget := sim.Encounter.TargetUnits[phase.ActiveTarget]
+		for _, unit := range sim.Raid.AllUnits {
+			unit.CurrentTarget = newTarget
+		}
+	}
+
+	if phase.Downtime > 0 {
+		downtime := DurationFromSeconds(phase.Downtime)
+		for _, unit := range sim.Raid.AllUnits {
+			if unit.IsEnabled() {
+				unit.pauseFor(sim, downtime)
+			}
+		}
+	}
+
+	if phase.MovementTime > 0 {
+		movementTime := DurationFromSeconds(phase.MovementTime)
+		for _, unit := range sim.Raid.AllPlayerUnits {
+			unit.MoveFor(sim, movementTime)
+		}
+	}
+}
+
+// Undoes the lasting effects of a phase.
+func (sim *Simulation) endPhase(phase *proto.EncounterPhase) {
+	for _, index := range phase.InvulnerableTargets {
+		sim.Encounter.TargetUnits[index].PseudoStats.Invulnerable = false
+	}
+	if phase.DamageTakenMultiplier != 0 {
+		for _, target := range sim.Encounter.TargetUnits {
+			target.PseudoStats.DamageTakenMultiplier /= phase.DamageTakenMultiplier
+		}
+	}
+}
+
+// Stops the unit from attacking or starting new GCDs for the given duration.
+func (unit *Unit) pauseFor(sim *Simulation, duration time.Duration) {
+	resumeAt := sim.CurrentTime + duration
+
+	unit.AutoAttacks.CancelAutoSwing(sim)
+	if unit.IsChanneling(sim) {
+		unit.ChanneledDot.Cancel(sim)
+	}
+	if unit.gcdAction != nil {
+		unit.WaitUntil(sim, max(resumeAt, unit.GCD.ReadyAt()))
+	}
+
+	sim.AddPendingAction(&PendingAction{
+		NextActionAt: resumeAt,
+		OnAction: func(sim *Simulation) {
+			if unit.IsEnabled() {
+				unit.AutoAttacks.EnableAutoSwing(sim)
+			}
+		},
+	})
+}
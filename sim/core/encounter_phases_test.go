@@ -0,0 +1,81 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+)
+
+func setupFakePhaseSim(phases []*proto.EncounterPhase) *Simulation {
+	return setupFakeTargetSim([]*proto.Target{
+		{Name: "boss", Level: 63},
+		{Name: "twin", Level: 63},
+		{Name: "add", Level: 63},
+	}, phases)
+}
+
+func TestEncounterPhases(t *testing.T) {
+	sim := setupFakePhaseSim([]*proto.EncounterPhase{
+		{
+			Name:                  "Twin",
+			StartTime:             10,
+			InvulnerableTargets:   []int32{0},
+			DamageTakenMultiplier: 2,
+			SwitchTarget:          true,
+			ActiveTarget:          1,
+		},
+		{
+			Name:               "Add",
+			StartHealthPercent: 50,
+			SpawnTargets:       []int32{2},
+			DespawnTargets:     []int32{1},
+		},
+	})
+	player := &sim.Raid.Parties[0].Players[0].GetCharacter().Unit
+	boss, twin, add := sim.Encounter.TargetUnits[0], sim.Encounter.TargetUnits[1], sim.Encounter.TargetUnits[2]
+
+	if add.IsEnabled() {
+		t.Fatalf("Spawned target should start the fight despawned")
+	}
+
+	sim.advance(time.Second * 5)
+	if boss.PseudoStats.Invulnerable || player.CurrentTarget != boss {
+		t.Fatalf("No phase should have started at 5s")
+	}
+
+	sim.advance(time.Second * 10)
+	if !boss.PseudoStats.Invulnerable {
+		t.Fatalf("Boss should be invulnerable during the Twin phase")
+	}
+	if player.CurrentTarget != twin {
+		t.Fatalf("Player should have switched to the twin")
+	}
+	if twin.PseudoStats.DamageTakenMultiplier != 2 {
+		t.Fatalf("Expected damage taken multiplier 2, got %0.2f", twin.PseudoStats.DamageTakenMultiplier)
+	}
+
+	// 50% health is reached at 50s in a 100s duration fight.
+	sim.advance(time.Second * 50)
+	if boss.PseudoStats.Invulnerable || twin.PseudoStats.DamageTakenMultiplier != 1 {
+		t.Fatalf("Twin phase effects should be undone once the Add phase starts")
+	}
+	if !add.IsEnabled() || twin.IsEnabled() {
+		t.Fatalf("Add should have spawned and the twin despawned")
+	}
+	if player.CurrentTarget != boss {
+		t.Fatalf("Player targeting a despawned target should switch to the first active target")
+	}
+}
+
+func TestEncounterPhasesInvalidTarget(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected a phase referencing a missing target to panic")
+		}
+	}()
+
+	setupFakePhaseSim([]*proto.EncounterPhase{
+		{Name: "Broken", InvulnerableTargets: []int32{3}},
+	})
+}
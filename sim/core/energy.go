@@ -147,7 +147,7 @@ func (eb *energyBar) onEnergyGain(sim *Simulation, crossedThreshold bool) {
 		return
 	}
 
-	if !sim.Options.Interactive && crossedThreshold {
+	if !sim.IsInteractive(eb.unit) && crossedThreshold {
 		eb.unit.Rotation.DoNextAction(sim)
 	}
 }
@@ -163,6 +163,9 @@ func (eb *energyBar) addEnergyInternal(sim *Simulation, amount float64, metrics
 	if sim.Log != nil {
 		eb.unit.Log(sim, "Gained %0.3f energy from %s (%0.3f --> %0.3f).", amount, metrics.ActionID, eb.currentEnergy, newEnergy)
 	}
+	if sim.combatLog != nil {
+		eb.unit.logCombatResource(sim, proto.CombatLogEventType_CombatLogEventResourceGain, proto.ResourceType_ResourceTypeEnergy, amount, metrics.ActionID)
+	}
 
 	crossedThreshold := eb.cumulativeEnergyDecisionThresholds == nil || eb.cumulativeEnergyDecisionThresholds[int(eb.currentEnergy)] != eb.cumulativeEnergyDecisionThresholds[int(newEnergy)]
 	eb.currentEnergy = newEnergy
@@ -185,6 +188,9 @@ func (eb *energyBar) SpendEnergy(sim *Simulation, amount float64, metrics *Resou
 	if sim.Log != nil {
 		eb.unit.Log(sim, "Spent %0.3f energy from %s (%0.3f --> %0.3f).", amount, metrics.ActionID, eb.currentEnergy, newEnergy)
 	}
+	if sim.combatLog != nil {
+		eb.unit.logCombatResource(sim, proto.CombatLogEventType_CombatLogEventResourceSpend, proto.ResourceType_ResourceTypeEnergy, amount, metrics.ActionID)
+	}
 
 	eb.currentEnergy = newEnergy
 }
@@ -213,6 +219,10 @@ func (eb *energyBar) AddComboPointsIgnoreTarget(sim *Simulation, pointsToAdd int
 		eb.unit.Log(sim, "Gained %d combo points on %s from %s (%d --> %d)", pointsToAdd, eb.comboPointTarget.LogLabel(), metrics.ActionID, eb.comboPoints, newComboPoints)
 	}
 
+	if sim.combatLog != nil {
+		eb.unit.logCombatResource(sim, proto.CombatLogEventType_CombatLogEventResourceGain, proto.ResourceType_ResourceTypeComboPoints, float64(pointsToAdd), metrics.ActionID)
+	}
+
 	eb.comboPoints = newComboPoints
 
 	for _, callback := range eb.onComboPointsGainedCallbacks {
@@ -250,6 +260,10 @@ func (eb *energyBar) AddComboPoints(sim *Simulation, pointsToAdd int32, target *
 		}
 	}
 
+	if sim.combatLog != nil {
+		eb.unit.logCombatResource(sim, proto.CombatLogEventType_CombatLogEventResourceGain, proto.ResourceType_ResourceTypeComboPoints, float64(pointsToAdd), metrics.ActionID)
+	}
+
 	eb.comboPoints = newComboPoints
 
 	// overwrite old comboPointTarget to accurately track
@@ -274,6 +288,9 @@ func (eb *energyBar) SpendComboPoints(sim *Simulation, spell *Spell) {
 	if sim.Log != nil {
 		eb.unit.Log(sim, "Spent %d combo points from %s (%d --> %d).", comboPoints, spell.ActionID, comboPoints, 0)
 	}
+	if sim.combatLog != nil {
+		eb.unit.logCombatResource(sim, proto.CombatLogEventType_CombatLogEventResourceSpend, proto.ResourceType_ResourceTypeComboPoints, float64(comboPoints), spell.ActionID)
+	}
 	spell.ComboPointMetrics().AddEvent(float64(-comboPoints), float64(-comboPoints))
 	eb.comboPoints = 0
 
@@ -93,17 +93,19 @@ func (env *Environment) construct(raidProto *proto.Raid, encounterProto *proto.E
 		}
 	}
 
+	// Resolve the Tanks field so encounter AIs can pick off-tanks.
+	env.Raid.Tanks = make([]*Unit, len(raidProto.Tanks))
+	for i, tankRef := range raidProto.Tanks {
+		env.Raid.Tanks[i] = env.GetUnit(tankRef, nil)
+	}
+
 	// Assign target or target using Tanks field.
 	for _, target := range env.Encounter.Targets {
 		if target.Index < int32(len(encounterProto.Targets)) {
 			targetProto := encounterProto.Targets[target.Index]
-			if targetProto.TankIndex >= 0 && targetProto.TankIndex < int32(len(raidProto.Tanks)) {
-				raidTargetProto := raidProto.Tanks[targetProto.TankIndex]
-				if raidTargetProto != nil {
-					raidTarget := env.GetUnit(raidTargetProto, nil)
-					if raidTarget != nil {
-						target.CurrentTarget = raidTarget
-					}
+			if targetProto.TankIndex >= 0 && targetProto.TankIndex < int32(len(env.Raid.Tanks)) {
+				if raidTarget := env.Raid.Tanks[targetProto.TankIndex]; raidTarget != nil {
+					target.CurrentTarget = raidTarget
 				}
 			}
 		}
@@ -175,6 +177,14 @@ func (env *Environment) finalize(raidProto *proto.Raid, _ *proto.Encounter, raid
 			playerProto := partyProto.Players[playerIdx]
 			char := player.GetCharacter()
 			char.Rotation = char.newAPLRotation(playerProto.Rotation)
+			if playerProto.PetRotation != nil {
+				for _, pet := range char.Pets {
+					if !pet.isGuardian {
+						pet.Rotation = pet.newAPLRotation(playerProto.PetRotation)
+						pet.hasAPLRotation = true
+					}
+				}
+			}
 		}
 	}
 
@@ -266,6 +276,11 @@ func (env *Environment) reset(sim *Simulation) {
 	}
 
 	env.Raid.reset(sim)
+
+	for _, target := range env.Encounter.Targets {
+		target.scheduleLifetime(sim)
+	}
+	env.Encounter.updateActiveTargets()
 }
 
 // The maximum possible duration for any iteration.
@@ -277,6 +292,11 @@ func (env *Environment) GetNumTargets() int32 {
 	return int32(len(env.Encounter.Targets))
 }
 
+// The number of targets currently in the fight.
+func (env *Environment) GetNumActiveTargets() int32 {
+	return int32(len(env.Encounter.ActiveTargetUnits))
+}
+
 func (env *Environment) GetTarget(index int32) *Target {
 	return env.Encounter.Targets[index]
 }
@@ -342,6 +362,19 @@ func (env *Environment) GetUnit(ref *proto.UnitReference, contextUnit *Unit) *Un
 		}
 	case proto.UnitReference_Self:
 		return contextUnit
+	case proto.UnitReference_Owner:
+		if contextUnit == nil || contextUnit.Type != PetUnit {
+			return nil
+		}
+		for _, party := range env.Raid.Parties {
+			for _, player := range party.Players {
+				for _, pet := range player.GetCharacter().Pets {
+					if &pet.Unit == contextUnit {
+						return &pet.Owner.Unit
+					}
+				}
+			}
+		}
 	case proto.UnitReference_CurrentTarget:
 		if contextUnit == nil {
 			return nil
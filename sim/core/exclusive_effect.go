@@ -19,6 +19,8 @@ type ExclusiveEffect struct {
 
 	Category  *ExclusiveCategory
 	isEnabled bool
+
+	damageAttribution *DamageAttribution
 }
 
 func (ee *ExclusiveEffect) IsActive() bool {
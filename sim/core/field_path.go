@@ -0,0 +1,55 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var fieldPathSegmentRegex = regexp.MustCompile(`^(\w+)(?:\[(\d+)\])?$`)
+
+// ResolveFieldPath follows a path of fields like "raid.parties[0].players[0].consumes.flask"
+// from msg, creating any unset message on the way, and returns the message holding the
+// last field along with that field. Field names can be either the proto or the JSON names.
+func ResolveFieldPath(msg protoreflect.Message, path string) (protoreflect.Message, protoreflect.FieldDescriptor, error) {
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		match := fieldPathSegmentRegex.FindStringSubmatch(segment)
+		if match == nil {
+			return nil, nil, fmt.Errorf("invalid path %s", path)
+		}
+		fields := msg.Descriptor().Fields()
+		fd := fields.ByName(protoreflect.Name(match[1]))
+		if fd == nil {
+			fd = fields.ByJSONName(match[1])
+		}
+		if fd == nil {
+			return nil, nil, fmt.Errorf("unknown field %s in path %s", match[1], path)
+		}
+
+		if i == len(segments)-1 && match[2] == "" {
+			return msg, fd, nil
+		}
+
+		if match[2] != "" {
+			if !fd.IsList() || fd.Kind() != protoreflect.MessageKind {
+				return nil, nil, fmt.Errorf("field %s in path %s is not a list of messages", match[1], path)
+			}
+			idx, _ := strconv.Atoi(match[2])
+			list := msg.Mutable(fd).List()
+			if idx >= list.Len() {
+				return nil, nil, fmt.Errorf("index %d out of range in path %s", idx, path)
+			}
+			msg = list.Get(idx).Message()
+		} else {
+			if fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() {
+				return nil, nil, fmt.Errorf("field %s in path %s is not a message", match[1], path)
+			}
+			msg = msg.Mutable(fd).Message()
+		}
+	}
+	return nil, nil, fmt.Errorf("path %s doesn't end with a field", path)
+}
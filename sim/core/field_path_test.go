@@ -0,0 +1,43 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestResolveFieldPath(t *testing.T) {
+	request := &proto.RaidSimRequest{
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{{Players: []*proto.Player{{}, {}}}},
+		},
+	}
+
+	// Nested messages are created on the way, and JSON names work too.
+	msg, fd, err := ResolveFieldPath(request.ProtoReflect(), "raid.parties[0].players[1].consumes.miscConsumes.juju_flurry")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	msg.Set(fd, protoreflect.ValueOfBool(true))
+	if !request.Raid.Parties[0].Players[1].GetConsumes().GetMiscConsumes().GetJujuFlurry() {
+		t.Fatalf("Expected Juju Flurry to be set on the second player, got %v", request.Raid.Parties[0].Players[1])
+	}
+	if request.Raid.Parties[0].Players[0].Consumes != nil {
+		t.Fatalf("Expected the first player to be unchanged, got %v", request.Raid.Parties[0].Players[0])
+	}
+
+	for _, path := range []string{
+		"",
+		"raid.parties[0].players[2].consumes.flask",
+		"raid.parties[0].players[0].consumes.not_a_field",
+		"raid.parties.players",
+		"raid[0].buffs",
+		"raid.buffs.gift_of_the_wild.value",
+		"raid.parties[0]",
+	} {
+		if _, _, err := ResolveFieldPath(request.ProtoReflect(), path); err == nil {
+			t.Fatalf("Expected an error for path %q", path)
+		}
+	}
+}
@@ -73,6 +73,9 @@ func (fb *focusBar) AddFocus(sim *Simulation, amount float64, metrics *ResourceM
 	if sim.Log != nil {
 		fb.unit.Log(sim, "Gained %0.3f focus from %s (%0.3f --> %0.3f).", amount, metrics.ActionID, fb.currentFocus, newFocus)
 	}
+	if sim.combatLog != nil {
+		fb.unit.logCombatResource(sim, proto.CombatLogEventType_CombatLogEventResourceGain, proto.ResourceType_ResourceTypeFocus, amount, metrics.ActionID)
+	}
 
 	fb.currentFocus = newFocus
 
@@ -92,6 +95,9 @@ func (fb *focusBar) SpendFocus(sim *Simulation, amount float64, metrics *Resourc
 	if sim.Log != nil {
 		fb.unit.Log(sim, "Spent %0.3f focus from %s (%0.3f --> %0.3f).", amount, metrics.ActionID, fb.currentFocus, newFocus)
 	}
+	if sim.combatLog != nil {
+		fb.unit.logCombatResource(sim, proto.CombatLogEventType_CombatLogEventResourceSpend, proto.ResourceType_ResourceTypeFocus, amount, metrics.ActionID)
+	}
 
 	fb.currentFocus = newFocus
 }
@@ -57,6 +57,9 @@ func (hb *healthBar) GainHealth(sim *Simulation, amount float64, metrics *Resour
 	if sim.Log != nil {
 		hb.unit.Log(sim, "Gained %0.3f health from %s (%0.3f --> %0.3f).", amount, metrics.ActionID, oldHealth, newHealth)
 	}
+	if sim.combatLog != nil {
+		hb.unit.logCombatResource(sim, proto.CombatLogEventType_CombatLogEventResourceGain, proto.ResourceType_ResourceTypeHealth, amount, metrics.ActionID)
+	}
 
 	hb.currentHealth = newHealth
 }
@@ -83,6 +86,9 @@ func (hb *healthBar) RemoveHealth(sim *Simulation, amount float64) {
 	if sim.Log != nil {
 		hb.unit.Log(sim, "Spent %0.3f health from %s (%0.3f --> %0.3f).", amount, metrics.ActionID, oldHealth, newHealth)
 	}
+	if sim.combatLog != nil {
+		hb.unit.logCombatResource(sim, proto.CombatLogEventType_CombatLogEventResourceSpend, proto.ResourceType_ResourceTypeHealth, amount, metrics.ActionID)
+	}
 
 	hb.currentHealth = newHealth
 }
@@ -121,6 +127,9 @@ func (character *Character) trackChanceOfDeath(healingModel *proto.HealingModel)
 					if sim.Log != nil {
 						character.Log(sim, "Dead")
 					}
+					if sim.combatLog != nil {
+						character.logCombatEvent(sim, proto.CombatLogEventType_CombatLogEventUnitDied)
+					}
 				}
 			}
 		},
@@ -133,6 +142,9 @@ func (character *Character) trackChanceOfDeath(healingModel *proto.HealingModel)
 					if sim.Log != nil {
 						character.Log(sim, "Dead")
 					}
+					if sim.combatLog != nil {
+						character.logCombatEvent(sim, proto.CombatLogEventType_CombatLogEventUnitDied)
+					}
 				}
 			}
 		},
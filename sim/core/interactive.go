@@ -0,0 +1,384 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+	"github.com/isfir/wowsims-turtle/sim/core/simsignals"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// Sessions that haven't been touched for this long are dropped.
+const interactiveSessionTimeout = time.Minute * 30
+
+// InteractiveSession runs a single iteration of a sim, pausing every time the
+// controlled player needs to make a decision.
+type InteractiveSession struct {
+	// Guards the session against concurrent requests.
+	lock sync.Mutex
+
+	id     string
+	sim    *Simulation
+	player *Character
+
+	logs   *strings.Builder
+	done   bool
+	result *proto.RaidSimResult
+
+	lastAccess time.Time
+}
+
+func NewInteractiveSession(request *proto.InteractiveSimStartRequest) (*InteractiveSession, error) {
+	rsr := request.Request
+	if rsr == nil || rsr.Raid == nil || rsr.Encounter == nil {
+		return nil, errors.New("interactive sim requires a raid and an encounter")
+	}
+
+	simOptions := googleProto.Clone(rsr.SimOptions).(*proto.SimOptions)
+	if simOptions == nil {
+		simOptions = &proto.SimOptions{}
+	}
+	simOptions.Interactive = true
+	simOptions.Iterations = 1
+
+	env, _, _ := NewEnvironment(rsr.Raid, rsr.Encounter, false)
+	sim := newSimWithEnv(env, simOptions, simsignals.CreateSignals())
+
+	playerRef := request.Player
+	if playerRef == nil {
+		playerRef = &proto.UnitReference{Type: proto.UnitReference_Player, Index: firstPlayerIndex(env.Raid)}
+	}
+	playerAgent := env.Raid.GetPlayerFromUnit(env.GetUnit(playerRef, nil))
+	if playerAgent == nil {
+		return nil, fmt.Errorf("no player found for %s", playerRef)
+	}
+
+	session := &InteractiveSession{
+		id:         uuid.NewString(),
+		sim:        sim,
+		player:     playerAgent.GetCharacter(),
+		logs:       &strings.Builder{},
+		lastAccess: time.Now(),
+	}
+	sim.interactiveUnit = &session.player.Unit
+	sim.Log = func(message string, vals ...interface{}) {
+		session.logs.WriteString(fmt.Sprintf("[%0.2f] "+message+"\n", append([]interface{}{sim.CurrentTime.Seconds()}, vals...)...))
+	}
+
+	sim.reset()
+	sim.PrePull()
+	session.runUntilInput()
+	return session, nil
+}
+
+func firstPlayerIndex(raid *Raid) int32 {
+	for _, party := range raid.Parties {
+		if len(party.Players) > 0 {
+			return party.Players[0].GetCharacter().Index
+		}
+	}
+	return 0
+}
+
+func (session *InteractiveSession) ID() string {
+	return session.id
+}
+
+func (session *InteractiveSession) IsDone() bool {
+	return session.done
+}
+
+// Applies the given action, then resumes the sim until the player needs another decision.
+func (session *InteractiveSession) Step(action *proto.InteractiveSimAction) error {
+	if session.done {
+		return errors.New("interactive sim is already finished")
+	}
+	if action == nil {
+		return errors.New("no action provided")
+	}
+
+	sim := session.sim
+	switch action.Action.(type) {
+	case *proto.InteractiveSimAction_CastSpell:
+		spell := session.player.GetSpell(ProtoToActionID(action.GetCastSpell()))
+		if spell == nil || !spell.Flags.Matches(SpellFlagAPL) {
+			return fmt.Errorf("%s cannot cast %s", session.player.Label, ProtoToActionID(action.GetCastSpell()))
+		}
+
+		target := session.player.CurrentTarget
+		if action.Target != nil {
+			target = session.player.GetUnit(action.Target)
+			if target == nil {
+				return fmt.Errorf("invalid target %s", action.Target)
+			}
+		}
+
+		if !spell.CanCast(sim, target) || !spell.Cast(sim, target) {
+			return fmt.Errorf("%s is not castable right now", spell.ActionID)
+		}
+
+		// Off-GCD spells leave the player free to act again immediately.
+		if spell.CurCast.GCD > 0 || session.player.IsCasting(sim) || session.player.IsChanneling(sim) {
+			sim.NeedsInput = false
+		}
+	case *proto.InteractiveSimAction_WaitSeconds:
+		if action.GetWaitSeconds() <= 0 {
+			return errors.New("wait duration must be positive")
+		}
+
+		sim.NeedsInput = false
+		StartDelayedAction(sim, DelayedActionOptions{
+			DoAt: sim.CurrentTime + DurationFromSeconds(action.GetWaitSeconds()),
+			OnAction: func(sim *Simulation) {
+				sim.NeedsInput = true
+			},
+		})
+	default:
+		return errors.New("unknown interactive action")
+	}
+
+	session.runUntilInput()
+	return nil
+}
+
+func (session *InteractiveSession) runUntilInput() {
+	sim := session.sim
+	for !sim.NeedsInput {
+		if finished := sim.Step(); finished {
+			session.finish()
+			return
+		}
+	}
+}
+
+func (session *InteractiveSession) finish() {
+	sim := session.sim
+	sim.NeedsInput = false
+	sim.Cleanup()
+
+	duration := sim.Duration
+	if sim.Encounter.EndFightAtHealth != 0 {
+		duration = sim.CurrentTime
+	}
+
+	session.done = true
+	session.result = &proto.RaidSimResult{
+		RaidMetrics:      sim.Raid.GetMetrics(),
+		EncounterMetrics: sim.Encounter.GetMetricsProto(),
+
+		FirstIterationDuration: duration.Seconds(),
+		AvgIterationDuration:   duration.Seconds(),
+		IterationsDone:         1,
+	}
+}
+
+// Returns a snapshot of the session. Logs are drained, so each line is only returned once.
+func (session *InteractiveSession) State() *proto.InteractiveSimState {
+	sim := session.sim
+
+	state := &proto.InteractiveSimState{
+		SessionId:   session.id,
+		CurrentTime: sim.CurrentTime.Seconds(),
+		NeedsInput:  sim.NeedsInput,
+		Done:        session.done,
+		Logs:        session.logs.String(),
+		FinalResult: session.result,
+	}
+	session.logs.Reset()
+
+	if session.done {
+		state.RemainingTime = 0
+	} else {
+		state.RemainingTime = max(0, sim.GetRemainingDuration().Seconds())
+	}
+
+	state.Player = session.unitState(&session.player.Unit)
+	for _, target := range sim.Encounter.TargetUnits {
+		state.Targets = append(state.Targets, session.unitState(target))
+	}
+
+	if !session.done {
+		for _, spell := range session.player.Spellbook {
+			if !spell.Flags.Matches(SpellFlagAPL) {
+				continue
+			}
+			state.AvailableActions = append(state.AvailableActions, &proto.InteractiveActionOption{
+				Id:                       spell.ActionID.ToProto(),
+				IsReady:                  spell.CanCast(sim, session.player.CurrentTarget),
+				OnGcd:                    spell.DefaultCast.GCD > 0,
+				CooldownRemainingSeconds: spell.TimeToReady(sim).Seconds(),
+				CastTimeSeconds:          spell.CastTime().Seconds(),
+			})
+		}
+	}
+
+	return state
+}
+
+func (session *InteractiveSession) unitState(unit *Unit) *proto.InteractiveUnitState {
+	sim := session.sim
+
+	state := &proto.InteractiveUnitState{
+		Name:                 unit.Label,
+		GcdRemainingSeconds:  unit.GCD.TimeToReady(sim).Seconds(),
+		CastRemainingSeconds: max(0, unit.Hardcast.Expires-sim.CurrentTime).Seconds(),
+		DamageDone:           unitDamageDone(unit),
+	}
+
+	if unit.HasHealthBar() {
+		state.Health = unit.CurrentHealth()
+		state.MaxHealth = unit.MaxHealth()
+	}
+	if unit.HasManaBar() {
+		state.Mana = unit.CurrentMana()
+		state.MaxMana = unit.MaxMana()
+	}
+	if unit.HasRageBar() {
+		state.Rage = unit.CurrentRage()
+	}
+	if unit.HasEnergyBar() {
+		state.Energy = unit.CurrentEnergy()
+		state.MaxEnergy = unit.MaxEnergy()
+		state.ComboPoints = unit.ComboPoints()
+	}
+	if unit.HasFocusBar() {
+		state.Focus = unit.CurrentFocus()
+	}
+
+	for _, aura := range unit.GetAuras() {
+		if !aura.IsActive() || aura.ActionID.IsEmptyAction() {
+			continue
+		}
+		state.Auras = append(state.Auras, &proto.InteractiveAuraState{
+			Id:               aura.ActionID.ToProto(),
+			Label:            aura.Label,
+			Stacks:           aura.GetStacks(),
+			RemainingSeconds: aura.RemainingDuration(sim).Seconds(),
+		})
+	}
+
+	return state
+}
+
+// Damage dealt by this unit and its pets to opponents in the current iteration.
+func unitDamageDone(unit *Unit) float64 {
+	damage := 0.0
+	for _, spell := range unit.Spellbook {
+		for targetIdx, spellMetrics := range spell.SpellMetrics {
+			if unit.IsOpponent(unit.Env.AllUnits[targetIdx]) {
+				damage += spellMetrics.TotalDamage
+			}
+		}
+	}
+	for _, pet := range unit.PetAgents {
+		damage += unitDamageDone(&pet.GetCharacter().Unit)
+	}
+	return damage
+}
+
+var interactiveSessions = map[string]*InteractiveSession{}
+var interactiveSessionsLock = sync.Mutex{}
+
+func getInteractiveSession(id string) (*InteractiveSession, bool) {
+	interactiveSessionsLock.Lock()
+	defer interactiveSessionsLock.Unlock()
+
+	session, ok := interactiveSessions[id]
+	if ok {
+		session.lastAccess = time.Now()
+	}
+	return session, ok
+}
+
+func addInteractiveSession(session *InteractiveSession) {
+	interactiveSessionsLock.Lock()
+	defer interactiveSessionsLock.Unlock()
+
+	for id, other := range interactiveSessions {
+		if time.Since(other.lastAccess) > interactiveSessionTimeout {
+			delete(interactiveSessions, id)
+		}
+	}
+	interactiveSessions[session.id] = session
+}
+
+func removeInteractiveSession(id string) {
+	interactiveSessionsLock.Lock()
+	defer interactiveSessionsLock.Unlock()
+	delete(interactiveSessions, id)
+}
+
+func interactiveErrorState(sessionId string, err any) *proto.InteractiveSimState {
+	errStr := ""
+	switch errt := err.(type) {
+	case string:
+		errStr = errt
+	case error:
+		errStr = errt.Error()
+	}
+	return &proto.InteractiveSimState{
+		SessionId: sessionId,
+		Error:     &proto.ErrorOutcome{Message: errStr},
+	}
+}
+
+// Recovers from panics inside the sim, turning them into an error state.
+func recoverInteractive(sessionId string, state **proto.InteractiveSimState) {
+	if err := recover(); err != nil {
+		*state = interactiveErrorState(sessionId, err)
+		(*state).Error.Message += "\nStack Trace:\n" + string(debug.Stack())
+		removeInteractiveSession(sessionId)
+	}
+}
+
+func startInteractiveSim(request *proto.InteractiveSimStartRequest) (state *proto.InteractiveSimState) {
+	defer recoverInteractive("", &state)
+
+	session, err := NewInteractiveSession(request)
+	if err != nil {
+		return interactiveErrorState("", err)
+	}
+	addInteractiveSession(session)
+	return session.State()
+}
+
+func stepInteractiveSim(request *proto.InteractiveSimStepRequest) (state *proto.InteractiveSimState) {
+	defer recoverInteractive(request.SessionId, &state)
+
+	session, ok := getInteractiveSession(request.SessionId)
+	if !ok {
+		return interactiveErrorState(request.SessionId, "unknown interactive session")
+	}
+
+	session.lock.Lock()
+	defer session.lock.Unlock()
+
+	if err := session.Step(request.Action); err != nil {
+		state = session.State()
+		state.Error = &proto.ErrorOutcome{Message: err.Error()}
+		return state
+	}
+
+	state = session.State()
+	if session.done {
+		removeInteractiveSession(session.id)
+	}
+	return state
+}
+
+func getInteractiveSimState(request *proto.InteractiveSimStateRequest) *proto.InteractiveSimState {
+	session, ok := getInteractiveSession(request.SessionId)
+	if !ok {
+		return interactiveErrorState(request.SessionId, "unknown interactive session")
+	}
+
+	session.lock.Lock()
+	defer session.lock.Unlock()
+	return session.State()
+}
@@ -89,6 +89,9 @@ func (unit *Unit) AddMana(sim *Simulation, amount float64, metrics *ResourceMetr
 	if sim.Log != nil {
 		unit.Log(sim, "Gained %0.3f mana from %s (%0.3f --> %0.3f).", amount, metrics.ActionID, oldMana, newMana)
 	}
+	if sim.combatLog != nil {
+		unit.logCombatResource(sim, proto.CombatLogEventType_CombatLogEventResourceGain, proto.ResourceType_ResourceTypeMana, amount, metrics.ActionID)
+	}
 
 	unit.currentMana = newMana
 	unit.Metrics.ManaGained += newMana - oldMana
@@ -105,6 +108,9 @@ func (unit *Unit) SpendMana(sim *Simulation, amount float64, metrics *ResourceMe
 	if sim.Log != nil {
 		unit.Log(sim, "Spent %0.3f mana from %s (%0.3f --> %0.3f).", amount, metrics.ActionID, unit.CurrentMana(), newMana)
 	}
+	if sim.combatLog != nil {
+		unit.logCombatResource(sim, proto.CombatLogEventType_CombatLogEventResourceSpend, proto.ResourceType_ResourceTypeMana, amount, metrics.ActionID)
+	}
 
 	unit.currentMana = newMana
 	unit.Metrics.ManaSpent += amount
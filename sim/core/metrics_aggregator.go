@@ -2,6 +2,7 @@ package core
 
 import (
 	"math"
+	"slices"
 	"time"
 
 	"github.com/isfir/wowsims-turtle/sim/core/proto"
@@ -35,7 +36,7 @@ func (distMetrics *DistributionMetrics) doneIteration(sim *Simulation) {
 	dps := distMetrics.Total / sim.Duration.Seconds()
 	distMetrics.add(dps)
 
-	if sim.Options.SaveAllValues {
+	if sim.Options.SaveAllValues || len(sim.Options.Percentiles) > 0 {
 		if cap(distMetrics.sample) < int(sim.Options.Iterations) {
 			distMetrics.sample = make([]float64, 0, sim.Options.Iterations)
 		}
@@ -75,6 +76,57 @@ func (distMetrics *DistributionMetrics) ToProto() *proto.DistributionMetrics {
 	}
 }
 
+// Fills in the standard error and confidence interval of a distribution, and its
+// percentiles if it has the values of every iteration.
+func fillDistributionStats(dm *proto.DistributionMetrics, confidence float64, percentiles []float64) {
+	if confidence <= 0 || confidence >= 1 {
+		confidence = 0.95
+	}
+	dm.ConfidenceLevel = confidence
+
+	dm.Stderr = 0
+	if n := dm.GetAggregatorData().GetN(); n > 0 && !math.IsNaN(dm.Stdev) {
+		dm.Stderr = dm.Stdev / math.Sqrt(float64(n))
+	}
+	z := math.Sqrt2 * math.Erfinv(confidence)
+	dm.CiLow = dm.Avg - z*dm.Stderr
+	dm.CiHigh = dm.Avg + z*dm.Stderr
+
+	if len(dm.AllValues) > 0 && len(percentiles) > 0 {
+		sorted := slices.Sorted(slices.Values(dm.AllValues))
+		dm.Percentiles = make([]*proto.PercentileValue, len(percentiles))
+		for i, p := range percentiles {
+			dm.Percentiles[i] = &proto.PercentileValue{P: p, Value: percentile(sorted, p)}
+		}
+	}
+}
+
+// Calls f for every DistributionMetrics of a result.
+func forEachDistribution(result *proto.RaidSimResult, f func(*proto.DistributionMetrics)) {
+	var forUnit func(unit *proto.UnitMetrics)
+	forUnit = func(unit *proto.UnitMetrics) {
+		for _, dm := range []*proto.DistributionMetrics{unit.Dps, unit.Dpasp, unit.Threat, unit.Dtps, unit.Tmi, unit.Hps, unit.Tto} {
+			f(dm)
+		}
+		for _, pet := range unit.Pets {
+			forUnit(pet)
+		}
+	}
+
+	f(result.RaidMetrics.Dps)
+	f(result.RaidMetrics.Hps)
+	for _, party := range result.RaidMetrics.Parties {
+		f(party.Dps)
+		f(party.Hps)
+		for _, player := range party.Players {
+			forUnit(player)
+		}
+	}
+	for _, target := range result.EncounterMetrics.Targets {
+		forUnit(target)
+	}
+}
+
 func NewDistributionMetrics() DistributionMetrics {
 	return DistributionMetrics{
 		hist: make(map[int32]int32),
@@ -98,10 +150,17 @@ type UnitMetrics struct {
 	CharacterIterationMetrics
 
 	// Aggregate values. These are updated after each iteration.
-	numItersDead int32
-	oomTimeSum   float64
-	actions      map[ActionID]*ActionMetrics
-	resources    []*ResourceMetrics
+	numItersDead  int32
+	oomTimeSum    float64
+	activeTimeSum float64
+	actions       map[ActionID]*ActionMetrics
+	resources     []*ResourceMetrics
+
+	// Only used if timelines are enabled.
+	dpsTimeline       timelineMetrics
+	resourceTimelines []*resourceTimeline
+
+	damageAttributions []*DamageAttributionMetrics
 }
 
 // Metrics for the current iteration, for 1 agent. Keep this as a separate
@@ -116,6 +175,8 @@ type CharacterIterationMetrics struct {
 	OOMTime time.Duration // time spent not casting and waiting for regen.
 
 	FirstOOMTimestamp time.Duration // Timestamp at which unit first went OOM.
+
+	ActiveTime time.Duration // Time spent in the fight, only tracked for targets.
 }
 
 type ActionMetrics struct {
@@ -182,6 +243,7 @@ type SpellMetrics struct {
 	TotalThreat                 float64 // Threat generated by all casts of this spell.
 	TotalHealing                float64 // Healing done by all casts of this spell.
 	TotalCritHealing            float64 // Healing done by all critical casts of this spell.
+	TotalOverhealing            float64 // Healing done by all casts of this spell beyond the target's missing health.
 	TotalShielding              float64 // Shielding done by all casts of this spell.
 	TotalCastTime               time.Duration
 }
@@ -219,6 +281,7 @@ type TargetedActionMetrics struct {
 	Threat                 float64
 	Healing                float64
 	CritHealing            float64
+	Overhealing            float64
 	Shielding              float64
 	CastTime               time.Duration
 }
@@ -258,6 +321,7 @@ func (tam *TargetedActionMetrics) ToProto(unitIndex int32) *proto.TargetedAction
 		Threat:                 tam.Threat,
 		Healing:                tam.Healing,
 		CritHealing:            tam.CritHealing,
+		Overhealing:            tam.Overhealing,
 		Shielding:              tam.Shielding,
 		CastTimeMs:             float64(tam.CastTime.Milliseconds()),
 	}
@@ -273,6 +337,8 @@ func NewUnitMetrics() UnitMetrics {
 		hps:     NewDistributionMetrics(),
 		tto:     NewDistributionMetrics(),
 		actions: make(map[ActionID]*ActionMetrics),
+
+		dpsTimeline: timelineMetrics{keepSamples: true},
 	}
 }
 
@@ -418,6 +484,7 @@ func (unitMetrics *UnitMetrics) addSpellMetrics(spell *Spell, actionID ActionID,
 		tam.Threat += spellTargetMetrics.TotalThreat
 		tam.Healing += spellTargetMetrics.TotalHealing
 		tam.CritHealing += spellTargetMetrics.TotalCritHealing
+		tam.Overhealing += spellTargetMetrics.TotalOverhealing
 		tam.Shielding += spellTargetMetrics.TotalShielding
 		if !spell.Flags.Matches(SpellFlagPassiveSpell) {
 			tam.CastTime += spellTargetMetrics.TotalCastTime
@@ -440,6 +507,12 @@ func (unitMetrics *UnitMetrics) addSpellMetrics(spell *Spell, actionID ActionID,
 // Assumes that doneIteration() has already been called on the pet metrics.
 func (unitMetrics *UnitMetrics) AddFinalPetMetrics(petMetrics *UnitMetrics) {
 	unitMetrics.dps.Total += petMetrics.dps.Total
+	for i, damage := range petMetrics.dpsTimeline.current {
+		unitMetrics.dpsTimeline.add(i, damage)
+	}
+	for _, dam := range petMetrics.damageAttributions {
+		unitMetrics.addAttributedDamage(dam.ID, dam.Damage)
+	}
 }
 
 func (unitMetrics *UnitMetrics) AddOOMTime(sim *Simulation, dur time.Duration) {
@@ -474,6 +547,10 @@ func (unitMetrics *UnitMetrics) reset() {
 	for _, resourceMetrics := range unitMetrics.resources {
 		resourceMetrics.reset()
 	}
+
+	for _, dam := range unitMetrics.damageAttributions {
+		dam.Damage = 0
+	}
 }
 
 // This should be called when a Sim iteration is complete.
@@ -506,6 +583,15 @@ func (unitMetrics *UnitMetrics) doneIteration(unit *Unit, sim *Simulation) {
 		unitMetrics.tmi.Total *= sim.Duration.Seconds()
 	}
 
+	if unit.Type == EnemyUnit {
+		unitMetrics.activeTimeSum += unitMetrics.ActiveTime.Seconds()
+		if unitMetrics.ActiveTime > 0 {
+			// Targets which spawn late or die early take damage over their lifetime only.
+			// Hack because of the way DistributionMetrics does its calculations.
+			unitMetrics.dtps.Total *= sim.Duration.Seconds() / unitMetrics.ActiveTime.Seconds()
+		}
+	}
+
 	unitMetrics.dps.doneIteration(sim)
 	unitMetrics.dpasp.doneIteration(sim)
 	unitMetrics.threat.doneIteration(sim)
@@ -518,6 +604,17 @@ func (unitMetrics *UnitMetrics) doneIteration(unit *Unit, sim *Simulation) {
 	if unitMetrics.Died {
 		unitMetrics.numItersDead++
 	}
+
+	for _, dam := range unitMetrics.damageAttributions {
+		dam.doneIteration(sim)
+	}
+
+	if sim.timelineBucketSize > 0 {
+		unitMetrics.dpsTimeline.doneIteration(sim, true)
+		for _, rt := range unitMetrics.resourceTimelines {
+			rt.doneIteration(sim, false)
+		}
+	}
 }
 
 func (unitMetrics *UnitMetrics) calculateTMI(unit *Unit, sim *Simulation) float64 {
@@ -604,6 +701,8 @@ func (unitMetrics *UnitMetrics) ToProto() *proto.UnitMetrics {
 		Tto:           unitMetrics.tto.ToProto(),
 		SecondsOomAvg: unitMetrics.oomTimeSum / n,
 		ChanceOfDeath: float64(unitMetrics.numItersDead) / n,
+
+		ActiveSecondsAvg: unitMetrics.activeTimeSum / n,
 	}
 
 	protoMetrics.Actions = make([]*proto.ActionMetrics, 0, len(unitMetrics.actions))
@@ -618,6 +717,20 @@ func (unitMetrics *UnitMetrics) ToProto() *proto.UnitMetrics {
 		}
 	}
 
+	if len(unitMetrics.dpsTimeline.buckets) > 0 {
+		protoMetrics.DpsTimeline = unitMetrics.dpsTimeline.ToProto()
+	}
+	for _, rt := range unitMetrics.resourceTimelines {
+		protoMetrics.ResourceTimelines = append(protoMetrics.ResourceTimelines, &proto.ResourceTimeline{
+			Type:   rt.Type,
+			Levels: rt.ToProto(),
+		})
+	}
+
+	for _, dam := range unitMetrics.damageAttributions {
+		protoMetrics.DamageAttributions = append(protoMetrics.DamageAttributions, dam.ToProto())
+	}
+
 	return protoMetrics
 }
 
@@ -631,6 +744,9 @@ type AuraMetrics struct {
 	// Aggregate values. These are updated after each iteration.
 	aggregator
 	procsSum int32
+
+	// Active seconds in each bucket, only used if timelines are enabled.
+	timeline timelineMetrics
 }
 
 func (auraMetrics *AuraMetrics) reset() {
@@ -639,9 +755,13 @@ func (auraMetrics *AuraMetrics) reset() {
 }
 
 // This should be called when a Sim iteration is complete.
-func (auraMetrics *AuraMetrics) doneIteration() {
+func (auraMetrics *AuraMetrics) doneIteration(sim *Simulation) {
 	auraMetrics.add(auraMetrics.Uptime.Seconds())
 	auraMetrics.procsSum += auraMetrics.Procs
+
+	if sim.timelineBucketSize > 0 {
+		auraMetrics.timeline.doneIteration(sim, true)
+	}
 }
 
 func (auraMetrics *AuraMetrics) ToProto() *proto.AuraMetrics {
@@ -658,5 +778,7 @@ func (auraMetrics *AuraMetrics) ToProto() *proto.AuraMetrics {
 			N:     int32(auraMetrics.n),
 			SumSq: auraMetrics.sumSq,
 		},
+
+		UptimeTimeline: auraMetrics.timeline.averages(),
 	}
 }
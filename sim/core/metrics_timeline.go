@@ -0,0 +1,188 @@
+package core
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+)
+
+// timelineMetrics aggregates a value for each time bucket of the fight, over all
+// iterations. See SimOptions.timeline_bucket_seconds.
+type timelineMetrics struct {
+	// Values for the current iteration. These are cleared after each iteration.
+	current []float64
+
+	// Aggregate values. These are updated after each iteration.
+	buckets []aggregator
+
+	// Values of every iteration for each bucket, only kept if percentiles are needed.
+	keepSamples bool
+	samples     [][]float32
+}
+
+// Returns the index of the timeline bucket containing t.
+func (sim *Simulation) timelineBucket(t time.Duration) int {
+	return int(max(0, t) / sim.timelineBucketSize)
+}
+
+// Returns the number of timeline buckets in the current iteration.
+func (sim *Simulation) numTimelineBuckets() int {
+	return int((sim.Duration + sim.timelineBucketSize - 1) / sim.timelineBucketSize)
+}
+
+// Returns the length of a timeline bucket in the current iteration, which is only
+// shorter than the bucket size for the last bucket.
+func (sim *Simulation) timelineBucketSeconds(bucket int) float64 {
+	start := time.Duration(bucket) * sim.timelineBucketSize
+	return (min(start+sim.timelineBucketSize, sim.Duration) - start).Seconds()
+}
+
+func (tl *timelineMetrics) grow(numBuckets int) {
+	for len(tl.current) < numBuckets {
+		tl.current = append(tl.current, 0)
+	}
+}
+
+func (tl *timelineMetrics) add(bucket int, value float64) {
+	tl.grow(bucket + 1)
+	tl.current[bucket] += value
+}
+
+func (tl *timelineMetrics) set(bucket int, value float64) {
+	tl.grow(bucket + 1)
+	tl.current[bucket] = value
+}
+
+// Adds the values of the current iteration to the given span of time, split across buckets.
+func (tl *timelineMetrics) addSpan(sim *Simulation, start time.Duration, end time.Duration) {
+	start = max(0, start)
+	for start < end {
+		bucket := sim.timelineBucket(start)
+		bucketEnd := min(end, time.Duration(bucket+1)*sim.timelineBucketSize)
+		tl.add(bucket, (bucketEnd - start).Seconds())
+		start = bucketEnd
+	}
+}
+
+// This should be called when a Sim iteration is complete. If perSecond is set, the
+// values of each bucket are divided by the bucket length.
+func (tl *timelineMetrics) doneIteration(sim *Simulation, perSecond bool) {
+	numBuckets := sim.numTimelineBuckets()
+	tl.grow(numBuckets)
+	for len(tl.buckets) < numBuckets {
+		tl.buckets = append(tl.buckets, aggregator{})
+		if tl.keepSamples {
+			tl.samples = append(tl.samples, make([]float32, 0, sim.Options.Iterations))
+		}
+	}
+
+	for i := 0; i < numBuckets; i++ {
+		value := tl.current[i]
+		if perSecond {
+			value /= sim.timelineBucketSeconds(i)
+		}
+		tl.buckets[i].add(value)
+		if tl.keepSamples {
+			tl.samples[i] = append(tl.samples[i], float32(value))
+		}
+	}
+
+	// Also clears values past the end of the fight, which don't belong to any bucket.
+	clear(tl.current)
+}
+
+func (tl *timelineMetrics) averages() []float64 {
+	avgs := make([]float64, len(tl.buckets))
+	for i := range tl.buckets {
+		avgs[i], _ = tl.buckets[i].meanAndStdDev()
+	}
+	return avgs
+}
+
+func (tl *timelineMetrics) ToProto() *proto.TimelineMetrics {
+	timeline := &proto.TimelineMetrics{
+		Buckets: make([]*proto.TimelineBucket, len(tl.buckets)),
+	}
+	for i := range tl.buckets {
+		mean, stdev := tl.buckets[i].meanAndStdDev()
+		if math.IsNaN(stdev) {
+			// Rounding can make the variance slightly negative when all values are equal.
+			stdev = 0
+		}
+		bucket := &proto.TimelineBucket{
+			Avg:   mean,
+			Stdev: stdev,
+			AggregatorData: &proto.AggregatorData{
+				N:     int32(tl.buckets[i].n),
+				SumSq: tl.buckets[i].sumSq,
+			},
+		}
+		if tl.keepSamples {
+			sorted := slices.Clone(tl.samples[i])
+			slices.Sort(sorted)
+			bucket.P10 = percentile(sorted, 0.1)
+			bucket.P50 = percentile(sorted, 0.5)
+			bucket.P90 = percentile(sorted, 0.9)
+		}
+		timeline.Buckets[i] = bucket
+	}
+	return timeline
+}
+
+// Returns the pth percentile of sorted values, using the nearest rank.
+func percentile[T float32 | float64](sorted []T, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return float64(sorted[max(0, rank)])
+}
+
+type resourceTimeline struct {
+	Type proto.ResourceType
+	timelineMetrics
+}
+
+func (unitMetrics *UnitMetrics) resourceTimeline(resourceType proto.ResourceType) *resourceTimeline {
+	for _, rt := range unitMetrics.resourceTimelines {
+		if rt.Type == resourceType {
+			return rt
+		}
+	}
+	rt := &resourceTimeline{
+		Type:            resourceType,
+		timelineMetrics: timelineMetrics{keepSamples: true},
+	}
+	unitMetrics.resourceTimelines = append(unitMetrics.resourceTimelines, rt)
+	return rt
+}
+
+// Records the resource levels of this unit for the given timeline bucket.
+func (unit *Unit) sampleResourceLevels(bucket int) {
+	if unit.HasManaBar() {
+		unit.Metrics.resourceTimeline(proto.ResourceType_ResourceTypeMana).set(bucket, unit.CurrentMana())
+	}
+	if unit.HasRageBar() {
+		unit.Metrics.resourceTimeline(proto.ResourceType_ResourceTypeRage).set(bucket, unit.CurrentRage())
+	}
+	if unit.HasEnergyBar() {
+		unit.Metrics.resourceTimeline(proto.ResourceType_ResourceTypeEnergy).set(bucket, unit.CurrentEnergy())
+	}
+	if unit.HasFocusBar() {
+		unit.Metrics.resourceTimeline(proto.ResourceType_ResourceTypeFocus).set(bucket, unit.CurrentFocus())
+	}
+}
+
+// Samples resource levels for every bucket which ends by nextTime. The last bucket
+// is sampled when the iteration is done.
+func (sim *Simulation) sampleTimelines(nextTime time.Duration) {
+	for sim.nextTimelineSample <= nextTime && sim.nextTimelineSample < sim.Duration {
+		bucket := int(sim.nextTimelineSample/sim.timelineBucketSize) - 1
+		for _, unit := range sim.Raid.AllUnits {
+			unit.sampleResourceLevels(bucket)
+		}
+		sim.nextTimelineSample += sim.timelineBucketSize
+	}
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+)
+
+func TestTimelineMetricsBuckets(t *testing.T) {
+	sim := &Simulation{
+		Options:            &proto.SimOptions{Iterations: 2},
+		Duration:           time.Second * 25,
+		timelineBucketSize: time.Second * 10,
+	}
+	tl := timelineMetrics{keepSamples: true}
+
+	// The last bucket is only 5s long.
+	tl.addSpan(sim, time.Second*5, time.Second*22)
+	tl.doneIteration(sim, true)
+
+	// Shorter iterations don't count towards the later buckets.
+	sim.Duration = time.Second * 15
+	tl.addSpan(sim, 0, time.Second*15)
+	tl.doneIteration(sim, true)
+
+	got := tl.ToProto()
+	want := []struct {
+		avg, p10, p90 float64
+		n             int32
+	}{
+		{0.75, 0.5, 1, 2},
+		{1, 1, 1, 2},
+		{0.4, 0.4, 0.4, 1},
+	}
+	if len(got.Buckets) != len(want) {
+		t.Fatalf("Expected %d buckets, got %d", len(want), len(got.Buckets))
+	}
+	for i, w := range want {
+		b := got.Buckets[i]
+		if math.Abs(b.Avg-w.avg) > 1e-6 || math.Abs(b.P10-w.p10) > 1e-6 || math.Abs(b.P90-w.p90) > 1e-6 || b.AggregatorData.N != w.n {
+			t.Fatalf("Bucket %d = %v, want %+v", i, b, w)
+		}
+	}
+}
+
+func TestUnitTimelines(t *testing.T) {
+	sim := setupFakeTargetSim([]*proto.Target{{Name: "boss", Level: 63}}, nil)
+	sim.Cleanup() // run() resets the sim itself.
+	sim.Options.Iterations = 2
+	sim.timelineBucketSize = time.Second * 30
+
+	result := sim.run()
+
+	// The fight lasts 100s, so the last bucket is only 10s long.
+	player := result.RaidMetrics.Parties[0].Players[0]
+	if player.DpsTimeline == nil || len(player.DpsTimeline.Buckets) != 4 {
+		t.Fatalf("Expected 4 dps timeline buckets, got %v", player.DpsTimeline)
+	}
+	for i, b := range player.DpsTimeline.Buckets {
+		if b.AggregatorData.N != 2 || b.Avg != 0 {
+			t.Fatalf("Bucket %d = %v, want no damage over 2 iterations", i, b)
+		}
+	}
+
+	// Units without resource bars don't get resource timelines.
+	if len(player.ResourceTimelines) != 0 {
+		t.Fatalf("Expected no resource timelines, got %v", player.ResourceTimelines)
+	}
+}
@@ -162,6 +162,18 @@ func (unit *Unit) MoveTo(moveRange float64, sim *Simulation) {
 	}))
 }
 
+// Keeps the unit moving for the given duration without changing its distance from the target.
+func (unit *Unit) MoveFor(sim *Simulation, duration time.Duration) {
+	unit.MovementHandler.moveSpell.Cast(sim, unit.CurrentTarget)
+
+	sim.AddPendingAction(&PendingAction{
+		NextActionAt: sim.CurrentTime + duration,
+		OnAction: func(sim *Simulation) {
+			unit.MovementHandler.moveAura.Deactivate(sim)
+		},
+	})
+}
+
 // A move speed increase of 30% should be represented as 1.30 and a move speed slow of 70% should be respresented as 0.70
 func (unit *Unit) AddMoveSpeedModifier(actionId *ActionID, modifier float64) {
 	moveSpeedMod := MoveModifier{
@@ -46,6 +46,10 @@ type Pet struct {
 
 	isReset bool
 
+	// Whether the pet follows the owner's pet APL rotation instead of its
+	// ExecuteCustomRotation.
+	hasAPLRotation bool
+
 	// Some pets expire after a certain duration. This is the pending action that disables
 	// the pet on expiration.
 	timeoutAction *PendingAction
@@ -124,6 +128,10 @@ func (pet *Pet) IsGuardian() bool {
 	return pet.isGuardian
 }
 
+func (pet *Pet) HasAPLRotation() bool {
+	return pet.hasAPLRotation
+}
+
 // petAgent should be the PetAgent which embeds this Pet.
 func (pet *Pet) Enable(sim *Simulation, petAgent PetAgent) {
 	if pet.enabled {
@@ -171,6 +179,9 @@ func (pet *Pet) Enable(sim *Simulation, petAgent PetAgent) {
 		pet.Log(sim, "Pet inherited stats: %s", pet.ApplyStatDependencies(pet.inheritedStats).FlatString())
 		pet.Log(sim, "Pet summoned")
 	}
+	if sim.combatLog != nil {
+		pet.logCombatEvent(sim, proto.CombatLogEventType_CombatLogEventPetSummon)
+	}
 
 	sim.addTracker(&pet.auraTracker)
 
@@ -270,6 +281,9 @@ func (pet *Pet) Disable(sim *Simulation) {
 		pet.Log(sim, "Pet dismissed")
 		pet.Log(sim, pet.GetStats().FlatString())
 	}
+	if sim.combatLog != nil {
+		pet.logCombatEvent(sim, proto.CombatLogEventType_CombatLogEventPetDismiss)
+	}
 }
 
 func (pet *Pet) ApplyOnPetDisable(newOnPetDisable OnPetDisable) {
@@ -166,9 +166,12 @@ func (rb *rageBar) AddRage(sim *Simulation, amount float64, metrics *ResourceMet
 	if sim.Log != nil {
 		rb.unit.Log(sim, "Gained %0.3f rage from %s (%0.3f --> %0.3f).", amount, metrics.ActionID, rb.currentRage, newRage)
 	}
+	if sim.combatLog != nil {
+		rb.unit.logCombatResource(sim, proto.CombatLogEventType_CombatLogEventResourceGain, proto.ResourceType_ResourceTypeRage, amount, metrics.ActionID)
+	}
 
 	rb.currentRage = newRage
-	if !sim.Options.Interactive {
+	if !sim.IsInteractive(rb.unit) {
 		rb.unit.Rotation.DoNextAction(sim)
 	}
 	StartDelayedAction(sim, DelayedActionOptions{
@@ -191,6 +194,9 @@ func (rb *rageBar) SpendRage(sim *Simulation, amount float64, metrics *ResourceM
 	if sim.Log != nil {
 		rb.unit.Log(sim, "Spent %0.3f rage from %s (%0.3f --> %0.3f).", amount, metrics.ActionID, rb.currentRage, newRage)
 	}
+	if sim.combatLog != nil {
+		rb.unit.logCombatResource(sim, proto.CombatLogEventType_CombatLogEventResourceSpend, proto.ResourceType_ResourceTypeRage, amount, metrics.ActionID)
+	}
 
 	rb.currentRage = newRage
 
@@ -122,6 +122,8 @@ type Raid struct {
 	AllPlayerUnits []*Unit // Cached list of all Players in the raid.
 	AllUnits       []*Unit // Cached list of all Units (players and pets) in the raid.
 
+	Tanks []*Unit // Units assigned as tanks, in the order of the raid's Tanks field.
+
 	nextPetIndex int32
 }
 
@@ -38,11 +38,19 @@ type Simulation struct {
 	Duration       time.Duration // Duration of current iteration
 	NeedsInput     bool          // Sim is in interactive mode and needs input
 
+	// Unit whose actions are chosen interactively. If nil, all units are interactive.
+	interactiveUnit *Unit
+
 	ProgressReport func(*proto.ProgressMetrics)
 	Signals        simsignals.Signals
 
 	Log func(string, ...interface{})
 
+	combatLog *combatLog // Only set while recording combat events.
+
+	timelineBucketSize time.Duration // Zero unless timelines are recorded.
+	nextTimelineSample time.Duration
+
 	executePhase int32 // 20, 25, or 35 for the respective execute range, 100 otherwise
 
 	executePhaseCallbacks []func(*Simulation, int32) // 2nd parameter is 35 for 35%, 25 for 25% and 20 for 20%
@@ -53,6 +61,9 @@ type Simulation struct {
 	endOfCombatDuration time.Duration
 	endOfCombatDamage   float64
 
+	nextPhase   int                   // Index into Encounter.Phases of the next phase to start.
+	activePhase *proto.EncounterPhase // Phase whose effects are currently applied, if any.
+
 	minTrackerTime time.Duration
 	trackers       []*auraTracker
 
@@ -203,6 +214,8 @@ func newSimWithEnv(env *Environment, simOptions *proto.SimOptions, signals simsi
 		isTest:    simOptions.IsTest || simOptions.UseLabeledRands,
 		testRands: make(map[string]Rand),
 
+		timelineBucketSize: DurationFromSeconds(simOptions.TimelineBucketSeconds),
+
 		Signals: signals,
 	}
 }
@@ -270,6 +283,11 @@ func (sim *Simulation) Proc(p float64, label string) bool {
 	}
 }
 
+// Returns whether the unit's actions come from interactive input rather than its rotation.
+func (sim *Simulation) IsInteractive(unit *Unit) bool {
+	return sim.Options.Interactive && (sim.interactiveUnit == nil || sim.interactiveUnit == unit)
+}
+
 func (sim *Simulation) Reset() {
 	sim.reset()
 }
@@ -296,6 +314,10 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 	// 	fmt.Printf(fmt.Sprintf("[%0.1f] "+message+"\n", append([]interface{}{sim.CurrentTime.Seconds()}, vals...)...))
 	// }
 
+	if sim.Options.RecordCombatLog {
+		sim.combatLog = &combatLog{}
+	}
+
 	sim.runOnce()
 	firstIterationDuration := sim.Duration
 	if sim.Encounter.EndFightAtHealth != 0 {
@@ -306,6 +328,11 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 	if !sim.Options.Debug {
 		sim.Log = nil
 	}
+	var combatLogEvents []*proto.CombatLogEvent
+	if sim.combatLog != nil {
+		combatLogEvents = sim.combatLog.events
+		sim.combatLog = nil
+	}
 
 	var st time.Time
 	for i := int32(1); i < sim.Options.Iterations; i++ {
@@ -347,7 +374,15 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 		FirstIterationDuration: firstIterationDuration.Seconds(),
 		AvgIterationDuration:   totalDuration.Seconds() / float64(sim.Options.Iterations),
 		IterationsDone:         sim.Options.Iterations,
+		CombatLog:              combatLogEvents,
 	}
+	forEachDistribution(result, func(dm *proto.DistributionMetrics) {
+		fillDistributionStats(dm, sim.Options.ConfidenceLevel, sim.Options.Percentiles)
+		if !sim.Options.SaveAllValues {
+			// Only kept for the percentiles.
+			dm.AllValues = nil
+		}
+	})
 
 	// Final progress report
 	if sim.ProgressReport != nil {
@@ -417,7 +452,10 @@ func (sim *Simulation) reset() {
 	sim.tasks = sim.tasks[:0]
 	sim.minTaskTime = NeverExpires
 
+	sim.nextTimelineSample = sim.timelineBucketSize
+
 	sim.Environment.reset(sim)
+	sim.resetPhases()
 
 	sim.initManaTickAction()
 }
@@ -455,6 +493,12 @@ func (sim *Simulation) Cleanup() {
 	// intuitive.
 	sim.CurrentTime = sim.Duration
 
+	if sim.timelineBucketSize > 0 {
+		for _, unit := range sim.Raid.AllUnits {
+			unit.sampleResourceLevels(sim.numTimelineBuckets() - 1)
+		}
+	}
+
 	for _, pa := range sim.pendingActions {
 		if pa.CleanUp != nil {
 			pa.CleanUp(sim)
@@ -545,6 +589,10 @@ func (sim *Simulation) advanceTasks() {
 
 // Advance moves time forward counting down auras, CDs, mana regen, etc
 func (sim *Simulation) advance(nextTime time.Duration) {
+	if sim.timelineBucketSize > 0 {
+		sim.sampleTimelines(nextTime)
+	}
+
 	sim.CurrentTime = nextTime
 
 	// this is a loop to handle duplicate ExecuteProportions, e.g. if they're all set to 100%, you reach
@@ -556,6 +604,10 @@ func (sim *Simulation) advance(nextTime time.Duration) {
 		}
 	}
 
+	if sim.nextPhase < len(sim.Encounter.Phases) {
+		sim.advancePhases()
+	}
+
 	if sim.CurrentTime >= sim.minTrackerTime {
 		sim.minTrackerTime = NeverExpires
 		for _, t := range sim.trackers {
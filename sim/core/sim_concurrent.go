@@ -42,6 +42,7 @@ func SplitSimRequestForConcurrency(request *proto.RaidSimRequest, splitCount int
 		split[i] = googleProto.Clone(request).(*proto.RaidSimRequest)
 		split[i].SimOptions.Iterations = iterPerSplit
 		split[i].SimOptions.DebugFirstIteration = false // No logs
+		split[i].SimOptions.RecordCombatLog = false
 		split[i].SimOptions.RandomSeed = nextStartSeed
 		nextStartSeed += int64(split[i].SimOptions.Iterations)
 	}
@@ -51,6 +52,29 @@ func SplitSimRequestForConcurrency(request *proto.RaidSimRequest, splitCount int
 	return res
 }
 
+// Percentiles can only be computed exactly from the values of every iteration, so a
+// request asking for them keeps all values in its splits. Otherwise the combined
+// percentiles are a weighted average of each split's.
+func keepValuesForPercentiles(request *proto.RaidSimRequest) *proto.RaidSimRequest {
+	if len(request.SimOptions.Percentiles) == 0 || request.SimOptions.SaveAllValues {
+		return request
+	}
+	request = googleProto.Clone(request).(*proto.RaidSimRequest)
+	request.SimOptions.SaveAllValues = true
+	return request
+}
+
+// Drops the values kept by keepValuesForPercentiles from a combined result, once
+// they've been used for its percentiles.
+func dropUnrequestedValues(request *proto.RaidSimRequest, result *proto.RaidSimResult) {
+	if request.SimOptions.SaveAllValues || result.Error != nil {
+		return
+	}
+	forEachDistribution(result, func(dm *proto.DistributionMetrics) {
+		dm.AllValues = nil
+	})
+}
+
 type raidSimResultCombiner struct {
 	Debug    bool
 	Combined *proto.RaidSimResult
@@ -132,10 +156,24 @@ func (rsrc *raidSimResultCombiner) combineDistMetrics(base *proto.DistributionMe
 
 	base.AllValues = append(base.AllValues, add.AllValues...)
 
+	// Percentiles are approximated by their weighted average, unless all values are kept.
+	for i, addPercentile := range add.Percentiles {
+		if i == len(base.Percentiles) {
+			base.Percentiles = append(base.Percentiles, &proto.PercentileValue{P: addPercentile.P})
+		}
+		base.Percentiles[i].Value += addPercentile.Value * weight
+	}
+
 	base.AggregatorData.N += add.AggregatorData.N
 	base.AggregatorData.SumSq += add.AggregatorData.SumSq
 	if isLast {
 		base.Stdev = math.Sqrt(base.AggregatorData.SumSq/float64(base.AggregatorData.N) - base.Avg*base.Avg)
+
+		percentiles := make([]float64, len(base.Percentiles))
+		for i, p := range base.Percentiles {
+			percentiles[i] = p.P
+		}
+		fillDistributionStats(base, add.ConfidenceLevel, percentiles)
 	}
 }
 
@@ -202,6 +240,7 @@ func (rsrc *raidSimResultCombiner) addActionMetrics(unit *proto.UnitMetrics, add
 		baseTgt.Threat += addTgt.Threat
 		baseTgt.Healing += addTgt.Healing
 		baseTgt.CritHealing += addTgt.CritHealing
+		baseTgt.Overhealing += addTgt.Overhealing
 		baseTgt.Shielding += addTgt.Shielding
 		baseTgt.CastTimeMs += addTgt.CastTimeMs
 	}
@@ -210,6 +249,12 @@ func (rsrc *raidSimResultCombiner) addActionMetrics(unit *proto.UnitMetrics, add
 func (rsrc *raidSimResultCombiner) combineAuraMetrics(base *proto.AuraMetrics, add *proto.AuraMetrics, weight float64, isLast bool) {
 	base.UptimeSecondsAvg += add.UptimeSecondsAvg * weight
 	base.ProcsAvg += add.ProcsAvg * weight
+	for i, uptime := range add.UptimeTimeline {
+		if i == len(base.UptimeTimeline) {
+			base.UptimeTimeline = append(base.UptimeTimeline, 0)
+		}
+		base.UptimeTimeline[i] += uptime * weight
+	}
 
 	base.AggregatorData.N += add.AggregatorData.N
 	base.AggregatorData.SumSq += add.AggregatorData.SumSq
@@ -218,6 +263,60 @@ func (rsrc *raidSimResultCombiner) combineAuraMetrics(base *proto.AuraMetrics, a
 	}
 }
 
+// Buckets are combined by the number of iterations which reached them, rather than
+// the weight of each result. Percentiles are approximated by their weighted average.
+func (rsrc *raidSimResultCombiner) combineTimelineMetrics(base *proto.TimelineMetrics, add *proto.TimelineMetrics, isLast bool) {
+	for i, addBucket := range add.Buckets {
+		if i == len(base.Buckets) {
+			base.Buckets = append(base.Buckets, &proto.TimelineBucket{AggregatorData: &proto.AggregatorData{}})
+		}
+		b := base.Buckets[i]
+		n := float64(addBucket.AggregatorData.N)
+
+		// Keep sums until the last result.
+		b.Avg += addBucket.Avg * n
+		b.P10 += addBucket.P10 * n
+		b.P50 += addBucket.P50 * n
+		b.P90 += addBucket.P90 * n
+		b.AggregatorData.N += addBucket.AggregatorData.N
+		b.AggregatorData.SumSq += addBucket.AggregatorData.SumSq
+	}
+
+	if isLast {
+		for _, b := range base.Buckets {
+			n := float64(b.AggregatorData.N)
+			b.Avg /= n
+			b.P10 /= n
+			b.P50 /= n
+			b.P90 /= n
+			b.Stdev = math.Sqrt(max(0, b.AggregatorData.SumSq/n-b.Avg*b.Avg))
+		}
+	}
+}
+
+func (rsrc *raidSimResultCombiner) addDamageAttributionMetrics(unit *proto.UnitMetrics, add *proto.DamageAttributionMetrics, weight float64) {
+	var dam *proto.DamageAttributionMetrics
+
+	addKey := add.Id.String()
+	for _, baseAttribution := range unit.DamageAttributions {
+		if baseAttribution.Id.String() == addKey {
+			dam = baseAttribution
+			break
+		}
+	}
+
+	if dam == nil {
+		dam = &proto.DamageAttributionMetrics{
+			Id:             add.Id,
+			AggregatorData: &proto.AggregatorData{},
+		}
+		unit.DamageAttributions = append(unit.DamageAttributions, dam)
+	}
+
+	dam.DpsAvg += add.DpsAvg * weight
+	dam.AggregatorData.SumSq += add.AggregatorData.SumSq
+}
+
 func (rsrc *raidSimResultCombiner) addResourceMetrics(unit *proto.UnitMetrics, add *proto.ResourceMetrics) {
 	var rm *proto.ResourceMetrics
 
@@ -256,6 +355,20 @@ func (rsrc *raidSimResultCombiner) combineUnitMetrics(base *proto.UnitMetrics, a
 
 	base.SecondsOomAvg += add.SecondsOomAvg * weight
 	base.ChanceOfDeath += add.ChanceOfDeath * weight
+	base.ActiveSecondsAvg += add.ActiveSecondsAvg * weight
+
+	if add.DpsTimeline != nil {
+		if base.DpsTimeline == nil {
+			base.DpsTimeline = &proto.TimelineMetrics{}
+		}
+		rsrc.combineTimelineMetrics(base.DpsTimeline, add.DpsTimeline, isLast)
+	}
+	for i, addTimeline := range add.ResourceTimelines {
+		if i == len(base.ResourceTimelines) {
+			base.ResourceTimelines = append(base.ResourceTimelines, &proto.ResourceTimeline{Type: addTimeline.Type, Levels: &proto.TimelineMetrics{}})
+		}
+		rsrc.combineTimelineMetrics(base.ResourceTimelines[i].Levels, addTimeline.Levels, isLast)
+	}
 
 	for _, addAction := range add.Actions {
 		rsrc.addActionMetrics(base, addAction)
@@ -269,6 +382,18 @@ func (rsrc *raidSimResultCombiner) combineUnitMetrics(base *proto.UnitMetrics, a
 		rsrc.addResourceMetrics(base, addResource)
 	}
 
+	for _, addAttribution := range add.DamageAttributions {
+		rsrc.addDamageAttributionMetrics(base, addAttribution, weight)
+	}
+	if isLast {
+		for _, dam := range base.DamageAttributions {
+			// Results which never saw a modifier count as 0 damage for it.
+			dam.AggregatorData.N = base.Dps.AggregatorData.N
+			n := float64(dam.AggregatorData.N)
+			dam.DpsStdev = math.Sqrt(max(0, dam.AggregatorData.SumSq/n-dam.DpsAvg*dam.DpsAvg))
+		}
+	}
+
 	for i, addPet := range add.Pets {
 		rsrc.combineUnitMetrics(base.Pets[i], addPet, isLast, weight)
 	}
@@ -310,6 +435,7 @@ func (rsrc *raidSimResultCombiner) SetBaseResult(baseRsr *proto.RaidSimResult) {
 			Targets: make([]*proto.UnitMetrics, len(baseRsr.EncounterMetrics.Targets)),
 		},
 		FirstIterationDuration: baseRsr.FirstIterationDuration,
+		CombatLog:              baseRsr.CombatLog,
 	}
 
 	if !rsrc.Debug {
@@ -439,7 +565,7 @@ func runSimConcurrent(request *proto.RaidSimRequest, progress chan *proto.Progre
 		}
 	}()
 
-	splitRes := SplitSimRequestForConcurrency(request, TernaryInt32(request.SimOptions.IsTest, 3, int32(runtime.NumCPU())))
+	splitRes := SplitSimRequestForConcurrency(keepValuesForPercentiles(request), TernaryInt32(request.SimOptions.IsTest, 3, int32(runtime.NumCPU())))
 
 	if splitRes.ErrorResult != "" {
 		panic(splitRes.ErrorResult)
@@ -525,6 +651,7 @@ func runSimConcurrent(request *proto.RaidSimRequest, progress chan *proto.Progre
 	}
 
 	result = CombineConcurrentSimResults(csd.FinalResults, request.SimOptions.Debug)
+	dropUnrequestedValues(request, result)
 
 	if progress != nil {
 		pm := csd.MakeProgressMetrics()
@@ -0,0 +1,42 @@
+package core
+
+import (
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+)
+
+// Reports the combined progress of several sims which are run one after another,
+// for requests like stat weights that are made of multiple raid sims.
+type simSeriesProgress struct {
+	progress chan *proto.ProgressMetrics
+
+	iterationsTotal int32
+	iterationsDone  int32
+	simsTotal       int32
+	simsCompleted   int32
+}
+
+// Forwards the progress of a single sim until it finishes, and returns its result.
+// A sim whose channel is closed without a final result returns an error result, so
+// callers can always check Error.
+func (sp *simSeriesProgress) waitForResult(srcProgressChannel chan *proto.ProgressMetrics) *proto.RaidSimResult {
+	var lastCompleted int32 = 0
+	for metrics := range srcProgressChannel {
+		sp.iterationsDone += metrics.CompletedIterations - lastCompleted
+		lastCompleted = metrics.CompletedIterations
+
+		if sp.progress != nil {
+			sp.progress <- &proto.ProgressMetrics{
+				TotalIterations:     sp.iterationsTotal,
+				CompletedIterations: sp.iterationsDone,
+				CompletedSims:       sp.simsCompleted,
+				TotalSims:           sp.simsTotal,
+			}
+		}
+
+		if metrics.FinalRaidResult != nil {
+			sp.simsCompleted++
+			return metrics.FinalRaidResult
+		}
+	}
+	return &proto.RaidSimResult{Error: &proto.ErrorOutcome{Message: "Sim ended without a result"}}
+}
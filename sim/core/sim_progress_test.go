@@ -0,0 +1,36 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+)
+
+func TestSimSeriesProgress(t *testing.T) {
+	progress := make(chan *proto.ProgressMetrics, 10)
+	sp := &simSeriesProgress{progress: progress, iterationsTotal: 20, simsTotal: 2}
+
+	first := make(chan *proto.ProgressMetrics, 10)
+	first <- &proto.ProgressMetrics{CompletedIterations: 4}
+	first <- &proto.ProgressMetrics{CompletedIterations: 10, FinalRaidResult: &proto.RaidSimResult{}}
+	if result := sp.waitForResult(first); result.Error != nil {
+		t.Fatalf("Unexpected error: %s", result.Error.Message)
+	}
+
+	// A sim which stops without a final result still returns one, with an error.
+	second := make(chan *proto.ProgressMetrics, 10)
+	second <- &proto.ProgressMetrics{CompletedIterations: 3}
+	close(second)
+	if result := sp.waitForResult(second); result.Error == nil {
+		t.Fatalf("Expected an error for a sim without a final result")
+	}
+
+	close(progress)
+	var last *proto.ProgressMetrics
+	for metrics := range progress {
+		last = metrics
+	}
+	if last.CompletedIterations != 13 || last.TotalIterations != 20 || last.CompletedSims != 1 || last.TotalSims != 2 {
+		t.Fatalf("Unexpected combined progress %v", last)
+	}
+}
@@ -512,6 +512,15 @@ func (spell *Spell) CanCast(sim *Simulation, target *Unit) bool {
 		return false
 	}
 
+	if spell.ProcMask.Matches(ProcMaskSpellHealing) && spell.Unit.PseudoStats.HealingSpellsLocked {
+		return false
+	}
+
+	// Targets which aren't in the fight can't be attacked.
+	if target != nil && target.Type == EnemyUnit && !target.IsEnabled() {
+		return false
+	}
+
 	// While moving only instant casts are possible
 	if spell.DefaultCast.CastTime > 0 && spell.Unit.IsMoving() {
 		//if sim.Log != nil {
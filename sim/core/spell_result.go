@@ -443,12 +443,21 @@ func (spell *Spell) dealDamageInternal(sim *Simulation, isPeriodic bool, result
 			spell.SpellMetrics[result.Target.UnitIndex].TotalCrushDamage += result.Damage
 		}
 		spell.SpellMetrics[result.Target.UnitIndex].TotalThreat += result.Threat
+
+		if result.Damage > 0 && spell.Unit.IsOpponent(result.Target) {
+			spell.attributeDamage(isPeriodic, result)
+		}
+	}
+
+	if sim.timelineBucketSize > 0 && sim.CurrentTime >= 0 && spell.Unit.IsOpponent(result.Target) {
+		spell.Unit.Metrics.dpsTimeline.add(sim.timelineBucket(sim.CurrentTime), result.Damage)
 	}
 
 	// Mark total damage done in raid so far for health based fights.
 	// Don't include damage done by EnemyUnits to Players
 	if result.Target.Type == EnemyUnit {
 		sim.Encounter.DamageTaken += result.Damage
+		sim.Encounter.Targets[result.Target.Index].addDamageTaken(sim, result.Damage)
 	}
 
 	if sim.Log != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
@@ -458,6 +467,9 @@ func (spell *Spell) dealDamageInternal(sim *Simulation, isPeriodic bool, result
 			spell.Unit.Log(sim, "%s %s %s (SpellSchool: %d). (Threat: %0.3f)", result.Target.LogLabel(), spell.ActionID, result.DamageString(), spell.SpellSchool, result.Threat)
 		}
 	}
+	if sim.combatLog != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+		spell.logCombatResult(sim, result, isPeriodic, false)
+	}
 
 	if !spell.Flags.Matches(SpellFlagNoOnDamageDealt) {
 		if isPeriodic {
@@ -540,11 +552,10 @@ func (dot *Dot) SnapshotHeal(target *Unit, baseHealing float64, isRollover bool)
 			dot.SnapshotBaseDamage += dot.BonusCoefficient * dot.Spell.HealingPower(target)
 		}
 
-		attackTable := dot.Spell.Unit.AttackTables[target.UnitIndex][dot.Spell.CastType]
-		dot.SnapshotAttackerMultiplier = dot.Spell.AttackerDamageMultiplier(attackTable, true)
+		dot.SnapshotAttackerMultiplier = dot.Spell.CasterHealingMultiplier()
 		dot.SnapshotAttackerMultiplier *= dot.DamageMultiplier
 
-		dot.SnapshotCritChance = dot.Spell.SpellCritChance(target)
+		dot.SnapshotCritChance = dot.Spell.HealingCritChance()
 	}
 }
 
@@ -556,6 +567,8 @@ func (spell *Spell) dealHealingInternal(sim *Simulation, isPeriodic bool, result
 	spell.SpellMetrics[result.Target.UnitIndex].TotalHealing += result.Damage
 	spell.SpellMetrics[result.Target.UnitIndex].TotalThreat += result.Threat
 	if result.Target.HasHealthBar() {
+		missingHealth := result.Target.MaxHealth() - result.Target.CurrentHealth()
+		spell.SpellMetrics[result.Target.UnitIndex].TotalOverhealing += max(0, result.Damage-missingHealth)
 		result.Target.GainHealth(sim, result.Damage, spell.HealthMetrics(result.Target))
 	}
 
@@ -566,6 +579,9 @@ func (spell *Spell) dealHealingInternal(sim *Simulation, isPeriodic bool, result
 			spell.Unit.Log(sim, "%s %s %s. (Threat: %0.3f)", result.Target.LogLabel(), spell.ActionID, result.HealingString(), result.Threat)
 		}
 	}
+	if sim.combatLog != nil {
+		spell.logCombatResult(sim, result, isPeriodic, true)
+	}
 
 	if isPeriodic {
 		spell.Unit.OnPeriodicHealDealt(sim, spell, result)
@@ -659,6 +675,10 @@ func (spell *Spell) TargetBonusDamageTakenAfterModifiers(attackTable *AttackTabl
 	return attackTable.Defender.PseudoStats.BonusDamageTakenAfterModifiers[spell.DefenseType]
 }
 func (spell *Spell) TargetDamageMultiplier(attackTable *AttackTable, isPeriodic bool) float64 {
+	if attackTable.Defender.PseudoStats.Invulnerable {
+		return 0
+	}
+
 	if spell.Flags.Matches(SpellFlagIgnoreTargetModifiers) {
 		return 1
 	}
@@ -0,0 +1,161 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+	"github.com/isfir/wowsims-turtle/sim/core/simsignals"
+	"github.com/isfir/wowsims-turtle/sim/core/stats"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+const maxStatScalingPoints = 200
+
+// Returns the amounts of the stat to sim, from start to end in steps of step. The end
+// is always included, even when the range isn't a multiple of the step.
+func statScalingAmounts(start float64, end float64, step float64) ([]float64, error) {
+	if step <= 0 {
+		return nil, fmt.Errorf("stat scaling step must be positive")
+	}
+	if end < start {
+		return nil, fmt.Errorf("stat scaling range end %g is below its start %g", end, start)
+	}
+
+	numSteps := int(math.Floor((end-start)/step + 1e-9))
+	if numSteps+2 > maxStatScalingPoints {
+		return nil, fmt.Errorf("stat scaling range has too many points, the maximum is %d", maxStatScalingPoints)
+	}
+
+	amounts := make([]float64, 0, numSteps+2)
+	for i := 0; i <= numSteps; i++ {
+		amounts = append(amounts, start+float64(i)*step)
+	}
+	if end-amounts[len(amounts)-1] > 1e-9*step {
+		amounts = append(amounts, end)
+	}
+	return amounts, nil
+}
+
+// Returns the paired difference between two points divided by the distance between
+// their amounts, i.e. the dps gained per point of the stat.
+func marginalDifference(low *proto.DistributionMetrics, high *proto.DistributionMetrics, amountDelta float64, confidence float64) *proto.PairedDifference {
+	diff := pairedDifference(low, high, confidence)
+	diff.Mean /= amountDelta
+	diff.Stderr /= amountDelta
+	diff.CiLow /= amountDelta
+	diff.CiHigh /= amountDelta
+	return diff
+}
+
+// Fills in the marginal dps of each point, using the central difference between its
+// neighbours, or the one-sided difference at the ends of the range.
+func computeStatScalingMarginals(points []*proto.StatScalingPoint, confidence float64) {
+	if len(points) < 2 {
+		return
+	}
+	for i, point := range points {
+		low := points[max(0, i-1)]
+		high := points[min(len(points)-1, i+1)]
+		point.MarginalDps = marginalDifference(low.Dps, high.Dps, high.Amount-low.Amount, confidence)
+	}
+}
+
+func runStatScaling(request *proto.StatScalingRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) *proto.StatScalingResult {
+	if request.Player == nil {
+		return &proto.StatScalingResult{Error: &proto.ErrorOutcome{Message: "Stat scaling needs a player!"}}
+	}
+
+	var unitStat stats.UnitStat
+	switch scaledStat := request.ScaledStat.(type) {
+	case *proto.StatScalingRequest_Stat:
+		unitStat = stats.UnitStatFromStat(stats.Stat(scaledStat.Stat))
+	case *proto.StatScalingRequest_PseudoStat:
+		unitStat = stats.UnitStatFromPseudoStat(scaledStat.PseudoStat)
+	default:
+		return &proto.StatScalingResult{Error: &proto.ErrorOutcome{Message: "Stat scaling needs a stat to scale!"}}
+	}
+
+	amounts, err := statScalingAmounts(request.RangeStart, request.RangeEnd, request.Step)
+	if err != nil {
+		return &proto.StatScalingResult{Error: &proto.ErrorOutcome{Message: err.Error()}}
+	}
+
+	simOptions := &proto.SimOptions{}
+	if request.SimOptions != nil {
+		simOptions = googleProto.Clone(request.SimOptions).(*proto.SimOptions)
+	}
+	simOptions.SaveAllValues = true
+	// Every point needs the same seed, so that their iterations can be paired.
+	if simOptions.RandomSeed == 0 {
+		simOptions.RandomSeed = time.Now().UnixNano()
+	}
+	simOptions.UseLabeledRands = true
+
+	confidence := simOptions.ConfidenceLevel
+	if confidence <= 0 || confidence >= 1 {
+		confidence = 0.95
+	}
+
+	sp := &simSeriesProgress{
+		progress:        progress,
+		iterationsTotal: simOptions.Iterations * int32(len(amounts)),
+		simsTotal:       int32(len(amounts)),
+	}
+
+	simFunc := runSimConcurrent
+	// Don't use go threads in wasm, it just adds more overhead and makes the worker more unresponsive.
+	if IsRunningInWasm() || simOptions.IsTest {
+		simFunc = RunSim
+	}
+
+	result := &proto.StatScalingResult{}
+	for _, amount := range amounts {
+		if signals.Abort.IsTriggered() {
+			return &proto.StatScalingResult{Error: &proto.ErrorOutcome{Message: "aborted"}}
+		}
+
+		player := googleProto.Clone(request.Player).(*proto.Player)
+		if player.BonusStats == nil {
+			player.BonusStats = &proto.UnitStats{}
+		}
+		if player.BonusStats.Stats == nil {
+			player.BonusStats.Stats = make([]float64, stats.Len)
+		}
+		if player.BonusStats.PseudoStats == nil {
+			player.BonusStats.PseudoStats = make([]float64, stats.PseudoStatsLen)
+		}
+		unitStat.AddToStatsProto(player.BonusStats, amount)
+
+		raidProto := SinglePlayerRaidProto(player, request.PartyBuffs, request.RaidBuffs, request.Debuffs)
+		raidProto.Tanks = request.Tanks
+		simRequest := &proto.RaidSimRequest{
+			Raid:       raidProto,
+			Encounter:  request.Encounter,
+			SimOptions: simOptions,
+		}
+
+		pointProgress := make(chan *proto.ProgressMetrics, 100)
+		go simFunc(simRequest, pointProgress, signals)
+		pointResult := sp.waitForResult(pointProgress)
+		if pointResult.Error != nil {
+			return &proto.StatScalingResult{Error: pointResult.Error}
+		}
+
+		result.Points = append(result.Points, &proto.StatScalingPoint{
+			Amount: amount,
+			Dps:    pointResult.RaidMetrics.Parties[0].Players[0].Dps,
+		})
+	}
+
+	computeStatScalingMarginals(result.Points, confidence)
+
+	// The iteration values were only needed for the pairing.
+	if !request.GetSimOptions().GetSaveAllValues() {
+		for _, point := range result.Points {
+			point.Dps.AllValues = nil
+		}
+	}
+	return result
+}
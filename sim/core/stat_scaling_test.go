@@ -0,0 +1,56 @@
+package core
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+)
+
+func TestStatScalingAmounts(t *testing.T) {
+	amounts, err := statScalingAmounts(-2, 3, 1)
+	if err != nil || !slices.Equal(amounts, []float64{-2, -1, 0, 1, 2, 3}) {
+		t.Fatalf("Unexpected amounts %v, %v", amounts, err)
+	}
+
+	// The end of the range is included even when it's not a whole step away.
+	amounts, _ = statScalingAmounts(0, 25, 10)
+	if !slices.Equal(amounts, []float64{0, 10, 20, 25}) {
+		t.Fatalf("Unexpected amounts %v", amounts)
+	}
+
+	if _, err := statScalingAmounts(0, 10, 0); err == nil {
+		t.Fatalf("Expected an error for a zero step")
+	}
+	if _, err := statScalingAmounts(10, 0, 1); err == nil {
+		t.Fatalf("Expected an error for a reversed range")
+	}
+	if _, err := statScalingAmounts(0, 1000, 1); err == nil {
+		t.Fatalf("Expected an error for too many points")
+	}
+}
+
+func TestStatScalingMarginals(t *testing.T) {
+	points := []*proto.StatScalingPoint{
+		{Amount: 0, Dps: &proto.DistributionMetrics{Avg: 100, AllValues: []float64{90, 110}}},
+		{Amount: 1, Dps: &proto.DistributionMetrics{Avg: 110, AllValues: []float64{100, 120}}},
+		{Amount: 2, Dps: &proto.DistributionMetrics{Avg: 116, AllValues: []float64{104, 128}}},
+	}
+	computeStatScalingMarginals(points, 0.95)
+
+	// One-sided at the ends, central in the middle.
+	want := []float64{10, 8, 6}
+	for i, point := range points {
+		if math.Abs(point.MarginalDps.Mean-want[i]) > 1e-9 {
+			t.Fatalf("Marginal dps at %0.0f = %0.3f, want %0.3f", point.Amount, point.MarginalDps.Mean, want[i])
+		}
+	}
+	// Differences of the middle point are 7 and 9 per point: stdev 1, over sqrt(2).
+	if math.Abs(points[1].MarginalDps.Stderr-1/math.Sqrt2) > 1e-9 {
+		t.Fatalf("Unexpected marginal stderr %0.3f", points[1].MarginalDps.Stderr)
+	}
+	if points[0].MarginalDps.Stderr != 0 || !points[0].MarginalDps.Significant {
+		t.Fatalf("Expected an exact marginal at the start, got %v", points[0].MarginalDps)
+	}
+}
@@ -436,6 +436,7 @@ type PseudoStats struct {
 
 	HealingDealtMultiplier float64 // All healing
 	ShieldDealtMultiplier  float64 // Increases the effectiveness of your shielding spells.
+	HealingSpellsLocked    bool    // Prevents casting healing spells, e.g. Loatheb's Corrupted Mind.
 
 	// Important when unit is attacker or target
 	BlockValueMultiplier float64
@@ -477,6 +478,8 @@ type PseudoStats struct {
 	CanCrush bool
 	Stunned  bool // prevents blocks, dodges, and parries
 
+	Invulnerable bool // Takes no damage, e.g. during scripted encounter phases.
+
 	ParryHaste bool
 
 	ReducedCritTakenChance float64 // Reduces chance to be crit.
@@ -276,38 +276,15 @@ func computeStatWeights(swcr *proto.StatWeightsCalcRequest) *proto.StatWeightsRe
 func runStatWeights(request *proto.StatWeightsRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) *proto.StatWeightsResult {
 	requestData := buildStatWeightRequests(request)
 
-	var iterationsTotal int32 = requestData.BaseRequest.SimOptions.Iterations
-	var iterationsDone int32 = 0
-	var simsTotal int32 = 1
-	var simsCompleted int32 = 0
-
-	for _, reqData := range requestData.StatSimRequests {
-		iterationsTotal += reqData.RequestLow.SimOptions.Iterations
-		iterationsTotal += reqData.RequestHigh.SimOptions.Iterations
-		simsTotal += 2
+	sp := &simSeriesProgress{
+		progress:        progress,
+		iterationsTotal: requestData.BaseRequest.SimOptions.Iterations,
+		simsTotal:       1,
 	}
-
-	waitForResult := func(srcProgressChannel chan *proto.ProgressMetrics) *proto.RaidSimResult {
-		var lastCompleted int32 = 0
-		for metrics := range srcProgressChannel {
-			iterationsDone += metrics.CompletedIterations - lastCompleted
-			lastCompleted = metrics.CompletedIterations
-
-			if progress != nil {
-				progress <- &proto.ProgressMetrics{
-					TotalIterations:     iterationsTotal,
-					CompletedIterations: iterationsDone,
-					CompletedSims:       simsCompleted,
-					TotalSims:           simsTotal,
-				}
-			}
-
-			if metrics.FinalRaidResult != nil {
-				simsCompleted++
-				return metrics.FinalRaidResult
-			}
-		}
-		return nil
+	for _, reqData := range requestData.StatSimRequests {
+		sp.iterationsTotal += reqData.RequestLow.SimOptions.Iterations
+		sp.iterationsTotal += reqData.RequestHigh.SimOptions.Iterations
+		sp.simsTotal += 2
 	}
 
 	simFunc := runSimConcurrent
@@ -318,7 +295,7 @@ func runStatWeights(request *proto.StatWeightsRequest, progress chan *proto.Prog
 
 	baseProgress := make(chan *proto.ProgressMetrics, 100)
 	go simFunc(requestData.BaseRequest, baseProgress, signals)
-	baselineResult := waitForResult(baseProgress)
+	baselineResult := sp.waitForResult(baseProgress)
 	if baselineResult.Error != nil {
 		return &proto.StatWeightsResult{Error: baselineResult.Error}
 	}
@@ -328,14 +305,14 @@ func runStatWeights(request *proto.StatWeightsRequest, progress chan *proto.Prog
 	for _, reqData := range requestData.StatSimRequests {
 		lowProgress := make(chan *proto.ProgressMetrics, 100)
 		go simFunc(reqData.RequestLow, lowProgress, signals)
-		lowRes := waitForResult(lowProgress)
+		lowRes := sp.waitForResult(lowProgress)
 		if lowRes.Error != nil {
 			return &proto.StatWeightsResult{Error: lowRes.Error}
 		}
 
 		highProgress := make(chan *proto.ProgressMetrics, 100)
 		go simFunc(reqData.RequestHigh, highProgress, signals)
-		highRes := waitForResult(highProgress)
+		highRes := sp.waitForResult(highProgress)
 		if highRes.Error != nil {
 			return &proto.StatWeightsResult{Error: highRes.Error}
 		}
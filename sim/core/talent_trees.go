@@ -0,0 +1,165 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+)
+
+const (
+	// Talent points available at level 60.
+	MaxTalentPoints = 51
+	// Points which must be spent in a tree to unlock each row.
+	TalentPointsPerRow = 5
+)
+
+// Layout of a single talent, in the same order as the talent string.
+type TalentConfig struct {
+	Name      string
+	Row       int
+	MaxPoints int
+
+	// Name of the talent in the same tree which must be maxed first, if any.
+	Prereq string
+}
+
+type TalentTrees [3][]TalentConfig
+
+var talentTreesByClass = make(map[proto.Class]TalentTrees)
+
+// Registers the talent tree layouts of a class, which are needed to search its
+// talent builds.
+func RegisterTalentTrees(class proto.Class, trees TalentTrees) {
+	talentTreesByClass[class] = trees
+}
+
+// Points spent in each talent of each tree.
+type talentBuild [3][]int
+
+// Parses a wowhead-formatted talent string, e.g. "12123131-123123123-123123213".
+func parseTalentBuild(talentsStr string, trees TalentTrees) (talentBuild, error) {
+	var build talentBuild
+	treeStrs := strings.Split(talentsStr, "-")
+	if len(treeStrs) > 3 {
+		return build, fmt.Errorf("invalid talent string %s", talentsStr)
+	}
+
+	for treeIdx := range trees {
+		build[treeIdx] = make([]int, len(trees[treeIdx]))
+		if treeIdx >= len(treeStrs) {
+			continue
+		}
+		if len(treeStrs[treeIdx]) > len(trees[treeIdx]) {
+			return build, fmt.Errorf("invalid talent string %s", talentsStr)
+		}
+		for talentIdx, c := range treeStrs[treeIdx] {
+			if c < '0' || c > '9' {
+				return build, fmt.Errorf("invalid talent string %s", talentsStr)
+			}
+			build[treeIdx][talentIdx] = int(c - '0')
+		}
+	}
+	return build, nil
+}
+
+func (build talentBuild) String() string {
+	treeStrs := make([]string, len(build))
+	for treeIdx, tree := range build {
+		var sb strings.Builder
+		for _, points := range tree {
+			sb.WriteByte(byte('0' + points))
+		}
+		treeStrs[treeIdx] = strings.TrimRight(sb.String(), "0")
+	}
+	return strings.TrimRight(strings.Join(treeStrs[:], "-"), "-")
+}
+
+func (build talentBuild) totalPoints() int {
+	total := 0
+	for _, tree := range build {
+		for _, points := range tree {
+			total += points
+		}
+	}
+	return total
+}
+
+// Returns whether the build could be learned in game, i.e. every talent respects
+// its max rank, row requirement and prerequisite.
+func (build talentBuild) isValid(trees TalentTrees) bool {
+	if build.totalPoints() > MaxTalentPoints {
+		return false
+	}
+
+	for treeIdx, tree := range trees {
+		for talentIdx, talent := range tree {
+			points := build[treeIdx][talentIdx]
+			if points == 0 {
+				continue
+			}
+			if points > talent.MaxPoints {
+				return false
+			}
+
+			spentBelow := 0
+			for otherIdx, other := range tree {
+				if other.Row < talent.Row {
+					spentBelow += build[treeIdx][otherIdx]
+				}
+			}
+			if spentBelow < talent.Row*TalentPointsPerRow {
+				return false
+			}
+
+			if talent.Prereq != "" {
+				prereqIdx := -1
+				for otherIdx, other := range tree {
+					if other.Name == talent.Prereq {
+						prereqIdx = otherIdx
+					}
+				}
+				if prereqIdx == -1 || build[treeIdx][prereqIdx] < tree[prereqIdx].MaxPoints {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// Returns every valid build which moves a single point of this build to another
+// talent, keeping the same point total.
+func (build talentBuild) neighbors(trees TalentTrees) []talentBuild {
+	var neighbors []talentBuild
+	for fromTree := range build {
+		for fromIdx := range build[fromTree] {
+			if build[fromTree][fromIdx] == 0 {
+				continue
+			}
+			for toTree := range build {
+				for toIdx := range build[toTree] {
+					if (fromTree == toTree && fromIdx == toIdx) || build[toTree][toIdx] >= trees[toTree][toIdx].MaxPoints {
+						continue
+					}
+
+					neighbor := build.clone()
+					neighbor[fromTree][fromIdx]--
+					neighbor[toTree][toIdx]++
+					if neighbor.isValid(trees) {
+						neighbors = append(neighbors, neighbor)
+					}
+				}
+			}
+		}
+	}
+	return neighbors
+}
+
+func (build talentBuild) clone() talentBuild {
+	var newBuild talentBuild
+	for treeIdx, tree := range build {
+		newBuild[treeIdx] = append([]int{}, tree...)
+	}
+	return newBuild
+}
@@ -0,0 +1,69 @@
+package core
+
+import (
+	"testing"
+)
+
+// Two talents on the first row, and a third one on the second row which needs
+// the first one maxed.
+var testTalentTrees = TalentTrees{
+	{
+		{Name: "a", Row: 0, MaxPoints: 5},
+		{Name: "b", Row: 0, MaxPoints: 5},
+		{Name: "c", Row: 1, MaxPoints: 1, Prereq: "a"},
+	},
+	{
+		{Name: "d", Row: 0, MaxPoints: 3},
+	},
+	{},
+}
+
+func TestTalentBuildIsValid(t *testing.T) {
+	for _, tc := range []struct {
+		talents string
+		want    bool
+	}{
+		{talents: "", want: true},
+		{talents: "42-1", want: true},
+		{talents: "501", want: true},
+		{talents: "6", want: false},
+		{talents: "-4", want: false},
+		{talents: "411", want: false}, // a isn't maxed
+		{talents: "051", want: false}, // b is maxed but not a
+		{talents: "401-3", want: false},
+	} {
+		build, err := parseTalentBuild(tc.talents, testTalentTrees)
+		if err != nil {
+			t.Fatalf("parseTalentBuild(%q) returned error: %v", tc.talents, err)
+		}
+		if got := build.isValid(testTalentTrees); got != tc.want {
+			t.Errorf("isValid(%q) = %v, want %v", tc.talents, got, tc.want)
+		}
+	}
+
+	if _, err := parseTalentBuild("5000", testTalentTrees); err == nil {
+		t.Errorf("parseTalentBuild() should reject strings longer than the tree")
+	}
+}
+
+func TestTalentBuildNeighbors(t *testing.T) {
+	build, _ := parseTalentBuild("51", testTalentTrees)
+	if build.String() != "51" {
+		t.Fatalf("String() = %q, want 51", build.String())
+	}
+
+	var got []string
+	for _, neighbor := range build.neighbors(testTalentTrees) {
+		got = append(got, neighbor.String())
+	}
+	// c can only take the point from b, since a must stay maxed.
+	want := []string{"42", "41-1", "501", "5-1"}
+	if len(got) != len(want) {
+		t.Fatalf("neighbors() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("neighbors() = %v, want %v", got, want)
+		}
+	}
+}
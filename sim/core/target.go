@@ -1,6 +1,7 @@
 package core
 
 import (
+	"slices"
 	"strconv"
 	"time"
 
@@ -14,6 +15,9 @@ type Encounter struct {
 	Targets           []*Target
 	TargetUnits       []*Unit
 
+	// Targets which are currently in the fight, in the same order as TargetUnits.
+	ActiveTargetUnits []*Unit
+
 	ExecuteProportion_20 float64
 	ExecuteProportion_25 float64
 	ExecuteProportion_35 float64
@@ -27,6 +31,9 @@ type Encounter struct {
 
 	// Value to multiply by, for damage spells which are subject to the aoe cap.
 	aoeCapMultiplier float64
+
+	// Scripted fight phases, see proto.EncounterPhase.
+	Phases []*proto.EncounterPhase
 }
 
 func NewEncounter(options *proto.Encounter) Encounter {
@@ -40,6 +47,7 @@ func NewEncounter(options *proto.Encounter) Encounter {
 		ExecuteProportion_25: max(options.ExecuteProportion_25, 0),
 		ExecuteProportion_35: max(options.ExecuteProportion_35, 0),
 		Targets:              []*Target{},
+		Phases:               options.Phases,
 	}
 	// If UseHealth is set, we use the sum of targets health.
 	if options.UseHealth {
@@ -63,6 +71,7 @@ func NewEncounter(options *proto.Encounter) Encounter {
 		encounter.Targets = append(encounter.Targets, target)
 		encounter.TargetUnits = append(encounter.TargetUnits, &target.Unit)
 	}
+	encounter.ActiveTargetUnits = slices.Clone(encounter.TargetUnits)
 
 	if encounter.EndFightAtHealth > 0 {
 		// Until we pre-sim set duration to 10m
@@ -71,6 +80,7 @@ func NewEncounter(options *proto.Encounter) Encounter {
 	}
 
 	encounter.updateAOECapMultiplier()
+	encounter.validatePhases()
 
 	return encounter
 }
@@ -79,7 +89,20 @@ func (encounter *Encounter) AOECapMultiplier() float64 {
 	return encounter.aoeCapMultiplier
 }
 func (encounter *Encounter) updateAOECapMultiplier() {
-	encounter.aoeCapMultiplier = min(10/float64(len(encounter.Targets)), 1)
+	encounter.aoeCapMultiplier = min(10/float64(max(len(encounter.ActiveTargetUnits), 1)), 1)
+}
+
+// Targets can despawn in the middle of an AoE ranging over the active targets, so the
+// list is always rebuilt in a new slice rather than in place.
+func (encounter *Encounter) updateActiveTargets() {
+	activeTargetUnits := make([]*Unit, 0, len(encounter.TargetUnits))
+	for _, unit := range encounter.TargetUnits {
+		if unit.IsEnabled() {
+			activeTargetUnits = append(activeTargetUnits, unit)
+		}
+	}
+	encounter.ActiveTargetUnits = activeTargetUnits
+	encounter.updateAOECapMultiplier()
 }
 
 func (encounter *Encounter) doneIteration(sim *Simulation) {
@@ -108,6 +131,18 @@ type Target struct {
 	Unit
 
 	AI TargetAI
+
+	// In-game name from the encounter, if any.
+	name string
+
+	spawnTime      time.Duration
+	despawnTime    time.Duration
+	despawnOnDeath bool
+
+	// Per-iteration lifetime tracking.
+	damageTaken float64
+	spawnedAt   time.Duration
+	activeTime  time.Duration
 }
 
 func NewTarget(options *proto.Target, targetIndex int32) *Target {
@@ -130,6 +165,11 @@ func NewTarget(options *proto.Target, targetIndex int32) *Target {
 
 			StatDependencyManager: stats.NewStatDependencyManager(),
 		},
+
+		name:           options.Name,
+		spawnTime:      DurationFromSeconds(options.SpawnTime),
+		despawnTime:    DurationFromSeconds(options.DespawnTime),
+		despawnOnDeath: options.DespawnOnDeath,
 	}
 	defaultRaidBossLevel := int32(CharacterMaxLevel + 3)
 	target.GCD = target.NewTimer()
@@ -162,14 +202,132 @@ func (target *Target) Reset(sim *Simulation) {
 	if target.AI != nil {
 		target.AI.Reset(sim)
 	}
+
+	target.damageTaken = 0
+	target.spawnedAt = 0
+	target.activeTime = 0
+}
+
+// Despawns targets which join the fight later and schedules their spawns and
+// timed despawns. Must happen after the raid reset, so that raid members
+// retarget away from targets which aren't in the fight yet.
+func (target *Target) scheduleLifetime(sim *Simulation) {
+	if target.spawnTime > 0 {
+		target.Despawn(sim)
+		StartDelayedAction(sim, DelayedActionOptions{
+			DoAt:     target.spawnTime,
+			Priority: ActionPriorityPrePull,
+			OnAction: target.Spawn,
+		})
+	}
+
+	if target.despawnTime > 0 {
+		StartDelayedAction(sim, DelayedActionOptions{
+			DoAt:     target.despawnTime,
+			Priority: ActionPriorityPrePull,
+			OnAction: target.Despawn,
+		})
+	}
 }
 
+func (target *Target) addDamageTaken(sim *Simulation, damage float64) {
+	target.damageTaken += damage
+	if target.despawnOnDeath && target.enabled && target.damageTaken >= target.GetStat(stats.Health) {
+		if sim.Log != nil {
+			target.Log(sim, "Died")
+		}
+		if sim.combatLog != nil {
+			target.logCombatEvent(sim, proto.CombatLogEventType_CombatLogEventUnitDied)
+		}
+		target.Despawn(sim)
+	}
+}
+
+// Spawn brings a despawned target back into the fight.
+func (target *Target) Spawn(sim *Simulation) {
+	if target.enabled {
+		return
+	}
+
+	if sim.Log != nil {
+		target.Log(sim, "Spawned")
+	}
+	if sim.combatLog != nil {
+		target.logCombatEvent(sim, proto.CombatLogEventType_CombatLogEventUnitSpawn)
+	}
+
+	target.enabled = true
+	target.spawnedAt = max(0, sim.CurrentTime)
+	target.Env.Encounter.updateActiveTargets()
+	if sim.CurrentTime >= 0 {
+		target.AutoAttacks.EnableAutoSwing(sim)
+	}
+	if target.gcdAction != nil {
+		target.SetGCDTimer(sim, max(0, sim.CurrentTime))
+	}
+}
+
+// Despawn removes the target from the fight. Raid members targeting it switch
+// to the first target that is still in the fight.
+func (target *Target) Despawn(sim *Simulation) {
+	if !target.enabled {
+		return
+	}
+
+	if sim.Log != nil {
+		target.Log(sim, "Despawned")
+	}
+	if sim.combatLog != nil {
+		target.logCombatEvent(sim, proto.CombatLogEventType_CombatLogEventUnitDespawn)
+	}
+
+	target.enabled = false
+	target.activeTime += max(0, sim.CurrentTime-target.spawnedAt)
+	target.Env.Encounter.updateActiveTargets()
+	target.AutoAttacks.CancelAutoSwing(sim)
+	if target.gcdAction != nil {
+		target.CancelGCDTimer(sim)
+	}
+
+	// DoTs and other temporary debuffs fall off, while permanent debuffs stay
+	// in case the target spawns again.
+	target.auraTracker.expireTemporary(sim)
+
+	if len(target.Env.Encounter.ActiveTargetUnits) == 0 {
+		return
+	}
+	newTarget := target.Env.Encounter.ActiveTargetUnits[0]
+	for _, unit := range target.Env.Raid.AllUnits {
+		if unit.CurrentTarget == &target.Unit {
+			unit.CurrentTarget = newTarget
+		}
+	}
+}
+
+// Returns the next target in the fight after this one, wrapping around. Returns
+// this target if no other target is in the fight.
 func (target *Target) NextTarget() *Target {
-	nextIndex := target.Index + 1
-	if nextIndex >= target.Env.GetNumTargets() {
-		nextIndex = 0
+	numTargets := target.Env.GetNumTargets()
+	nextIndex := target.Index
+	for range numTargets {
+		nextIndex++
+		if nextIndex >= numTargets {
+			nextIndex = 0
+		}
+		if next := target.Env.GetTarget(nextIndex); next.enabled {
+			return next
+		}
+	}
+	return target
+}
+
+func (target *Target) doneIteration(sim *Simulation) {
+	if target.enabled {
+		target.activeTime += max(0, sim.CurrentTime-target.spawnedAt)
 	}
-	return target.Env.GetTarget(nextIndex)
+	target.Metrics.ActiveTime = target.activeTime
+
+	target.Unit.doneIteration(sim)
 }
 
 func (target *Target) GetMetricsProto() *proto.UnitMetrics {
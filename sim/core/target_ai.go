@@ -114,7 +114,7 @@ func GetPresetTargetWithID(id int32) *PresetTarget {
 	return nil
 }
 
-func AddPresetEncounter(name string, targetPaths []string) {
+func AddPresetEncounter(name string, targetPaths []string, phases ...*proto.EncounterPhase) {
 	if len(targetPaths) == 0 {
 		log.Fatalf("Encounter must have targets!")
 	}
@@ -143,5 +143,6 @@ func AddPresetEncounter(name string, targetPaths []string) {
 	PresetEncounters = append(PresetEncounters, &proto.PresetEncounter{
 		Path:    path,
 		Targets: targetProtos,
+		Phases:  phases,
 	})
 }
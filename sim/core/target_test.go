@@ -0,0 +1,125 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+	"github.com/isfir/wowsims-turtle/sim/core/simsignals"
+	"github.com/isfir/wowsims-turtle/sim/core/stats"
+)
+
+func setupFakeTargetSim(targets []*proto.Target, phases []*proto.EncounterPhase) *Simulation {
+	sim := NewSim(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{
+			RandomSeed: 100,
+		},
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{
+				{
+					Players: []*proto.Player{
+						{
+							Name:      "Caster",
+							Class:     proto.Class_ClassShaman,
+							Consumes:  &proto.Consumes{},
+							Buffs:     &proto.IndividualBuffs{},
+							Spec:      &proto.Player_ElementalShaman{},
+							Equipment: &proto.EquipmentSpec{},
+						},
+					},
+					Buffs: &proto.PartyBuffs{},
+				},
+			},
+		},
+		Encounter: &proto.Encounter{
+			Targets:  targets,
+			Duration: 100,
+			Phases:   phases,
+		},
+	}, simsignals.CreateSignals())
+	sim.Reset()
+
+	return sim
+}
+
+// Runs all pending actions up to the given time.
+func runSimUntil(sim *Simulation, t time.Duration) {
+	for sim.pendingActions[len(sim.pendingActions)-1].NextActionAt <= t {
+		if finished := sim.Step(); finished {
+			return
+		}
+	}
+	sim.advance(t)
+}
+
+func TestTargetSpawnAndDespawn(t *testing.T) {
+	sim := setupFakeTargetSim([]*proto.Target{
+		{Name: "boss", Level: 63},
+		{Name: "add", Level: 63, SpawnTime: 10, DespawnTime: 30},
+		{Name: "totem", Level: 63, DespawnOnDeath: true, Stats: stats.Stats{stats.Health: 1000}.ToFloatArray()},
+	}, nil)
+	boss, add, totem := sim.Encounter.Targets[0], sim.Encounter.Targets[1], sim.Encounter.Targets[2]
+	numTargets := (&APLValueNumberTargets{}).GetInt
+
+	if add.IsEnabled() || numTargets(sim) != 2 {
+		t.Fatalf("Add should not be in the fight before its spawn time")
+	}
+	if sim.Environment.NextTarget(&boss.Unit) != totem {
+		t.Fatalf("Next target should skip targets which aren't in the fight")
+	}
+
+	runSimUntil(sim, time.Second*15)
+	if !add.IsEnabled() || numTargets(sim) != 3 {
+		t.Fatalf("Add should have spawned at 10s")
+	}
+
+	runSimUntil(sim, time.Second*35)
+	if add.IsEnabled() || numTargets(sim) != 2 {
+		t.Fatalf("Add should have despawned at 30s")
+	}
+
+	totem.addDamageTaken(sim, 600)
+	if !totem.IsEnabled() {
+		t.Fatalf("Totem should survive with health left")
+	}
+	totem.addDamageTaken(sim, 600)
+	if totem.IsEnabled() || numTargets(sim) != 1 {
+		t.Fatalf("Totem should have died once it took its health worth of damage")
+	}
+	if sim.Environment.NextTarget(&boss.Unit) != boss {
+		t.Fatalf("Next target should be the target itself when no other target is in the fight")
+	}
+
+	sim.Cleanup()
+	if add.Metrics.ActiveTime != time.Second*20 {
+		t.Fatalf("Expected the add to be active for 20s, got %s", add.Metrics.ActiveTime)
+	}
+	if boss.Metrics.ActiveTime != sim.Duration {
+		t.Fatalf("Expected the boss to be active for the whole fight, got %s", boss.Metrics.ActiveTime)
+	}
+}
+
+func TestTargetDespawnDuringAOE(t *testing.T) {
+	sim := setupFakeTargetSim([]*proto.Target{
+		{Name: "boss", Level: 63},
+		{Name: "totem", Level: 63, DespawnOnDeath: true, Stats: stats.Stats{stats.Health: 1000}.ToFloatArray()},
+		{Name: "add 1", Level: 63},
+		{Name: "add 2", Level: 63},
+	}, nil)
+
+	// The totem dies to the AoE while it's still going through the targets after it.
+	hits := make(map[*Unit]int)
+	for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
+		hits[aoeTarget]++
+		sim.Encounter.Targets[aoeTarget.Index].addDamageTaken(sim, 2000)
+	}
+
+	for _, target := range sim.Encounter.Targets {
+		if hits[&target.Unit] != 1 {
+			t.Fatalf("Expected %s to be hit once by the AoE, got %d hits", target.Label, hits[&target.Unit])
+		}
+	}
+	if sim.Encounter.Targets[1].IsEnabled() || sim.Environment.GetNumActiveTargets() != 3 {
+		t.Fatalf("Totem should have died to the AoE")
+	}
+}
@@ -128,6 +128,9 @@ type Unit struct {
 	// Statistics describing the results of the sim.
 	Metrics UnitMetrics
 
+	// Damage modifiers currently active on this unit whose contributions are tracked.
+	damageAttributions []*DamageAttribution
+
 	cdTimers []*Timer
 
 	AttackTables                []map[proto.CastType]*AttackTable
@@ -162,8 +165,8 @@ type Unit struct {
 
 // Units can be disabled for several reasons:
 //  1. Downtime for temporary pets (e.g. Water Elemental)
-//  2. Enemy units in various phases (not yet implemented)
-//  3. Dead units (not yet implemented)
+//  2. Enemy units which haven't spawned yet or have despawned
+//  3. Dead units (only implemented for enemy units)
 func (unit *Unit) IsEnabled() bool {
 	return unit.enabled
 }
@@ -1,18 +1,18 @@
 package druid
 
 import (
-	"time"
-
 	"github.com/isfir/wowsims-turtle/sim/core"
 )
 
+// https://www.wowhead.com/classic/spell=9898/demoralizing-roar
 func (druid *Druid) registerDemoralizingRoarSpell() {
 	druid.DemoralizingRoarAuras = druid.NewEnemyAuraArray(func(target *core.Unit) *core.Aura {
 		return core.DemoralizingRoarAura(target, druid.Talents.FeralAggression)
 	})
 
 	druid.DemoralizingRoar = druid.RegisterSpell(Bear, core.SpellConfig{
-		ActionID:    core.ActionID{SpellID: 48560},
+		SpellCode:   SpellCode_DruidDemoralizingRoar,
+		ActionID:    core.ActionID{SpellID: 9898},
 		SpellSchool: core.SpellSchoolPhysical,
 		ProcMask:    core.ProcMaskEmpty,
 		Flags:       SpellFlagOmen | core.SpellFlagAPL,
@@ -28,10 +28,10 @@ func (druid *Druid) registerDemoralizingRoarSpell() {
 		},
 
 		ThreatMultiplier: 1,
-		FlatThreatBonus:  62 * 2,
+		FlatThreatBonus:  42,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 				result := spell.CalcAndDealOutcome(sim, aoeTarget, spell.OutcomeMagicHit)
 				if result.Landed() {
 					druid.DemoralizingRoarAuras.Get(aoeTarget).Activate(sim)
@@ -42,22 +42,3 @@ func (druid *Druid) registerDemoralizingRoarSpell() {
 		RelatedAuras: []core.AuraArray{druid.DemoralizingRoarAuras},
 	})
 }
-
-func (druid *Druid) ShouldDemoralizingRoar(sim *core.Simulation, filler bool, maintainOnly bool) bool {
-	if !druid.DemoralizingRoar.CanCast(sim, druid.CurrentTarget) {
-		return false
-	}
-
-	if filler {
-		return true
-	}
-
-	refreshWindow := time.Second * 2
-
-	if (druid.MangleBear != nil) && (!druid.MangleBear.IsReady(sim)) {
-		refreshWindow = druid.MangleBear.ReadyAt() - sim.CurrentTime + core.GCDDefault
-	}
-
-	return maintainOnly &&
-		druid.DemoralizingRoarAuras.Get(druid.CurrentTarget).ShouldRefreshExclusiveEffects(sim, refreshWindow)
-}
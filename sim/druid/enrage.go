@@ -7,35 +7,24 @@ import (
 	"github.com/isfir/wowsims-turtle/sim/core/stats"
 )
 
+// https://www.wowhead.com/classic/spell=5229/enrage
+// Generates 20 rage over 10 sec, but reduces armor from items by 16% in Dire Bear Form.
 func (druid *Druid) registerEnrageSpell() {
 	actionID := core.ActionID{SpellID: 5229}
 	rageMetrics := druid.NewRageMetrics(actionID)
 
-	instantRage := []float64{20, 24, 27, 30}[druid.Talents.Intensity]
-
-	dmgBonus := 0.05 * float64(druid.Talents.KingOfTheJungle)
-
-	t10_4p := druid.HasSetBonus(ItemSetLasherweaveBattlegear, 4)
+	instantRage := 5 * float64(druid.Talents.ImprovedEnrage)
+	armorMultiplier := 0.84
 
 	druid.EnrageAura = druid.RegisterAura(core.Aura{
 		Label:    "Enrage Aura",
 		ActionID: actionID,
 		Duration: 10 * time.Second,
 		OnGain: func(aura *core.Aura, sim *core.Simulation) {
-			druid.PseudoStats.DamageDealtMultiplier *= 1.0 + dmgBonus
-			if !t10_4p {
-				druid.ApplyDynamicEquipScaling(sim, stats.Armor, 0.84)
-			} else {
-				druid.PseudoStats.DamageTakenMultiplier *= 0.88
-			}
+			druid.ApplyDynamicEquipScaling(sim, stats.Armor, armorMultiplier)
 		},
 		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
-			druid.PseudoStats.DamageDealtMultiplier /= 1.0 + dmgBonus
-			if !t10_4p {
-				druid.RemoveDynamicEquipScaling(sim, stats.Armor, 0.84)
-			} else {
-				druid.PseudoStats.DamageTakenMultiplier /= 0.88
-			}
+			druid.RemoveDynamicEquipScaling(sim, stats.Armor, armorMultiplier)
 		},
 	})
 
@@ -52,14 +41,16 @@ func (druid *Druid) registerEnrageSpell() {
 		},
 
 		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
-			druid.AddRage(sim, instantRage, rageMetrics)
+			if instantRage > 0 {
+				druid.AddRage(sim, instantRage, rageMetrics)
+			}
 
 			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
 				NumTicks: 10,
 				Period:   time.Second * 1,
 				OnAction: func(sim *core.Simulation) {
 					if druid.EnrageAura.IsActive() {
-						druid.AddRage(sim, 1, rageMetrics)
+						druid.AddRage(sim, 2, rageMetrics)
 					}
 				},
 			})
@@ -67,9 +58,4 @@ func (druid *Druid) registerEnrageSpell() {
 			druid.EnrageAura.Activate(sim)
 		},
 	})
-
-	druid.AddMajorCooldown(core.MajorCooldown{
-		Spell: druid.Enrage.Spell,
-		Type:  core.CooldownTypeDPS,
-	})
 }
@@ -6,51 +6,49 @@ import (
 	"github.com/isfir/wowsims-turtle/sim/core"
 )
 
+// https://www.wowhead.com/classic/spell=22896/frenzied-regeneration
+// Converts up to 10 rage per second into health for 10 sec. Each point of rage is converted into 20 health.
 func (druid *Druid) registerFrenziedRegenerationCD() {
-	actionID := core.ActionID{SpellID: 22842}
+	actionID := core.ActionID{SpellID: 22896}
 	healthMetrics := druid.NewHealthMetrics(actionID)
 	rageMetrics := druid.NewRageMetrics(actionID)
 
-	cdTimer := druid.NewTimer()
-	cd := time.Minute * 3
-	healingMulti := 1.0
+	healthPerRage := 20.0
 
 	druid.FrenziedRegenerationAura = druid.RegisterAura(core.Aura{
 		Label:    "Frenzied Regeneration",
 		ActionID: actionID,
 		Duration: time.Second * 10,
-		OnGain: func(aura *core.Aura, sim *core.Simulation) {
-			druid.PseudoStats.HealingTakenMultiplier *= healingMulti
-		},
-
-		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
-			druid.PseudoStats.HealingTakenMultiplier /= healingMulti
-		},
 	})
 
 	druid.FrenziedRegeneration = druid.RegisterSpell(Bear, core.SpellConfig{
 		ActionID: actionID,
+		Flags:    core.SpellFlagAPL,
+
 		Cast: core.CastConfig{
 			DefaultCast: core.Cast{
 				GCD: core.GCDDefault,
 			},
 			CD: core.Cooldown{
-				Timer:    cdTimer,
-				Duration: cd,
+				Timer:    druid.NewTimer(),
+				Duration: time.Minute * 3,
 			},
 			IgnoreHaste: true,
 		},
+
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
 				NumTicks: 10,
 				Period:   time.Second * 1,
 				OnAction: func(sim *core.Simulation) {
-					rageDumped := min(druid.CurrentRage(), 10.0)
-					healthGained := rageDumped * 0.3 / 100 * druid.MaxHealth() * druid.PseudoStats.HealingTakenMultiplier
+					if !druid.FrenziedRegenerationAura.IsActive() {
+						return
+					}
 
-					if druid.FrenziedRegenerationAura.IsActive() {
+					rageDumped := min(druid.CurrentRage(), 10.0)
+					if rageDumped > 0 {
 						druid.SpendRage(sim, rageDumped, rageMetrics)
-						druid.GainHealth(sim, healthGained, healthMetrics)
+						druid.GainHealth(sim, rageDumped*healthPerRage*druid.PseudoStats.HealingTakenMultiplier, healthMetrics)
 					}
 				},
 			})
@@ -62,5 +60,8 @@ func (druid *Druid) registerFrenziedRegenerationCD() {
 	druid.AddMajorCooldown(core.MajorCooldown{
 		Spell: druid.FrenziedRegeneration.Spell,
 		Type:  core.CooldownTypeSurvival,
+		ShouldActivate: func(sim *core.Simulation, character *core.Character) bool {
+			return druid.CurrentHealthPercent() < 0.5
+		},
 	})
 }
@@ -61,13 +61,19 @@ func init() {
 			Label:    "Metamorphosis Rune",
 			Duration: duration,
 			OnGain: func(aura *core.Aura, sim *core.Simulation) {
-				druid.CatForm.Cost.Multiplier -= 100
-				//druid.BearForm.Cost.Multiplier -= 100
+				for _, form := range []*DruidSpell{druid.CatForm, druid.BearForm} {
+					if form != nil {
+						form.Cost.Multiplier -= 100
+					}
+				}
 				//druid.MoonkinForm.Cost.Multiplier -= 100
 			},
 			OnExpire: func(aura *core.Aura, sim *core.Simulation) {
-				druid.CatForm.Cost.Multiplier += 100
-				//druid.BearForm.Cost.Multiplier += 100
+				for _, form := range []*DruidSpell{druid.CatForm, druid.BearForm} {
+					if form != nil {
+						form.Cost.Multiplier += 100
+					}
+				}
 				//druid.MoonkinForm.Cost.Multiplier += 100
 			},
 		})
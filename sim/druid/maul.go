@@ -4,8 +4,9 @@ import (
 	"github.com/isfir/wowsims-turtle/sim/core"
 )
 
+// https://www.wowhead.com/classic/spell=9881/maul
 func (druid *Druid) registerMaulSpell() {
-	flatBaseDamage := 578.0
+	flatBaseDamage := 128.0
 	rageCost := 15 - float64(druid.Talents.Ferocity)
 
 	switch druid.Ranged().ID {
@@ -14,20 +15,22 @@ func (druid *Druid) registerMaulSpell() {
 	}
 
 	druid.Maul = druid.RegisterSpell(Bear, core.SpellConfig{
-		ActionID:    core.ActionID{SpellID: 48480},
+		SpellCode:   SpellCode_DruidMaul,
+		ActionID:    core.ActionID{SpellID: 9881},
 		SpellSchool: core.SpellSchoolPhysical,
 		DefenseType: core.DefenseTypeMelee,
 		ProcMask:    core.ProcMaskMeleeMHSpecial | core.ProcMaskMeleeMHAuto,
-		Flags:       SpellFlagOmen | core.SpellFlagMeleeMetrics | core.SpellFlagIncludeTargetBonusDamage | core.SpellFlagNoOnCastComplete,
+		Flags:       SpellFlagOmen | core.SpellFlagMeleeMetrics | core.SpellFlagNoOnCastComplete,
 
 		RageCost: core.RageCostOptions{
 			Cost:   rageCost,
 			Refund: 0.8,
 		},
 
-		DamageMultiplier: 1 + 0.1*float64(druid.Talents.SavageFury),
-		ThreatMultiplier: 1,
-		FlatThreatBonus:  424,
+		DamageMultiplierAdditive: 1 + 0.1*float64(druid.Talents.SavageFury),
+		DamageMultiplier:         1,
+		ThreatMultiplier:         1.75,
+		BonusCoefficient:         1,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			// Need to specially deactivate CC here in case maul is cast simultaneously with another spell.
@@ -35,20 +38,8 @@ func (druid *Druid) registerMaulSpell() {
 				druid.ClearcastingAura.Deactivate(sim)
 			}
 
-			modifier := 1.0
-			if druid.BleedCategories.Get(target).AnyActive() {
-				modifier += .3
-			}
-			if druid.AssumeBleedActive || druid.Rip.Dot(target).IsActive() || druid.Rake.Dot(target).IsActive() || druid.Lacerate.Dot(target).IsActive() {
-				modifier *= 1.0 + (0.04 * float64(druid.Talents.RendAndTear))
-			}
-
-			baseDamage := flatBaseDamage +
-				spell.Unit.MHWeaponDamage(sim, spell.MeleeAttackPower()) +
-				spell.BonusWeaponDamage()
-			baseDamage *= modifier
-
-			result := spell.CalcAndDealDamage(sim, curTarget, baseDamage, spell.OutcomeMeleeSpecialHitAndCrit)
+			baseDamage := flatBaseDamage + spell.Unit.MHWeaponDamage(sim, spell.MeleeAttackPower(target))
+			result := spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMeleeWeaponSpecialHitAndCrit)
 
 			if !result.Landed() {
 				spell.IssueRefund(sim)
@@ -60,19 +51,17 @@ func (druid *Druid) registerMaulSpell() {
 
 	druid.MaulQueueAura = druid.RegisterAura(core.Aura{
 		Label:    "Maul Queue Aura",
-		ActionID: druid.Maul.ActionID,
+		ActionID: druid.Maul.ActionID.WithTag(1),
 		Duration: core.NeverExpires,
 	})
 
 	druid.MaulQueueSpell = druid.RegisterSpell(Bear, core.SpellConfig{
-		ActionID:    druid.Maul.WithTag(1),
-		SpellSchool: core.SpellSchoolPhysical,
-		ProcMask:    core.ProcMaskMeleeMHSpecial,
-		Flags:       core.SpellFlagMeleeMetrics | core.SpellFlagAPL,
+		ActionID: druid.Maul.ActionID.WithTag(1),
+		Flags:    core.SpellFlagMeleeMetrics | core.SpellFlagAPL | core.SpellFlagCastTimeNoGCD,
 
 		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
 			return !druid.MaulQueueAura.IsActive() &&
-				druid.CurrentRage() >= druid.Maul.Cost.GetCurrentCost() &&
+				druid.CurrentRage() >= druid.Maul.DefaultCast.Cost &&
 				!druid.IsCasting(sim)
 		},
 
@@ -82,13 +71,7 @@ func (druid *Druid) registerMaulSpell() {
 	})
 }
 
-func (druid *Druid) QueueMaul(sim *core.Simulation) {
-	if druid.MaulQueueSpell.CanCast(sim, druid.CurrentTarget) {
-		druid.MaulQueueSpell.Cast(sim, druid.CurrentTarget)
-	}
-}
-
-// Returns true if the regular melee swing should be used, false otherwise.
+// Returns the Maul spell if it is queued and castable, otherwise the regular melee swing.
 func (druid *Druid) MaulReplaceMH(sim *core.Simulation, mhSwingSpell *core.Spell) *core.Spell {
 	if !druid.MaulQueueAura.IsActive() {
 		return mhSwingSpell
@@ -0,0 +1,84 @@
+package druid
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core"
+)
+
+const RejuvenationRanks = 11
+
+var RejuvenationSpellId = [RejuvenationRanks + 1]int32{0, 774, 1058, 1430, 2090, 2091, 3627, 8910, 9839, 9840, 9841, 25299}
+var RejuvenationBaseHealing = [RejuvenationRanks + 1]float64{0, 32, 56, 116, 180, 244, 304, 388, 488, 608, 756, 888}
+var RejuvenationSpellCoeff = [RejuvenationRanks + 1]float64{0, 0.32, 0.5, 0.68, 0.8, 0.8, 0.8, 0.8, 0.8, 0.8, 0.8, 0.8}
+var RejuvenationManaCost = [RejuvenationRanks + 1]float64{0, 25, 40, 75, 105, 135, 160, 195, 235, 280, 335, 360}
+var RejuvenationLevel = [RejuvenationRanks + 1]int{0, 4, 10, 16, 22, 28, 34, 40, 46, 52, 58, 60}
+
+func (druid *Druid) registerRejuvenationSpell() {
+	druid.Rejuvenation = make([]*DruidSpell, RejuvenationRanks+1)
+
+	for rank := 1; rank <= RejuvenationRanks; rank++ {
+		config := druid.newRejuvenationSpellConfig(rank)
+
+		if config.RequiredLevel <= int(druid.Level) {
+			druid.Rejuvenation[rank] = druid.RegisterSpell(Humanoid, config)
+		}
+	}
+}
+
+func (druid *Druid) newRejuvenationSpellConfig(rank int) core.SpellConfig {
+	ticks := int32(4)
+	tickLength := time.Second * 3
+
+	spellId := RejuvenationSpellId[rank]
+	baseTickHealing := RejuvenationBaseHealing[rank] / float64(ticks)
+	spellCoeff := RejuvenationSpellCoeff[rank] / float64(ticks)
+	manaCost := RejuvenationManaCost[rank]
+	level := RejuvenationLevel[rank]
+
+	return core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: spellId},
+		SpellCode:   SpellCode_DruidRejuvenation,
+		SpellSchool: core.SpellSchoolNature,
+		DefenseType: core.DefenseTypeMagic,
+		ProcMask:    core.ProcMaskSpellHealing,
+		Flags:       SpellFlagOmen | core.SpellFlagHelpful | core.SpellFlagAPL,
+
+		RequiredLevel: level,
+		Rank:          rank,
+
+		ManaCost: core.ManaCostOptions{
+			FlatCost: manaCost,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+
+		DamageMultiplierAdditive: 1 + druid.giftOfNatureBonus() + 0.05*float64(druid.Talents.ImprovedRejuvenation),
+		DamageMultiplier:         1,
+		ThreatMultiplier:         1,
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label:    fmt.Sprintf("Rejuvenation (Rank %d)", rank),
+				ActionID: core.ActionID{SpellID: spellId},
+			},
+			NumberOfTicks:    ticks,
+			TickLength:       tickLength,
+			BonusCoefficient: spellCoeff,
+			OnSnapshot: func(sim *core.Simulation, target *core.Unit, dot *core.Dot, isRollover bool) {
+				dot.SnapshotHeal(target, baseTickHealing, isRollover)
+			},
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeTick)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.Hot(target).Apply(sim)
+		},
+	}
+}
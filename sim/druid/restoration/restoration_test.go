@@ -0,0 +1,76 @@
+package restoration
+
+import (
+	"testing"
+
+	_ "github.com/isfir/wowsims-turtle/sim/common"
+	"github.com/isfir/wowsims-turtle/sim/core"
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+)
+
+func init() {
+	RegisterRestorationDruid()
+}
+
+func TestRestoration(t *testing.T) {
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
+		{
+			Class:      proto.Class_ClassDruid,
+			Phase:      1,
+			Race:       proto.Race_RaceTauren,
+			OtherRaces: []proto.Race{proto.Race_RaceNightElf},
+
+			Talents:     StandardTalents,
+			GearSet:     core.GetGearSet("../../../ui/restoration_druid/gear_sets", "blank"),
+			Rotation:    core.GetAplRotation("../../../ui/restoration_druid/apls", "default"),
+			Buffs:       core.FullBuffs,
+			Consumes:    FullConsumes,
+			SpecOptions: core.SpecOptionsCombo{Label: "Default", SpecOptions: PlayerOptionsDefault},
+
+			IsHealer: true,
+
+			ItemFilter:      ItemFilters,
+			EPReferenceStat: proto.Stat_StatHealingPower,
+			StatsToWeigh:    Stats,
+		},
+	}))
+}
+
+var StandardTalents = "50000500001--055103105315051"
+
+var PlayerOptionsDefault = &proto.Player_RestorationDruid{
+	RestorationDruid: &proto.RestorationDruid{
+		Options: &proto.RestorationDruid_Options{
+			InnervateTarget: &proto.UnitReference{Type: proto.UnitReference_Player, Index: 0}, // self innervate
+		},
+	},
+}
+
+var FullConsumes = core.ConsumesCombo{
+	Label: "Healer-Consumes",
+	Consumes: &proto.Consumes{
+		DefaultPotion: proto.Potions_MajorManaPotion,
+		Flask:         proto.Flask_FlaskOfDistilledWisdom,
+	},
+}
+
+var ItemFilters = core.ItemFilter{
+	WeaponTypes: []proto.WeaponType{
+		proto.WeaponType_WeaponTypeDagger,
+		proto.WeaponType_WeaponTypeMace,
+		proto.WeaponType_WeaponTypeOffHand,
+		proto.WeaponType_WeaponTypeStaff,
+	},
+	ArmorType: proto.ArmorType_ArmorTypeLeather,
+	RangedWeaponTypes: []proto.RangedWeaponType{
+		proto.RangedWeaponType_RangedWeaponTypeIdol,
+	},
+}
+
+var Stats = []proto.Stat{
+	proto.Stat_StatIntellect,
+	proto.Stat_StatSpirit,
+	proto.Stat_StatHealingPower,
+	proto.Stat_StatSpellCrit,
+	proto.Stat_StatMP5,
+}
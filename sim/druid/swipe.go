@@ -19,7 +19,7 @@ func (druid *Druid) registerSwipeBearSpell() {
 		25: 2,
 		40: 3,
 		50: 4,
-		60: 6,
+		60: 5,
 	}[druid.Level]
 
 	level := SwipeLevel[rank]
@@ -36,6 +36,7 @@ func (druid *Druid) registerSwipeBearSpell() {
 	}
 
 	druid.SwipeBear = druid.RegisterSpell(Bear, core.SpellConfig{
+		SpellCode:   SpellCode_DruidSwipeBear,
 		ActionID:    core.ActionID{SpellID: spellID},
 		SpellSchool: core.SpellSchoolPhysical,
 		DefenseType: core.DefenseTypeMelee,
@@ -46,7 +47,7 @@ func (druid *Druid) registerSwipeBearSpell() {
 		RequiredLevel: level,
 
 		RageCost: core.RageCostOptions{
-			Cost: 20 - float64(druid.Talents.Ferocity),
+			Cost: rageCost,
 		},
 
 		Cast: core.CastConfig{
@@ -60,12 +61,13 @@ func (druid *Druid) registerSwipeBearSpell() {
 		ThreatMultiplier: SwipeThreatMultiplier,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			for idx := range results {
+			numTargets := min(len(results), int(sim.GetNumActiveTargets()))
+			for idx := range results[:numTargets] {
 				results[idx] = spell.CalcDamage(sim, target, baseDamage, spell.OutcomeMeleeSpecialHitAndCrit)
 				target = sim.Environment.NextTargetUnit(target)
 			}
 
-			for _, result := range results {
+			for _, result := range results[:numTargets] {
 				spell.DealDamage(sim, result)
 			}
 		},
@@ -4,9 +4,71 @@ import (
 	"time"
 
 	"github.com/isfir/wowsims-turtle/sim/core"
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
 	"github.com/isfir/wowsims-turtle/sim/core/stats"
 )
 
+// Layout of the talent trees, in the same order as the talent string.
+var TalentTrees = core.TalentTrees{
+	{ // Balance
+		{Name: "improvedWrath", Row: 0, MaxPoints: 5},
+		{Name: "naturesGrasp", Row: 0, MaxPoints: 1},
+		{Name: "improvedNaturesGrasp", Row: 0, MaxPoints: 4, Prereq: "naturesGrasp"},
+		{Name: "improvedEntanglingRoots", Row: 1, MaxPoints: 3},
+		{Name: "improvedMoonfire", Row: 1, MaxPoints: 5},
+		{Name: "naturalWeapons", Row: 1, MaxPoints: 5},
+		{Name: "naturalShapeshifter", Row: 1, MaxPoints: 3},
+		{Name: "improvedThorns", Row: 2, MaxPoints: 3},
+		{Name: "omenOfClarity", Row: 2, MaxPoints: 1, Prereq: "naturalWeapons"},
+		{Name: "naturesReach", Row: 2, MaxPoints: 2},
+		{Name: "vengeance", Row: 3, MaxPoints: 5, Prereq: "improvedMoonfire"},
+		{Name: "improvedStarfire", Row: 3, MaxPoints: 5},
+		{Name: "naturesGrace", Row: 4, MaxPoints: 1},
+		{Name: "moonglow", Row: 4, MaxPoints: 3},
+		{Name: "moonfury", Row: 5, MaxPoints: 5, Prereq: "naturesGrace"},
+		{Name: "moonkinForm", Row: 6, MaxPoints: 1},
+	},
+	{ // Feral Combat
+		{Name: "ferocity", Row: 0, MaxPoints: 5},
+		{Name: "feralAggression", Row: 0, MaxPoints: 5},
+		{Name: "feralInstinct", Row: 1, MaxPoints: 5},
+		{Name: "brutalImpact", Row: 1, MaxPoints: 2},
+		{Name: "thickHide", Row: 1, MaxPoints: 5},
+		{Name: "felineSwiftness", Row: 2, MaxPoints: 2},
+		{Name: "feralCharge", Row: 2, MaxPoints: 1},
+		{Name: "sharpenedClaws", Row: 2, MaxPoints: 3},
+		{Name: "improvedShred", Row: 3, MaxPoints: 2},
+		{Name: "predatoryStrikes", Row: 3, MaxPoints: 3},
+		{Name: "bloodFrenzy", Row: 3, MaxPoints: 2, Prereq: "sharpenedClaws"},
+		{Name: "primalFury", Row: 3, MaxPoints: 2},
+		{Name: "savageFury", Row: 4, MaxPoints: 2},
+		{Name: "faerieFireFeral", Row: 4, MaxPoints: 1},
+		{Name: "heartOfTheWild", Row: 5, MaxPoints: 5, Prereq: "predatoryStrikes"},
+		{Name: "leaderOfThePack", Row: 6, MaxPoints: 1},
+	},
+	{ // Restoration
+		{Name: "improvedMarkOfTheWild", Row: 0, MaxPoints: 5},
+		{Name: "furor", Row: 0, MaxPoints: 5},
+		{Name: "improvedHealingTouch", Row: 1, MaxPoints: 5},
+		{Name: "naturesFocus", Row: 1, MaxPoints: 5},
+		{Name: "improvedEnrage", Row: 1, MaxPoints: 2},
+		{Name: "reflection", Row: 2, MaxPoints: 3},
+		{Name: "insectSwarm", Row: 2, MaxPoints: 1},
+		{Name: "subtlety", Row: 2, MaxPoints: 5},
+		{Name: "tranquilSpirit", Row: 3, MaxPoints: 5},
+		{Name: "improvedRejuvenation", Row: 3, MaxPoints: 3},
+		{Name: "naturesSwiftness", Row: 4, MaxPoints: 1, Prereq: "improvedHealingTouch"},
+		{Name: "giftOfNature", Row: 4, MaxPoints: 5, Prereq: "insectSwarm"},
+		{Name: "improvedTranquility", Row: 4, MaxPoints: 2},
+		{Name: "improvedRegrowth", Row: 5, MaxPoints: 5},
+		{Name: "swiftmend", Row: 6, MaxPoints: 1, Prereq: "tranquilSpirit"},
+	},
+}
+
+func init() {
+	core.RegisterTalentTrees(proto.Class_ClassDruid, TalentTrees)
+}
+
 func (druid *Druid) ApplyTalents() {
 	// Balance
 	druid.registerMoonkinFormSpell()
@@ -21,6 +83,7 @@ func (druid *Druid) ApplyTalents() {
 
 	// Feral
 	druid.applyBloodFrenzy()
+	druid.applyPrimalFury()
 
 	druid.ApplyEquipScaling(stats.Armor, druid.ThickHideMultiplier())
 
@@ -35,6 +98,11 @@ func (druid *Druid) ApplyTalents() {
 	druid.PseudoStats.SpiritRegenRateCasting += .05 * float64(druid.Talents.Reflection)
 }
 
+// Gift of Nature increases the effect of all healing spells by 2% per point.
+func (druid *Druid) giftOfNatureBonus() float64 {
+	return 0.02 * float64(druid.Talents.GiftOfNature)
+}
+
 func (druid *Druid) ThickHideMultiplier() float64 {
 	thickHideMulti := 1.0
 
@@ -45,9 +113,9 @@ func (druid *Druid) ThickHideMultiplier() float64 {
 	return thickHideMulti
 }
 
+// Dire Bear Form increases armor contribution from items by 360%.
 func (druid *Druid) BearArmorMultiplier() float64 {
-	sotfMulti := 1.0 + 0.33/3.0
-	return 4.7 * sotfMulti
+	return 4.6
 }
 
 func (druid *Druid) applyNaturesGrace() {
@@ -172,42 +240,27 @@ func (druid *Druid) applyNaturesGrace() {
 // 	})
 // }
 
-// TODO: Classic bear
-// func (druid *Druid) applyPrimalFury() {
-// 	if druid.Talents.PrimalFury == 0 {
-// 		return
-// 	}
+func (druid *Druid) applyPrimalFury() {
+	if druid.Talents.PrimalFury == 0 {
+		return
+	}
 
-// 	procChance := []float64{0, 0.5, 1}[druid.Talents.PrimalFury]
-// 	actionID := core.ActionID{SpellID: 37117}
-// 	rageMetrics := druid.NewRageMetrics(actionID)
-// 	cpMetrics := druid.NewComboPointMetrics(actionID)
+	procChance := []float64{0, 0.5, 1}[druid.Talents.PrimalFury]
+	actionID := core.ActionID{SpellID: 16959}
+	rageMetrics := druid.NewRageMetrics(actionID)
 
-// 	druid.RegisterAura(core.Aura{
-// 		Label:    "Primal Fury",
-// 		Duration: core.NeverExpires,
-// 		OnReset: func(aura *core.Aura, sim *core.Simulation) {
-// 			aura.Activate(sim)
-// 		},
-// 		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
-// 			if druid.InForm(Bear) {
-// 				if result.Outcome.Matches(core.OutcomeCrit) {
-// 					if sim.Proc(procChance, "Primal Fury") {
-// 						druid.AddRage(sim, 5, rageMetrics)
-// 					}
-// 				}
-// 			} else if druid.InForm(Cat) {
-// 				if druid.IsMangle(spell) || druid.Shred.IsEqual(spell) || druid.Rake.IsEqual(spell) {
-// 					if result.Outcome.Matches(core.OutcomeCrit) {
-// 						if sim.Proc(procChance, "Primal Fury") {
-// 							druid.AddComboPoints(sim, 1, cpMetrics)
-// 						}
-// 					}
-// 				}
-// 			}
-// 		},
-// 	})
-// }
+	core.MakePermanent(druid.RegisterAura(core.Aura{
+		Label: "Primal Fury",
+		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if druid.InForm(Bear) &&
+				spell.ProcMask.Matches(core.ProcMaskMelee) &&
+				result.Outcome.Matches(core.OutcomeCrit) &&
+				sim.Proc(procChance, "Primal Fury") {
+				druid.AddRage(sim, 5, rageMetrics)
+			}
+		},
+	}))
+}
 
 func (druid *Druid) applyBloodFrenzy() {
 	if druid.Talents.BloodFrenzy == 0 {
@@ -414,6 +467,9 @@ func (druid *Druid) applyMoonglow() {
 						druid.Wrath,
 						druid.Starfire,
 						druid.Moonfire,
+						druid.HealingTouch,
+						druid.Regrowth,
+						druid.Rejuvenation,
 					},
 				),
 				func(spell *DruidSpell) bool { return spell != nil },
@@ -0,0 +1,85 @@
+package tank
+
+import (
+	"testing"
+
+	_ "github.com/isfir/wowsims-turtle/sim/common"
+	"github.com/isfir/wowsims-turtle/sim/core"
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+)
+
+func init() {
+	RegisterFeralTankDruid()
+}
+
+func TestFeralTank(t *testing.T) {
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
+		{
+			Class:      proto.Class_ClassDruid,
+			Phase:      1,
+			Race:       proto.Race_RaceTauren,
+			OtherRaces: []proto.Race{proto.Race_RaceNightElf},
+
+			Talents:     StandardTalents,
+			GearSet:     core.GetGearSet("../../../ui/feral_tank_druid/gear_sets", "blank"),
+			Rotation:    core.GetAplRotation("../../../ui/feral_tank_druid/apls", "default"),
+			Buffs:       core.FullBuffs,
+			Consumes:    FullConsumes,
+			SpecOptions: core.SpecOptionsCombo{Label: "Default", SpecOptions: PlayerOptionsDefault},
+
+			IsTank:          true,
+			InFrontOfTarget: true,
+
+			ItemFilter:      ItemFilters,
+			EPReferenceStat: proto.Stat_StatAttackPower,
+			StatsToWeigh:    Stats,
+		},
+	}))
+}
+
+var StandardTalents = "-5050501303222151-05002"
+
+var PlayerOptionsDefault = &proto.Player_FeralTankDruid{
+	FeralTankDruid: &proto.FeralTankDruid{
+		Options: &proto.FeralTankDruid_Options{
+			InnervateTarget: &proto.UnitReference{}, // no Innervate
+			StartingRage:    20,
+		},
+	},
+}
+
+var FullConsumes = core.ConsumesCombo{
+	Label: "Tank-Consumes",
+	Consumes: &proto.Consumes{
+		AgilityElixir:   proto.AgilityElixir_ElixirOfTheMongoose,
+		AttackPowerBuff: proto.AttackPowerBuff_JujuMight,
+		DefaultPotion:   proto.Potions_MajorManaPotion,
+		Flask:           proto.Flask_FlaskOfTheTitans,
+		Food:            proto.Food_FoodSmokedDesertDumpling,
+		StrengthBuff:    proto.StrengthBuff_JujuPower,
+	},
+}
+
+var ItemFilters = core.ItemFilter{
+	WeaponTypes: []proto.WeaponType{
+		proto.WeaponType_WeaponTypeDagger,
+		proto.WeaponType_WeaponTypeMace,
+		proto.WeaponType_WeaponTypeOffHand,
+		proto.WeaponType_WeaponTypeStaff,
+		proto.WeaponType_WeaponTypePolearm,
+	},
+	ArmorType: proto.ArmorType_ArmorTypeLeather,
+	RangedWeaponTypes: []proto.RangedWeaponType{
+		proto.RangedWeaponType_RangedWeaponTypeIdol,
+	},
+}
+
+var Stats = []proto.Stat{
+	proto.Stat_StatStrength,
+	proto.Stat_StatAgility,
+	proto.Stat_StatStamina,
+	proto.Stat_StatAttackPower,
+	proto.Stat_StatArmor,
+	proto.Stat_StatDodge,
+	proto.Stat_StatDefense,
+}
@@ -0,0 +1,403 @@
+package encounters
+
+import (
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core"
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+	"github.com/isfir/wowsims-turtle/sim/core/stats"
+)
+
+func addCThun(bossPrefix string) {
+	AddSingleTargetBossEncounter(&core.PresetTarget{
+		PathPrefix: bossPrefix,
+		Config: &proto.Target{
+			Id:        15727,
+			Name:      "Ahn'Qiraj C'Thun",
+			Level:     63,
+			MobType:   proto.MobType_MobTypeUnknown,
+			TankIndex: -1,
+
+			Stats: stats.Stats{
+				stats.Health:      2_000_000, // TODO:
+				stats.Armor:       3731,      // TODO:
+				stats.AttackPower: 805,       // TODO:
+				// TODO: Resistances
+			}.ToFloatArray(),
+
+			// C'Thun does not melee.
+			SpellSchool:      proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:       0,
+			DamageSpread:     0.3333,
+			ParryHaste:       false,
+			DualWield:        false,
+			DualWieldPenalty: false,
+			TargetInputs:     make([]*proto.TargetInput, 0),
+		},
+		AI: NewCThunAI(),
+	})
+}
+
+type CThunAI struct {
+	Target *core.Target
+
+	eyeBeamSpell *core.Spell
+}
+
+func NewCThunAI() core.AIFactory {
+	return func() core.TargetAI {
+		return &CThunAI{}
+	}
+}
+
+func (ai *CThunAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+
+	ai.eyeBeamSpell = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 26134},
+		SpellSchool: core.SpellSchoolNature,
+		DefenseType: core.DefenseTypeMagic,
+		ProcMask:    core.ProcMaskSpellDamage,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Second * 3, // TODO:
+			},
+		},
+
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseDamage := sim.Roll(2188, 2812) // TODO:
+			spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMagicHit)
+		},
+	})
+}
+
+func (ai *CThunAI) Reset(*core.Simulation) {
+}
+
+func (ai *CThunAI) ExecuteCustomRotation(sim *core.Simulation) {
+	if ai.eyeBeamSpell.IsReady(sim) {
+		ai.eyeBeamSpell.Cast(sim, randomRaidPlayer(sim, ai.Target))
+	}
+
+	ai.Target.WaitUntil(sim, sim.CurrentTime+BossGCD)
+}
+
+// Vek'lor is immune to physical damage and Vek'nilash is immune to magic damage.
+func addTwinEmperors(bossPrefix string) {
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: bossPrefix,
+		Config: &proto.Target{
+			Id:        15276,
+			Name:      "Ahn'Qiraj Emperor Vek'lor",
+			Level:     63,
+			MobType:   proto.MobType_MobTypeHumanoid,
+			TankIndex: 1,
+
+			Stats: stats.Stats{
+				stats.Health:      1_004_700, // TODO:
+				stats.Armor:       3731,      // TODO:
+				stats.AttackPower: 805,       // TODO:
+				// TODO: Resistances
+			}.ToFloatArray(),
+
+			// Vek'lor is a caster and does not melee.
+			SpellSchool:      proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:       0,
+			DamageSpread:     0.3333,
+			ParryHaste:       false,
+			DualWield:        false,
+			DualWieldPenalty: false,
+			TargetInputs:     make([]*proto.TargetInput, 0),
+		},
+		AI: NewVeklorAI(),
+	})
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: bossPrefix,
+		Config: &proto.Target{
+			Id:        15275,
+			Name:      "Ahn'Qiraj Emperor Vek'nilash",
+			Level:     63,
+			MobType:   proto.MobType_MobTypeHumanoid,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      1_004_700, // TODO:
+				stats.Armor:       3731,      // TODO:
+				stats.AttackPower: 805,       // TODO:
+				// TODO: Resistances
+			}.ToFloatArray(),
+
+			SpellSchool:      proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:       2,
+			MinBaseDamage:    3500, // TODO:
+			DamageSpread:     0.3333,
+			ParryHaste:       true,
+			DualWield:        false,
+			DualWieldPenalty: false,
+			TargetInputs:     make([]*proto.TargetInput, 0),
+		},
+		AI: NewVeknilashAI(),
+	})
+	core.AddPresetEncounter("Ahn'Qiraj Twin Emperors", []string{
+		bossPrefix + "/Ahn'Qiraj Emperor Vek'nilash",
+		bossPrefix + "/Ahn'Qiraj Emperor Vek'lor",
+	}, twinEmperorsTeleports()...)
+}
+
+// The emperors swap places every 30 sec, leaving the raid a few seconds of
+// downtime to pick the right twin back up.
+func twinEmperorsTeleports() []*proto.EncounterPhase {
+	var phases []*proto.EncounterPhase
+	for teleport := 30.0; teleport < 900; teleport += 30 {
+		phases = append(phases, &proto.EncounterPhase{
+			Name:      "Twin Teleport",
+			StartTime: teleport,
+			Downtime:  2, // TODO:
+		})
+	}
+	return phases
+}
+
+type VeklorAI struct {
+	Target *core.Target
+
+	shadowBoltSpell *core.Spell
+}
+
+func NewVeklorAI() core.AIFactory {
+	return func() core.TargetAI {
+		return &VeklorAI{}
+	}
+}
+
+func (ai *VeklorAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+	makeImmuneToSchools(target, stats.SchoolIndexPhysical)
+
+	ai.shadowBoltSpell = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 26006},
+		SpellSchool: core.SpellSchoolShadow,
+		DefenseType: core.DefenseTypeMagic,
+		ProcMask:    core.ProcMaskSpellDamage,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: BossGCD,
+			},
+		},
+
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseDamage := sim.Roll(1750, 2250) // TODO:
+			spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMagicHit)
+		},
+	})
+}
+
+func (ai *VeklorAI) Reset(*core.Simulation) {
+}
+
+func (ai *VeklorAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := tankOrFirstPlayer(ai.Target)
+
+	if ai.shadowBoltSpell.CanCast(sim, target) {
+		ai.shadowBoltSpell.Cast(sim, target)
+		return
+	}
+
+	ai.Target.WaitUntil(sim, sim.CurrentTime+BossGCD)
+}
+
+type VeknilashAI struct {
+	Target *core.Target
+
+	unbalancingStrikeSpell *core.Spell
+}
+
+func NewVeknilashAI() core.AIFactory {
+	return func() core.TargetAI {
+		return &VeknilashAI{}
+	}
+}
+
+func (ai *VeknilashAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+	makeImmuneToSchools(target,
+		stats.SchoolIndexArcane, stats.SchoolIndexFire, stats.SchoolIndexFrost,
+		stats.SchoolIndexHoly, stats.SchoolIndexNature, stats.SchoolIndexShadow)
+
+	// Unbalancing Strike also reduces the tank's defense, which is not modelled.
+	ai.unbalancingStrikeSpell = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 26613},
+		SpellSchool: core.SpellSchoolPhysical,
+		DefenseType: core.DefenseTypeMelee,
+		ProcMask:    core.ProcMaskMeleeMHSpecial,
+		Flags:       core.SpellFlagMeleeMetrics,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Second * 8, // TODO:
+			},
+		},
+
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseDamage := sim.Roll(5000, 6000) // TODO:
+			spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeEnemyMeleeWhite)
+		},
+	})
+}
+
+func (ai *VeknilashAI) Reset(*core.Simulation) {
+}
+
+func (ai *VeknilashAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := tankOrFirstPlayer(ai.Target)
+
+	if ai.unbalancingStrikeSpell.CanCast(sim, target) {
+		ai.unbalancingStrikeSpell.Cast(sim, target)
+	}
+
+	ai.Target.WaitUntil(sim, sim.CurrentTime+BossGCD)
+}
+
+func addPrincessHuhuran(bossPrefix string) {
+	AddSingleTargetBossEncounter(&core.PresetTarget{
+		PathPrefix: bossPrefix,
+		Config: &proto.Target{
+			Id:        15509,
+			Name:      "Ahn'Qiraj Princess Huhuran",
+			Level:     63,
+			MobType:   proto.MobType_MobTypeBeast,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:           1_998_200, // TODO:
+				stats.Armor:            3731,      // TODO:
+				stats.AttackPower:      805,       // TODO:
+				stats.NatureResistance: 100,       // TODO:
+			}.ToFloatArray(),
+
+			SpellSchool:      proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:       2,
+			MinBaseDamage:    3000, // TODO:
+			DamageSpread:     0.3333,
+			ParryHaste:       true,
+			DualWield:        false,
+			DualWieldPenalty: false,
+			TargetInputs:     make([]*proto.TargetInput, 0),
+		},
+		AI: NewPrincessHuhuranAI(),
+	})
+}
+
+// Huhuran stacks Acid Spit on her tank and goes Berserk at 30% health.
+type PrincessHuhuranAI struct {
+	Target *core.Target
+
+	acidSpitSpell *core.Spell
+	berserkSpell  *core.Spell
+}
+
+func NewPrincessHuhuranAI() core.AIFactory {
+	return func() core.TargetAI {
+		return &PrincessHuhuranAI{}
+	}
+}
+
+func (ai *PrincessHuhuranAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+	ai.registerSpells()
+}
+
+func (ai *PrincessHuhuranAI) registerSpells() {
+	ai.acidSpitSpell = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 26050},
+		SpellSchool: core.SpellSchoolNature,
+		DefenseType: core.DefenseTypeMagic,
+		ProcMask:    core.ProcMaskSpellDamage,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 10, // TODO:
+			},
+		},
+
+		DamageMultiplier: 1,
+
+		Dot: core.DotConfig{
+			Aura: core.Aura{
+				Label:     "Acid Spit",
+				MaxStacks: 10,
+			},
+			NumberOfTicks: 10,
+			TickLength:    time.Second * 3,
+
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.Spell.CalcAndDealPeriodicDamage(sim, target, 150*float64(dot.GetStacks()), dot.OutcomeTick) // TODO:
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			result := spell.CalcOutcome(sim, target, spell.OutcomeMagicHit)
+			if result.Landed() {
+				// Reapplying must keep the stacks and the tick timer running.
+				dot := spell.Dot(target)
+				dot.ApplyOrRefresh(sim)
+				if dot.GetStacks() < dot.MaxStacks {
+					dot.AddStack(sim)
+				}
+			}
+		},
+	})
+
+	berserkActionID := core.ActionID{SpellID: 26068}
+	berserkAura := ai.Target.RegisterAura(core.Aura{
+		Label:    "Berserk",
+		ActionID: berserkActionID,
+		Duration: core.NeverExpires,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Unit.MultiplyAttackSpeed(sim, 2.5)
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Unit.MultiplyAttackSpeed(sim, 1/2.5)
+		},
+	})
+
+	ai.berserkSpell = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID: berserkActionID,
+		Flags:    core.SpellFlagNoOnCastComplete,
+
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return !berserkAura.IsActive()
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			berserkAura.Activate(sim)
+		},
+	})
+}
+
+func (ai *PrincessHuhuranAI) Reset(*core.Simulation) {
+}
+
+func (ai *PrincessHuhuranAI) ExecuteCustomRotation(sim *core.Simulation) {
+	if sim.GetRemainingDurationPercent() <= 0.30 && ai.berserkSpell.CanCast(sim, &ai.Target.Unit) {
+		ai.berserkSpell.Cast(sim, &ai.Target.Unit)
+	}
+
+	target := tankOrFirstPlayer(ai.Target)
+	if ai.acidSpitSpell.CanCast(sim, target) {
+		ai.acidSpitSpell.Cast(sim, target)
+	}
+
+	ai.Target.WaitUntil(sim, sim.CurrentTime+BossGCD)
+}
@@ -0,0 +1,28 @@
+package encounters
+
+import (
+	"github.com/isfir/wowsims-turtle/sim/core"
+	"github.com/isfir/wowsims-turtle/sim/core/stats"
+)
+
+// Returns the unit tanking the boss, falling back to the first player of the raid
+// so that tank-targeted abilities still show up in individual non-tank sims.
+func tankOrFirstPlayer(target *core.Target) *core.Unit {
+	if target.CurrentTarget != nil {
+		return target.CurrentTarget
+	}
+	return target.Env.Raid.AllPlayerUnits[0]
+}
+
+// Returns a random player from the raid, used for abilities that pick raid members at random.
+func randomRaidPlayer(sim *core.Simulation, target *core.Target) *core.Unit {
+	players := target.Env.Raid.AllPlayerUnits
+	return players[int(sim.RandomFloat("Boss Target Selection")*float64(len(players)))]
+}
+
+// Makes a boss immune to the given spell schools.
+func makeImmuneToSchools(target *core.Target, schools ...stats.SchoolIndex) {
+	for _, school := range schools {
+		target.PseudoStats.SchoolDamageTakenMultiplier[school] = 0
+	}
+}
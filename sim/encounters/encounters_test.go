@@ -0,0 +1,148 @@
+package encounters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core"
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+	dpswarrior "github.com/isfir/wowsims-turtle/sim/warrior/dps_warrior"
+)
+
+const testIterations = 10
+
+func init() {
+	dpswarrior.RegisterDpsWarrior()
+}
+
+// Sims a lone warrior, which tanks the first target, against the given preset targets.
+func runBossSim(t *testing.T, duration time.Duration, bossNames ...string) *proto.RaidSimResult {
+	var targets []*proto.Target
+	for _, bossName := range bossNames {
+		preset := core.GetPresetTargetWithPath(unverifiedPrefix + "/" + bossName)
+		if preset == nil {
+			t.Fatalf("No preset target registered for %s", bossName)
+		}
+		targets = append(targets, preset.Config)
+	}
+
+	result := core.RunRaidSim(&proto.RaidSimRequest{
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{{Players: []*proto.Player{{
+				Name:      "Warrior",
+				Race:      proto.Race_RaceHuman,
+				Class:     proto.Class_ClassWarrior,
+				Equipment: &proto.EquipmentSpec{},
+				Consumes:  &proto.Consumes{},
+				Spec:      &proto.Player_Warrior{Warrior: &proto.Warrior{Options: &proto.Warrior_Options{}}},
+				Rotation:  &proto.APLRotation{},
+			}}}},
+			Buffs:   &proto.RaidBuffs{},
+			Debuffs: &proto.Debuffs{},
+			Tanks:   []*proto.UnitReference{{Type: proto.UnitReference_Player, Index: 0}},
+		},
+		Encounter: &proto.Encounter{
+			Duration: duration.Seconds(),
+			Targets:  targets,
+		},
+		SimOptions: &proto.SimOptions{Iterations: testIterations, RandomSeed: 101, IsTest: true},
+	})
+	if result.Error != nil {
+		t.Fatalf("Sim failed: %s", result.Error.Message)
+	}
+	return result
+}
+
+// Returns the total casts and damage of a spell across all iterations, for the given unit's metrics.
+func spellMetrics(unit *proto.UnitMetrics, spellID int32) (int32, float64) {
+	var casts int32
+	var damage float64
+	for _, action := range unit.Actions {
+		if action.Id.GetSpellId() == spellID {
+			for _, target := range action.Targets {
+				casts += target.Casts
+				damage += target.Damage
+			}
+		}
+	}
+	return casts, damage
+}
+
+func TestRagnaros(t *testing.T) {
+	result := runBossSim(t, time.Minute, "Molten Core Ragnaros")
+	ragnaros := result.EncounterMetrics.Targets[0]
+
+	// Wrath of Ragnaros at the pull and then every 25 sec.
+	if casts, _ := spellMetrics(ragnaros, 20566); casts != 3*testIterations {
+		t.Fatalf("Expected %d Wraths of Ragnaros, got %d", 3*testIterations, casts)
+	}
+	// Elemental Fire every 10 sec, delayed by Wrath of Ragnaros taking the boss GCD.
+	if casts, _ := spellMetrics(ragnaros, 20564); casts != 6*testIterations {
+		t.Fatalf("Expected %d Elemental Fires, got %d", 6*testIterations, casts)
+	}
+}
+
+func TestGolemaggMagmaSplash(t *testing.T) {
+	result := runBossSim(t, time.Minute, "Molten Core Golemagg the Incinerator")
+	casts, damage := spellMetrics(result.EncounterMetrics.Targets[0], 13880)
+	if casts == 0 {
+		t.Fatalf("Expected Golemagg's melee hits to apply Magma Splash")
+	}
+	// A single stack ticking all fight long deals 20 ticks of 50 damage, so
+	// anything above that means the stacks build up across hits.
+	if maxSingleStack := 20 * 50.0 * testIterations; damage <= maxSingleStack {
+		t.Fatalf("Expected Magma Splash to deal more than %.0f damage from stacking, got %.0f", maxSingleStack, damage)
+	}
+}
+
+func TestOnyxia(t *testing.T) {
+	result := runBossSim(t, time.Second*100, "Onyxia's Lair Onyxia")
+	onyxia := result.EncounterMetrics.Targets[0]
+
+	// Airborne from 35 to 60 sec, Flame Breath every 15 sec on the ground and
+	// Fireball every 3 sec in the air.
+	if casts, _ := spellMetrics(onyxia, 18435); casts != 6*testIterations {
+		t.Fatalf("Expected %d Flame Breaths, got %d", 6*testIterations, casts)
+	}
+	if casts, _ := spellMetrics(onyxia, 18392); casts != 8*testIterations {
+		t.Fatalf("Expected %d Fireballs, got %d", 8*testIterations, casts)
+	}
+}
+
+func TestCThunEyeBeam(t *testing.T) {
+	// C'Thun has no tank, so Eye Beam has to find the player on its own.
+	result := runBossSim(t, time.Minute, "Ahn'Qiraj C'Thun")
+	if casts, damage := spellMetrics(result.EncounterMetrics.Targets[0], 26134); casts != 19*testIterations || damage == 0 {
+		t.Fatalf("Expected %d Eye Beams dealing damage, got %d dealing %.0f", 19*testIterations, casts, damage)
+	}
+}
+
+func TestTwinEmperors(t *testing.T) {
+	result := runBossSim(t, time.Minute, "Ahn'Qiraj Emperor Vek'nilash", "Ahn'Qiraj Emperor Vek'lor")
+
+	if casts, _ := spellMetrics(result.EncounterMetrics.Targets[0], 26613); casts != 8*testIterations {
+		t.Fatalf("Expected %d Unbalancing Strikes, got %d", 8*testIterations, casts)
+	}
+	// Vek'lor casts Shadow Bolt back to back on the boss GCD.
+	if casts, _ := spellMetrics(result.EncounterMetrics.Targets[1], 26006); casts != 38*testIterations {
+		t.Fatalf("Expected %d Shadow Bolts, got %d", 38*testIterations, casts)
+	}
+}
+
+func TestPrincessHuhuran(t *testing.T) {
+	result := runBossSim(t, time.Minute, "Ahn'Qiraj Princess Huhuran")
+	huhuran := result.EncounterMetrics.Targets[0]
+
+	if casts, _ := spellMetrics(huhuran, 26068); casts != testIterations {
+		t.Fatalf("Expected one Berserk per iteration, got %d in total", casts)
+	}
+
+	casts, damage := spellMetrics(huhuran, 26050)
+	if casts != 6*testIterations {
+		t.Fatalf("Expected %d Acid Spits, got %d", 6*testIterations, casts)
+	}
+	// A single stack ticking all fight long deals 20 ticks of 150 damage.
+	if maxSingleStack := 20 * 150.0 * testIterations; damage <= maxSingleStack {
+		t.Fatalf("Expected Acid Spit to deal more than %.0f damage from stacking, got %.0f", maxSingleStack, damage)
+	}
+}
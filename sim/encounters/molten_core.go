@@ -0,0 +1,239 @@
+package encounters
+
+import (
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core"
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+	"github.com/isfir/wowsims-turtle/sim/core/stats"
+)
+
+func addRagnaros(bossPrefix string) {
+	AddSingleTargetBossEncounter(&core.PresetTarget{
+		PathPrefix: bossPrefix,
+		Config: &proto.Target{
+			Id:        11502,
+			Name:      "Molten Core Ragnaros",
+			Level:     63,
+			MobType:   proto.MobType_MobTypeElemental,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      1_099_230,
+				stats.Armor:       3731, // TODO:
+				stats.AttackPower: 805,  // TODO:
+				// TODO: Resistances, besides the fire immunity set in the AI
+			}.ToFloatArray(),
+
+			SpellSchool:      proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:       2,
+			MinBaseDamage:    3500, // TODO:
+			DamageSpread:     0.3333,
+			ParryHaste:       true,
+			DualWield:        false,
+			DualWieldPenalty: false,
+			TargetInputs:     make([]*proto.TargetInput, 0),
+		},
+		AI: NewRagnarosAI(),
+	})
+}
+
+type RagnarosAI struct {
+	Target *core.Target
+
+	wrathOfRagnarosSpell *core.Spell
+	elementalFireSpell   *core.Spell
+}
+
+func NewRagnarosAI() core.AIFactory {
+	return func() core.TargetAI {
+		return &RagnarosAI{}
+	}
+}
+
+func (ai *RagnarosAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+	makeImmuneToSchools(target, stats.SchoolIndexFire)
+	ai.registerSpells()
+}
+
+func (ai *RagnarosAI) registerSpells() {
+	// Knocks back melee in range, forcing a tank swap in real raids.
+	ai.wrathOfRagnarosSpell = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 20566},
+		SpellSchool: core.SpellSchoolFire,
+		DefenseType: core.DefenseTypeMagic,
+		ProcMask:    core.ProcMaskSpellDamage,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 25,
+			},
+		},
+
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseDamage := sim.Roll(2313, 2687) // TODO:
+			spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMagicHit)
+		},
+	})
+
+	ai.elementalFireSpell = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 20564},
+		SpellSchool: core.SpellSchoolFire,
+		DefenseType: core.DefenseTypeMagic,
+		ProcMask:    core.ProcMaskSpellDamage,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 10, // TODO:
+			},
+		},
+
+		DamageMultiplier: 1,
+
+		Dot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Elemental Fire",
+			},
+			NumberOfTicks: 4,
+			TickLength:    time.Second * 2,
+
+			OnSnapshot: func(sim *core.Simulation, target *core.Unit, dot *core.Dot, isRollover bool) {
+				dot.Snapshot(target, 1000, isRollover) // TODO:
+			},
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotDamage(sim, target, dot.OutcomeTick)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseDamage := sim.Roll(1250, 1750) // TODO:
+			result := spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMagicHit)
+			if result.Landed() {
+				spell.Dot(target).Apply(sim)
+			}
+		},
+	})
+}
+
+func (ai *RagnarosAI) Reset(*core.Simulation) {
+}
+
+func (ai *RagnarosAI) ExecuteCustomRotation(sim *core.Simulation) {
+	target := tankOrFirstPlayer(ai.Target)
+
+	if ai.wrathOfRagnarosSpell.CanCast(sim, target) {
+		ai.wrathOfRagnarosSpell.Cast(sim, target)
+	} else if ai.elementalFireSpell.CanCast(sim, target) {
+		ai.elementalFireSpell.Cast(sim, target)
+	}
+
+	ai.Target.WaitUntil(sim, sim.CurrentTime+BossGCD)
+}
+
+func addGolemagg(bossPrefix string) {
+	AddSingleTargetBossEncounter(&core.PresetTarget{
+		PathPrefix: bossPrefix,
+		Config: &proto.Target{
+			Id:        11988,
+			Name:      "Molten Core Golemagg the Incinerator",
+			Level:     63,
+			MobType:   proto.MobType_MobTypeGiant,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      848_000, // TODO:
+				stats.Armor:       3731,    // TODO:
+				stats.AttackPower: 805,     // TODO:
+				// TODO: Resistances, besides the fire immunity set in the AI
+			}.ToFloatArray(),
+
+			SpellSchool:      proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:       2,
+			MinBaseDamage:    3000, // TODO:
+			DamageSpread:     0.3333,
+			ParryHaste:       true,
+			DualWield:        false,
+			DualWieldPenalty: false,
+			TargetInputs:     make([]*proto.TargetInput, 0),
+		},
+		AI: NewGolemaggAI(),
+	})
+}
+
+type GolemaggAI struct {
+	Target *core.Target
+}
+
+func NewGolemaggAI() core.AIFactory {
+	return func() core.TargetAI {
+		return &GolemaggAI{}
+	}
+}
+
+func (ai *GolemaggAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+	makeImmuneToSchools(target, stats.SchoolIndexFire)
+	ai.registerMagmaSplash()
+}
+
+// Golemagg's melee hits apply Magma Splash, a stacking armor reduction and fire DoT on the tank.
+func (ai *GolemaggAI) registerMagmaSplash() {
+	actionID := core.ActionID{SpellID: 13880}
+	armorReductionPerStack := 250.0 // TODO:
+
+	magmaSplash := ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:    actionID,
+		SpellSchool: core.SpellSchoolFire,
+		DefenseType: core.DefenseTypeMagic,
+		ProcMask:    core.ProcMaskEmpty,
+		Flags:       core.SpellFlagNoOnCastComplete,
+
+		DamageMultiplier: 1,
+
+		Dot: core.DotConfig{
+			Aura: core.Aura{
+				Label:     "Magma Splash",
+				MaxStacks: 10, // TODO:
+				OnStacksChange: func(aura *core.Aura, sim *core.Simulation, oldStacks int32, newStacks int32) {
+					aura.Unit.AddStatDynamic(sim, stats.Armor, -armorReductionPerStack*float64(newStacks-oldStacks))
+				},
+			},
+			NumberOfTicks: 10,
+			TickLength:    time.Second * 3,
+
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.Spell.CalcAndDealPeriodicDamage(sim, target, 50*float64(dot.GetStacks()), dot.OutcomeTick) // TODO:
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			// Reapplying must keep the stacks and the tick timer running.
+			dot := spell.Dot(target)
+			dot.ApplyOrRefresh(sim)
+			if dot.GetStacks() < dot.MaxStacks {
+				dot.AddStack(sim)
+			}
+		},
+	})
+
+	core.MakePermanent(ai.Target.RegisterAura(core.Aura{
+		Label: "Magma Splash Trigger",
+		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if spell.ProcMask.Matches(core.ProcMaskMeleeMHAuto) && result.Landed() {
+				magmaSplash.Cast(sim, result.Target)
+			}
+		},
+	}))
+}
+
+func (ai *GolemaggAI) Reset(*core.Simulation) {
+}
+
+func (ai *GolemaggAI) ExecuteCustomRotation(sim *core.Simulation) {
+	ai.Target.WaitUntil(sim, sim.CurrentTime+BossGCD)
+}
@@ -0,0 +1,96 @@
+package naxxramas
+
+import (
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core"
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+	"github.com/isfir/wowsims-turtle/sim/core/stats"
+)
+
+func addKelThuzad(bossPrefix string) {
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: bossPrefix,
+		Config: &proto.Target{
+			Id:        15990,
+			Name:      "Naxxramas Kel'Thuzad",
+			Level:     63,
+			MobType:   proto.MobType_MobTypeUndead,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      3_331_000, // TODO:
+				stats.Armor:       3731,      // TODO:
+				stats.AttackPower: 805,       // TODO:
+			}.ToFloatArray(),
+
+			SpellSchool:      proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:       2,
+			MinBaseDamage:    3500, // TODO:
+			DamageSpread:     0.3333,
+			ParryHaste:       false,
+			DualWield:        false,
+			DualWieldPenalty: false,
+			TargetInputs:     make([]*proto.TargetInput, 0),
+		},
+		AI: NewKelThuzadAI(),
+	})
+	core.AddPresetEncounter("Naxxramas Kel'Thuzad", []string{
+		bossPrefix + "/Naxxramas Kel'Thuzad",
+	})
+}
+
+type KelThuzadAI struct {
+	Target *core.Target
+
+	FrostboltVolley *core.Spell
+}
+
+func NewKelThuzadAI() core.AIFactory {
+	return func() core.TargetAI {
+		return &KelThuzadAI{}
+	}
+}
+
+func (ai *KelThuzadAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+
+	ai.registerFrostboltVolleySpell()
+}
+
+func (ai *KelThuzadAI) Reset(*core.Simulation) {
+}
+
+// Frostbolt Volley hits every player in the raid.
+func (ai *KelThuzadAI) registerFrostboltVolleySpell() {
+	ai.FrostboltVolley = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 28479},
+		SpellSchool: core.SpellSchoolFrost,
+		DefenseType: core.DefenseTypeMagic,
+		ProcMask:    core.ProcMaskSpellDamage,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 15, // TODO:
+			},
+		},
+
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, unit := range sim.Raid.AllPlayerUnits {
+				baseDamage := sim.Roll(2250, 2750) // TODO:
+				spell.CalcAndDealDamage(sim, unit, baseDamage, spell.OutcomeMagicHit)
+			}
+		},
+	})
+}
+
+func (ai *KelThuzadAI) ExecuteCustomRotation(sim *core.Simulation) {
+	if ai.FrostboltVolley.IsReady(sim) {
+		ai.FrostboltVolley.Cast(sim, firstPlayer(ai.Target))
+	}
+
+	ai.Target.WaitUntil(sim, sim.CurrentTime+BossGCD)
+}
@@ -0,0 +1,149 @@
+package naxxramas
+
+import (
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core"
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+	"github.com/isfir/wowsims-turtle/sim/core/stats"
+)
+
+func addLoatheb(bossPrefix string) {
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: bossPrefix,
+		Config: &proto.Target{
+			Id:        16011,
+			Name:      "Naxxramas Loatheb",
+			Level:     63,
+			MobType:   proto.MobType_MobTypeUndead,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      3_997_000, // TODO:
+				stats.Armor:       3731,      // TODO:
+				stats.AttackPower: 805,       // TODO:
+			}.ToFloatArray(),
+
+			SpellSchool:      proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:       2,
+			MinBaseDamage:    3000, // TODO:
+			DamageSpread:     0.3333,
+			ParryHaste:       false,
+			DualWield:        false,
+			DualWieldPenalty: false,
+			TargetInputs:     make([]*proto.TargetInput, 0),
+		},
+		AI: NewLoathebAI(),
+	})
+	core.AddPresetEncounter("Naxxramas Loatheb", []string{
+		bossPrefix + "/Naxxramas Loatheb",
+	})
+}
+
+type LoathebAI struct {
+	Target *core.Target
+
+	InevitableDoom *core.Spell
+}
+
+func NewLoathebAI() core.AIFactory {
+	return func() core.TargetAI {
+		return &LoathebAI{}
+	}
+}
+
+func (ai *LoathebAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+
+	ai.registerCorruptedMind()
+	ai.registerInevitableDoomSpell()
+}
+
+func (ai *LoathebAI) Reset(*core.Simulation) {
+	ai.InevitableDoom.CD.Duration = time.Second * 30
+}
+
+// Corrupted Mind: after casting a healing spell, a player cannot cast another one for 60 sec.
+func (ai *LoathebAI) registerCorruptedMind() {
+	actionID := core.ActionID{SpellID: 29185}
+
+	for _, unit := range ai.Target.Env.Raid.AllPlayerUnits {
+		lockoutAura := unit.RegisterAura(core.Aura{
+			Label:    "Corrupted Mind",
+			ActionID: actionID,
+			Duration: time.Minute,
+			OnGain: func(aura *core.Aura, sim *core.Simulation) {
+				aura.Unit.PseudoStats.HealingSpellsLocked = true
+			},
+			OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+				aura.Unit.PseudoStats.HealingSpellsLocked = false
+			},
+		})
+
+		core.MakePermanent(unit.RegisterAura(core.Aura{
+			Label: "Corrupted Mind Trigger",
+			OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
+				if spell.ProcMask.Matches(core.ProcMaskSpellHealing) {
+					lockoutAura.Activate(sim)
+				}
+			},
+		}))
+	}
+}
+
+// Inevitable Doom: every player takes 4000 Shadow damage after 10 sec.
+// First cast at 2 min, then every 30 sec, speeding up to every 15 sec after 5 min.
+func (ai *LoathebAI) registerInevitableDoomSpell() {
+	actionID := core.ActionID{SpellID: 29204}
+
+	ai.InevitableDoom = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:    actionID,
+		SpellSchool: core.SpellSchoolShadow,
+		DefenseType: core.DefenseTypeMagic,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagIgnoreResists,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 30,
+			},
+		},
+
+		DamageMultiplier: 1,
+
+		Dot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Inevitable Doom",
+			},
+			NumberOfTicks: 1,
+			TickLength:    time.Second * 10,
+
+			OnSnapshot: func(sim *core.Simulation, target *core.Unit, dot *core.Dot, isRollover bool) {
+				dot.Snapshot(target, 4000, isRollover)
+			},
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotDamage(sim, target, dot.OutcomeTick)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, unit := range sim.Raid.AllPlayerUnits {
+				spell.Dot(unit).Apply(sim)
+			}
+		},
+	})
+}
+
+func (ai *LoathebAI) ExecuteCustomRotation(sim *core.Simulation) {
+	// Instant spells without a cast time skip ModifyCast, so the cooldown is shortened here.
+	if sim.CurrentTime >= time.Minute*5 {
+		ai.InevitableDoom.CD.Duration = time.Second * 15
+	}
+
+	if sim.CurrentTime >= time.Minute*2 && ai.InevitableDoom.IsReady(sim) {
+		ai.InevitableDoom.Cast(sim, firstPlayer(ai.Target))
+	}
+
+	ai.Target.WaitUntil(sim, sim.CurrentTime+BossGCD)
+}
@@ -0,0 +1,30 @@
+package naxxramas
+
+import (
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core"
+)
+
+// Naxxramas bosses are tuned for the 40-man Turtle WoW raid. Their stats and
+// ability damage are still estimates, so they're registered under an
+// "Unverified" path until they're checked against the real fights. Thaddius
+// only models his Berserk so far, without Polarity Shift or Chain Lightning.
+// Encounters and their AIs are resolved by NPC ID, so every preset target
+// registered here must use a unique ID.
+
+const BossGCD = time.Millisecond * 1600
+
+func Register(bossPrefix string) {
+	addPatchwerk(bossPrefix)
+	addLoatheb(bossPrefix)
+	addThaddius(bossPrefix)
+	addKelThuzad(bossPrefix)
+}
+
+// Returns the first player of the raid, used so that raid-wide mechanics
+// still affect the simmed player in individual sims. The first party can be
+// empty in raid sims, so this looks through all of them.
+func firstPlayer(target *core.Target) *core.Unit {
+	return target.Env.Raid.AllPlayerUnits[0]
+}
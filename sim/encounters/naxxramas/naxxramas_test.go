@@ -0,0 +1,156 @@
+package naxxramas
+
+import (
+	"testing"
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core"
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+	"github.com/isfir/wowsims-turtle/sim/paladin/holy"
+	dpswarrior "github.com/isfir/wowsims-turtle/sim/warrior/dps_warrior"
+	goproto "google.golang.org/protobuf/proto"
+)
+
+const testPrefix = "Test"
+
+const testIterations = 10
+
+func init() {
+	dpswarrior.RegisterDpsWarrior()
+	holy.RegisterHolyPaladin()
+	Register(testPrefix)
+}
+
+func warriorPlayer() *proto.Player {
+	return &proto.Player{
+		Name:      "Warrior",
+		Race:      proto.Race_RaceHuman,
+		Class:     proto.Class_ClassWarrior,
+		Equipment: &proto.EquipmentSpec{},
+		Consumes:  &proto.Consumes{},
+		Spec:      &proto.Player_Warrior{Warrior: &proto.Warrior{Options: &proto.Warrior_Options{}}},
+		Rotation:  &proto.APLRotation{},
+	}
+}
+
+// A holy paladin casting Holy Light whenever it can.
+func healerPlayer() *proto.Player {
+	return &proto.Player{
+		Name:          "Healer",
+		Race:          proto.Race_RaceHuman,
+		Class:         proto.Class_ClassPaladin,
+		Equipment:     &proto.EquipmentSpec{},
+		Consumes:      &proto.Consumes{},
+		Spec:          &proto.Player_HolyPaladin{HolyPaladin: &proto.HolyPaladin{Options: &proto.PaladinOptions{}}},
+		TalentsString: "05503100521051-5",
+		Rotation: core.APLRotationFromJsonString(`{"type": "TypeAPL",
+			"priorityList": [
+				{"action": {"castSpell": {"spellId": {"spellId": 25292, "rank": 9}}}}
+			]}`),
+	}
+}
+
+// Sims the player alone against the named preset target, which tanks it.
+func runBossSim(t *testing.T, bossName string, duration time.Duration, player *proto.Player, targetInputs ...*proto.TargetInput) *proto.RaidSimResult {
+	preset := core.GetPresetTargetWithPath(testPrefix + "/" + bossName)
+	if preset == nil {
+		t.Fatalf("No preset target registered for %s", bossName)
+	}
+
+	config := preset.Config
+	if len(targetInputs) > 0 {
+		config = goproto.Clone(preset.Config).(*proto.Target)
+		config.TargetInputs = targetInputs
+	}
+
+	result := core.RunRaidSim(&proto.RaidSimRequest{
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{{Players: []*proto.Player{player}}},
+			Buffs:   &proto.RaidBuffs{},
+			Debuffs: &proto.Debuffs{},
+			Tanks:   []*proto.UnitReference{{Type: proto.UnitReference_Player, Index: 0}},
+		},
+		Encounter: &proto.Encounter{
+			Duration: duration.Seconds(),
+			Targets:  []*proto.Target{config},
+		},
+		SimOptions: &proto.SimOptions{Iterations: testIterations, RandomSeed: 101, IsTest: true},
+	})
+	if result.Error != nil {
+		t.Fatalf("Sim failed: %s", result.Error.Message)
+	}
+	return result
+}
+
+// Returns the total casts of a spell across all iterations, for the given unit's metrics.
+func spellCasts(unit *proto.UnitMetrics, spellID int32) int32 {
+	var casts int32
+	for _, action := range unit.Actions {
+		if action.Id.GetSpellId() == spellID {
+			for _, target := range action.Targets {
+				casts += target.Casts
+			}
+		}
+	}
+	return casts
+}
+
+func TestPatchwerkHatefulStrike(t *testing.T) {
+	// With no off-tank Hateful Strike only goes out when it's set to hit the player.
+	result := runBossSim(t, "Naxxramas Patchwerk", time.Minute, warriorPlayer())
+	if casts := spellCasts(result.EncounterMetrics.Targets[0], 28308); casts != 0 {
+		t.Fatalf("Expected no Hateful Strikes without an off-tank, got %d", casts)
+	}
+
+	result = runBossSim(t, "Naxxramas Patchwerk", time.Minute, warriorPlayer(), &proto.TargetInput{
+		InputType: proto.InputType_Bool,
+		BoolValue: true,
+	})
+	// One Hateful Strike every 1.2 sec, from the pull up to the end of the fight.
+	if casts := spellCasts(result.EncounterMetrics.Targets[0], 28308); casts != 51*testIterations {
+		t.Fatalf("Expected %d Hateful Strikes, got %d", 51*testIterations, casts)
+	}
+}
+
+func TestPatchwerkFrenzy(t *testing.T) {
+	result := runBossSim(t, "Naxxramas Patchwerk", time.Minute, warriorPlayer())
+	if casts := spellCasts(result.EncounterMetrics.Targets[0], 28131); casts != testIterations {
+		t.Fatalf("Expected one Frenzy per iteration, got %d in total", casts)
+	}
+}
+
+func TestLoathebInevitableDoom(t *testing.T) {
+	result := runBossSim(t, "Naxxramas Loatheb", time.Minute*6, warriorPlayer())
+	// From 2 min every 30 sec, then every 15 sec from 5 min: 7 casts up to 5 min and 3 after.
+	if casts := spellCasts(result.EncounterMetrics.Targets[0], 29204); casts != 10*testIterations {
+		t.Fatalf("Expected %d Inevitable Dooms, got %d", 10*testIterations, casts)
+	}
+}
+
+func TestLoathebCorruptedMind(t *testing.T) {
+	result := runBossSim(t, "Naxxramas Loatheb", time.Minute*3, healerPlayer())
+	// One heal at the pull and one each time the lockout expires.
+	if casts := spellCasts(result.RaidMetrics.Parties[0].Players[0], 25292); casts != 3*testIterations {
+		t.Fatalf("Expected %d Holy Lights, got %d", 3*testIterations, casts)
+	}
+}
+
+func TestThaddiusBerserk(t *testing.T) {
+	result := runBossSim(t, "Naxxramas Thaddius", time.Minute*4, warriorPlayer())
+	if casts := spellCasts(result.EncounterMetrics.Targets[0], 26662); casts != 0 {
+		t.Fatalf("Expected no Berserk before 5 min, got %d", casts)
+	}
+
+	result = runBossSim(t, "Naxxramas Thaddius", time.Minute*6, warriorPlayer())
+	if casts := spellCasts(result.EncounterMetrics.Targets[0], 26662); casts != testIterations {
+		t.Fatalf("Expected one Berserk per iteration, got %d in total", casts)
+	}
+}
+
+func TestKelThuzadFrostboltVolley(t *testing.T) {
+	result := runBossSim(t, "Naxxramas Kel'Thuzad", time.Minute, warriorPlayer())
+	// One volley at the pull and then every 15 sec.
+	if casts := spellCasts(result.EncounterMetrics.Targets[0], 28479); casts != 4*testIterations {
+		t.Fatalf("Expected %d Frostbolt Volleys, got %d", 4*testIterations, casts)
+	}
+}
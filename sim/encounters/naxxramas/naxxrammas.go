@@ -1,11 +0,0 @@
-package naxxramas
-
-func Register() {
-	addPatchwerk25("Naxxrammas 25")
-	addKelThuzad25("Naxxrammas 25")
-	addThaddius25("Naxxrammas 25")
-	addLoatheb25("Naxxrammas 25")
-
-	// TODO: Figure out why this isn't pickable
-	//addPatchwerk10("Naxxrammas")
-}
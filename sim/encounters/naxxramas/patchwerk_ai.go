@@ -0,0 +1,165 @@
+package naxxramas
+
+import (
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core"
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+	"github.com/isfir/wowsims-turtle/sim/core/stats"
+)
+
+func addPatchwerk(bossPrefix string) {
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: bossPrefix,
+		Config: &proto.Target{
+			Id:        16028,
+			Name:      "Naxxramas Patchwerk",
+			Level:     63,
+			MobType:   proto.MobType_MobTypeUndead,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      4_495_000, // TODO:
+				stats.Armor:       3731,      // TODO:
+				stats.AttackPower: 805,       // TODO:
+			}.ToFloatArray(),
+
+			SpellSchool:      proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:       1.2,
+			MinBaseDamage:    4000, // TODO:
+			DamageSpread:     0.3333,
+			ParryHaste:       false,
+			DualWield:        false,
+			DualWieldPenalty: false,
+			TargetInputs: []*proto.TargetInput{
+				{
+					Label:     "Hateful Strike on player",
+					Tooltip:   "Whether Hateful Strike hits the simmed player when the raid has no off-tank assigned, e.g. for off-tank sims.",
+					InputType: proto.InputType_Bool,
+					BoolValue: false,
+				},
+			},
+		},
+		AI: NewPatchwerkAI(),
+	})
+	core.AddPresetEncounter("Naxxramas Patchwerk", []string{
+		bossPrefix + "/Naxxramas Patchwerk",
+	})
+}
+
+type PatchwerkAI struct {
+	Target *core.Target
+
+	HatefulStrike *core.Spell
+	Frenzy        *core.Spell
+
+	hatefulStrikeOnPlayer bool
+}
+
+func NewPatchwerkAI() core.AIFactory {
+	return func() core.TargetAI {
+		return &PatchwerkAI{}
+	}
+}
+
+func (ai *PatchwerkAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+
+	if len(config.TargetInputs) > 0 {
+		ai.hatefulStrikeOnPlayer = config.TargetInputs[0].BoolValue
+	}
+
+	ai.registerHatefulStrikeSpell(target)
+	ai.registerFrenzySpell(target)
+}
+
+func (ai *PatchwerkAI) Reset(*core.Simulation) {
+}
+
+func (ai *PatchwerkAI) registerHatefulStrikeSpell(target *core.Target) {
+	ai.HatefulStrike = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 28308},
+		SpellSchool: core.SpellSchoolPhysical,
+		DefenseType: core.DefenseTypeMelee,
+		ProcMask:    core.ProcMaskMeleeMHSpecial,
+		Flags:       core.SpellFlagMeleeMetrics,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Millisecond * 1200,
+			},
+		},
+
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseDamage := sim.Roll(9000, 11000) // TODO: Estimated, the 40-man Hateful Strike hits far weaker than the 25-man one
+			spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeEnemyMeleeWhite)
+		},
+	})
+}
+
+func (ai *PatchwerkAI) registerFrenzySpell(target *core.Target) {
+	actionID := core.ActionID{SpellID: 28131}
+	frenzyAura := target.GetOrRegisterAura(core.Aura{
+		ActionID: actionID,
+		Label:    "Frenzy",
+		Duration: core.NeverExpires,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Unit.PseudoStats.SchoolDamageDealtMultiplier[stats.SchoolIndexPhysical] *= 1.25
+			aura.Unit.MultiplyMeleeSpeed(sim, 1.4)
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Unit.PseudoStats.SchoolDamageDealtMultiplier[stats.SchoolIndexPhysical] /= 1.25
+			aura.Unit.MultiplyMeleeSpeed(sim, 1.0/1.4)
+		},
+	})
+
+	ai.Frenzy = target.RegisterSpell(core.SpellConfig{
+		ActionID: actionID,
+		Flags:    core.SpellFlagNoOnCastComplete,
+
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return !frenzyAura.IsActive()
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			frenzyAura.Activate(sim)
+		},
+	})
+}
+
+// Hateful Strike hits the healthiest tank that is not currently tanking Patchwerk.
+func (ai *PatchwerkAI) hatefulStrikeTarget() *core.Unit {
+	var hatefulStrikeTarget *core.Unit
+	for _, tank := range ai.Target.Env.Raid.Tanks {
+		if tank == nil || tank == ai.Target.CurrentTarget {
+			continue
+		}
+		if hatefulStrikeTarget == nil || tank.CurrentHealth() > hatefulStrikeTarget.CurrentHealth() {
+			hatefulStrikeTarget = tank
+		}
+	}
+
+	if hatefulStrikeTarget == nil && ai.hatefulStrikeOnPlayer {
+		hatefulStrikeTarget = firstPlayer(ai.Target)
+	}
+
+	return hatefulStrikeTarget
+}
+
+func (ai *PatchwerkAI) ExecuteCustomRotation(sim *core.Simulation) {
+	// Frenzy at 5% health.
+	if ai.Frenzy.CanCast(sim, &ai.Target.Unit) && sim.GetRemainingDurationPercent() < 0.05 {
+		ai.Frenzy.Cast(sim, &ai.Target.Unit)
+	}
+
+	if ai.HatefulStrike.IsReady(sim) {
+		if hatefulStrikeTarget := ai.hatefulStrikeTarget(); hatefulStrikeTarget != nil {
+			ai.HatefulStrike.Cast(sim, hatefulStrikeTarget)
+		}
+	}
+
+	ai.Target.WaitUntil(sim, max(sim.CurrentTime+time.Millisecond*100, ai.HatefulStrike.ReadyAt()))
+}
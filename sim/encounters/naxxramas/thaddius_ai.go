@@ -0,0 +1,141 @@
+package naxxramas
+
+import (
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core"
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+	"github.com/isfir/wowsims-turtle/sim/core/stats"
+)
+
+func addThaddius(bossPrefix string) {
+	// Phase 1: Stalagg and Feugen are tanked separately and have to die together.
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: bossPrefix,
+		Config:     thaddiusAddConfig(15929, "Naxxramas Stalagg", 0),
+	})
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: bossPrefix,
+		Config:     thaddiusAddConfig(15930, "Naxxramas Feugen", 1),
+	})
+	core.AddPresetEncounter("Naxxramas Stalagg and Feugen", []string{
+		bossPrefix + "/Naxxramas Stalagg",
+		bossPrefix + "/Naxxramas Feugen",
+	})
+
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: bossPrefix,
+		Config: &proto.Target{
+			Id:        15928,
+			Name:      "Naxxramas Thaddius",
+			Level:     63,
+			MobType:   proto.MobType_MobTypeUndead,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      4_895_000, // TODO:
+				stats.Armor:       3731,      // TODO:
+				stats.AttackPower: 805,       // TODO:
+			}.ToFloatArray(),
+
+			SpellSchool:      proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:       2,
+			MinBaseDamage:    4500, // TODO:
+			DamageSpread:     0.3333,
+			ParryHaste:       false,
+			DualWield:        false,
+			DualWieldPenalty: false,
+			TargetInputs:     make([]*proto.TargetInput, 0),
+		},
+		AI: NewThaddiusAI(),
+	})
+	core.AddPresetEncounter("Naxxramas Thaddius", []string{
+		bossPrefix + "/Naxxramas Thaddius",
+	})
+}
+
+func thaddiusAddConfig(id int32, name string, tankIndex int32) *proto.Target {
+	return &proto.Target{
+		Id:        id,
+		Name:      name,
+		Level:     63,
+		MobType:   proto.MobType_MobTypeUndead,
+		TankIndex: tankIndex,
+
+		Stats: stats.Stats{
+			stats.Health:      1_249_000, // TODO:
+			stats.Armor:       3731,      // TODO:
+			stats.AttackPower: 805,       // TODO:
+		}.ToFloatArray(),
+
+		SpellSchool:      proto.SpellSchool_SpellSchoolPhysical,
+		SwingSpeed:       2,
+		MinBaseDamage:    3000, // TODO:
+		DamageSpread:     0.3333,
+		ParryHaste:       false,
+		DualWield:        false,
+		DualWieldPenalty: false,
+		TargetInputs:     make([]*proto.TargetInput, 0),
+	}
+}
+
+type ThaddiusAI struct {
+	Target *core.Target
+
+	Berserk *core.Spell
+}
+
+func NewThaddiusAI() core.AIFactory {
+	return func() core.TargetAI {
+		return &ThaddiusAI{}
+	}
+}
+
+func (ai *ThaddiusAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+
+	ai.registerBerserkSpell()
+}
+
+func (ai *ThaddiusAI) Reset(*core.Simulation) {
+}
+
+// Berserk at 5 min: +150% attack speed and +500% damage dealt.
+func (ai *ThaddiusAI) registerBerserkSpell() {
+	actionID := core.ActionID{SpellID: 26662}
+
+	berserkAura := ai.Target.RegisterAura(core.Aura{
+		Label:    "Berserk",
+		ActionID: actionID,
+		Duration: core.NeverExpires,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Unit.MultiplyAttackSpeed(sim, 2.5)
+			aura.Unit.PseudoStats.DamageDealtMultiplier *= 6
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Unit.MultiplyAttackSpeed(sim, 1/2.5)
+			aura.Unit.PseudoStats.DamageDealtMultiplier /= 6
+		},
+	})
+
+	ai.Berserk = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID: actionID,
+		Flags:    core.SpellFlagNoOnCastComplete,
+
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return !berserkAura.IsActive()
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			berserkAura.Activate(sim)
+		},
+	})
+}
+
+func (ai *ThaddiusAI) ExecuteCustomRotation(sim *core.Simulation) {
+	if sim.CurrentTime >= time.Minute*5 && ai.Berserk.CanCast(sim, &ai.Target.Unit) {
+		ai.Berserk.Cast(sim, &ai.Target.Unit)
+	}
+
+	ai.Target.WaitUntil(sim, sim.CurrentTime+BossGCD)
+}
@@ -0,0 +1,130 @@
+package encounters
+
+import (
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core"
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+	"github.com/isfir/wowsims-turtle/sim/core/stats"
+)
+
+func addOnyxia(bossPrefix string) {
+	AddSingleTargetBossEncounter(&core.PresetTarget{
+		PathPrefix: bossPrefix,
+		Config: &proto.Target{
+			Id:        10184,
+			Name:      "Onyxia's Lair Onyxia",
+			Level:     63,
+			MobType:   proto.MobType_MobTypeDragonkin,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:         1_249_500, // TODO:
+				stats.Armor:          3731,      // TODO:
+				stats.AttackPower:    805,       // TODO:
+				stats.FireResistance: 100,       // TODO:
+			}.ToFloatArray(),
+
+			SpellSchool:      proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:       2,
+			MinBaseDamage:    3000, // TODO:
+			DamageSpread:     0.3333,
+			ParryHaste:       true,
+			DualWield:        false,
+			DualWieldPenalty: false,
+			TargetInputs:     make([]*proto.TargetInput, 0),
+		},
+		AI: NewOnyxiaAI(),
+	})
+}
+
+// Onyxia is airborne between 65% and 40% health. While airborne she stops
+// meleeing the tank and casts Fireball on random raid members instead.
+type OnyxiaAI struct {
+	Target *core.Target
+
+	flameBreathSpell *core.Spell
+	fireballSpell    *core.Spell
+}
+
+func NewOnyxiaAI() core.AIFactory {
+	return func() core.TargetAI {
+		return &OnyxiaAI{}
+	}
+}
+
+func (ai *OnyxiaAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+	ai.registerSpells()
+}
+
+func (ai *OnyxiaAI) registerSpells() {
+	ai.flameBreathSpell = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 18435},
+		SpellSchool: core.SpellSchoolFire,
+		DefenseType: core.DefenseTypeMagic,
+		ProcMask:    core.ProcMaskSpellDamage,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 15, // TODO:
+			},
+		},
+
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseDamage := sim.Roll(3063, 3937)
+			spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMagicHit)
+		},
+	})
+
+	ai.fireballSpell = ai.Target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 18392},
+		SpellSchool: core.SpellSchoolFire,
+		DefenseType: core.DefenseTypeMagic,
+		ProcMask:    core.ProcMaskSpellDamage,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    ai.Target.NewTimer(),
+				Duration: time.Second * 3, // TODO:
+			},
+		},
+
+		DamageMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseDamage := sim.Roll(2313, 2687) // TODO:
+			spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMagicHit)
+		},
+	})
+}
+
+func (ai *OnyxiaAI) Reset(*core.Simulation) {
+}
+
+func (ai *OnyxiaAI) isAirborne(sim *core.Simulation) bool {
+	remaining := sim.GetRemainingDurationPercent()
+	return remaining <= 0.65 && remaining > 0.40
+}
+
+func (ai *OnyxiaAI) ExecuteCustomRotation(sim *core.Simulation) {
+	if ai.isAirborne(sim) {
+		ai.Target.AutoAttacks.CancelAutoSwing(sim)
+
+		if ai.fireballSpell.IsReady(sim) {
+			ai.fireballSpell.Cast(sim, randomRaidPlayer(sim, ai.Target))
+		}
+	} else {
+		ai.Target.AutoAttacks.EnableAutoSwing(sim)
+
+		target := tankOrFirstPlayer(ai.Target)
+		if ai.flameBreathSpell.CanCast(sim, target) {
+			ai.flameBreathSpell.Cast(sim, target)
+		}
+	}
+
+	ai.Target.WaitUntil(sim, sim.CurrentTime+BossGCD)
+}
@@ -2,13 +2,23 @@ package encounters
 
 import (
 	"github.com/isfir/wowsims-turtle/sim/core"
+	"github.com/isfir/wowsims-turtle/sim/encounters/naxxramas"
 )
 
+// Bosses whose stats and ability damage are still estimates are listed under this
+// path, so they aren't mistaken for finished encounters.
+const unverifiedPrefix = "Classic/Unverified"
+
 func init() {
-	// TODO: Classic encounters?
-	// naxxramas.Register()
 	addLevel60("Classic")
+	addRagnaros(unverifiedPrefix)
+	addGolemagg(unverifiedPrefix)
+	addOnyxia(unverifiedPrefix)
 	addVaelastraszTheCorrupt("Classic")
+	addCThun(unverifiedPrefix)
+	addTwinEmperors(unverifiedPrefix)
+	addPrincessHuhuran(unverifiedPrefix)
+	naxxramas.Register(unverifiedPrefix)
 }
 
 func AddSingleTargetBossEncounter(presetTarget *core.PresetTarget) {
@@ -89,3 +89,51 @@ var Stats = []proto.Stat{
 	proto.Stat_StatMeleeCrit,
 	proto.Stat_StatMeleeHit,
 }
+
+func TestHunterPetAPLRotation(t *testing.T) {
+	// Bite whenever it's ready, pooling focus for it instead of spending it on Claw.
+	petRotation := core.APLRotationFromJsonString(`{"type": "TypeAPL", "priorityList": [
+		{"action": {"castSpell": {"spellId": {"spellId": 17261}}}},
+		{"action": {"condition": {"cmp": {"op": "OpGe", "lhs": {"currentFocus": {}}, "rhs": {"const": {"val": "60"}}}}, "castSpell": {"spellId": {"spellId": 3009}}}}
+	]}`)
+
+	petCasts := func(petRotation *proto.APLRotation) map[int32]int32 {
+		player := &proto.Player{
+			Name:          "Hunter",
+			Race:          proto.Race_RaceOrc,
+			Class:         proto.Class_ClassHunter,
+			Equipment:     &proto.EquipmentSpec{},
+			Consumes:      P1Consumes.Consumes,
+			Spec:          P1PlayerOptions,
+			TalentsString: P1Talents,
+			Rotation:      core.GetAplRotation("../../ui/hunter/apls", "p1").Rotation,
+			PetRotation:   petRotation,
+		}
+		result := core.RunRaidSim(&proto.RaidSimRequest{
+			Raid:       core.SinglePlayerRaidProto(player, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+			Encounter:  core.MakeSingleTargetEncounter(0),
+			SimOptions: &proto.SimOptions{Iterations: 20, RandomSeed: 101, IsTest: true},
+		})
+		if result.Error != nil {
+			t.Fatalf("Sim failed: %s", result.Error.Message)
+		}
+
+		casts := make(map[int32]int32)
+		for _, action := range result.RaidMetrics.Parties[0].Players[0].Pets[0].Actions {
+			for _, target := range action.Targets {
+				casts[action.Id.GetSpellId()] += target.Casts
+			}
+		}
+		return casts
+	}
+
+	// The cat's default rotation only Claws.
+	defaultCasts := petCasts(nil)
+	if defaultCasts[17261] != 0 || defaultCasts[3009] == 0 {
+		t.Fatalf("Expected the default rotation to only Claw, got %v", defaultCasts)
+	}
+	aplCasts := petCasts(petRotation)
+	if aplCasts[17261] == 0 || aplCasts[3009] == 0 || aplCasts[3009] >= defaultCasts[3009] {
+		t.Fatalf("Expected the pet APL to Bite and Claw less, got %v", aplCasts)
+	}
+}
@@ -61,8 +61,9 @@ func (hunter *Hunter) getMultiShotConfig(rank int, timer *core.Timer) core.Spell
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			curTarget := target
+			numTargets := min(numHits, sim.GetNumActiveTargets())
 
-			for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
+			for hitIndex := int32(0); hitIndex < numTargets; hitIndex++ {
 				baseDamage := baseDamage +
 					hunter.AutoAttacks.Ranged().CalculateNormalizedWeaponDamage(sim, spell.RangedAttackPower(target, false)) +
 					hunter.AmmoDamageBonus
@@ -73,7 +74,7 @@ func (hunter *Hunter) getMultiShotConfig(rank int, timer *core.Timer) core.Spell
 			}
 			hunter.Unit.AutoAttacks.EnableAutoSwing(sim)
 			spell.WaitTravelTime(sim, func(s *core.Simulation) {
-				for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
+				for hitIndex := int32(0); hitIndex < numTargets; hitIndex++ {
 					spell.DealDamage(sim, results[hitIndex])
 
 					curTarget = sim.Environment.NextTargetUnit(curTarget)
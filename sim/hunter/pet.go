@@ -129,8 +129,22 @@ func (hp *HunterPet) Initialize() {
 	})
 }
 
-func (hp *HunterPet) Reset(_ *core.Simulation) {
+func (hp *HunterPet) Reset(sim *core.Simulation) {
 	hp.uptimePercent = min(1, max(0, hp.hunterOwner.Options.PetUptime))
+
+	// APL rotations don't go through ExecuteCustomRotation, so the uptime is checked separately.
+	if hp.HasAPLRotation() && hp.uptimePercent < 1 {
+		var uptimeCheck *core.PendingAction
+		uptimeCheck = core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+			Period: time.Millisecond * 500,
+			OnAction: func(sim *core.Simulation) {
+				if sim.GetRemainingDurationPercent() < 1.0-hp.uptimePercent {
+					hp.Disable(sim)
+					uptimeCheck.Cancel(sim)
+				}
+			},
+		})
+	}
 }
 
 func (hp *HunterPet) ExecuteCustomRotation(sim *core.Simulation) {
@@ -8,6 +8,66 @@ import (
 	"github.com/isfir/wowsims-turtle/sim/core/stats"
 )
 
+// Layout of the talent trees, in the same order as the talent string.
+var TalentTrees = core.TalentTrees{
+	{ // Beast Mastery
+		{Name: "improvedAspectOfTheHawk", Row: 0, MaxPoints: 5},
+		{Name: "enduranceTraining", Row: 0, MaxPoints: 5},
+		{Name: "improvedEyesOfTheBeast", Row: 1, MaxPoints: 2},
+		{Name: "improvedAspectOfTheMonkey", Row: 1, MaxPoints: 5},
+		{Name: "thickHide", Row: 1, MaxPoints: 3},
+		{Name: "improvedRevivePet", Row: 1, MaxPoints: 2},
+		{Name: "pathfinding", Row: 2, MaxPoints: 2},
+		{Name: "bestialSwiftness", Row: 2, MaxPoints: 1},
+		{Name: "unleashedFury", Row: 2, MaxPoints: 5},
+		{Name: "improvedMendPet", Row: 3, MaxPoints: 2},
+		{Name: "ferocity", Row: 3, MaxPoints: 5},
+		{Name: "spiritBond", Row: 4, MaxPoints: 2},
+		{Name: "intimidation", Row: 4, MaxPoints: 1},
+		{Name: "bestialDiscipline", Row: 4, MaxPoints: 2},
+		{Name: "frenzy", Row: 5, MaxPoints: 5, Prereq: "ferocity"},
+		{Name: "bestialWrath", Row: 6, MaxPoints: 1, Prereq: "intimidation"},
+	},
+	{ // Marksmanship
+		{Name: "improvedConcussiveShot", Row: 0, MaxPoints: 5},
+		{Name: "efficiency", Row: 0, MaxPoints: 5},
+		{Name: "improvedHuntersMark", Row: 1, MaxPoints: 5},
+		{Name: "lethalShots", Row: 1, MaxPoints: 5},
+		{Name: "aimedShot", Row: 2, MaxPoints: 1},
+		{Name: "improvedArcaneShot", Row: 2, MaxPoints: 5},
+		{Name: "hawkEye", Row: 2, MaxPoints: 3},
+		{Name: "improvedSerpentSting", Row: 3, MaxPoints: 5},
+		{Name: "mortalShots", Row: 3, MaxPoints: 5, Prereq: "lethalShots"},
+		{Name: "scatterShot", Row: 4, MaxPoints: 1},
+		{Name: "barrage", Row: 4, MaxPoints: 3},
+		{Name: "improvedScorpidSting", Row: 4, MaxPoints: 3},
+		{Name: "rangedWeaponSpecialization", Row: 5, MaxPoints: 5},
+		{Name: "trueshotAura", Row: 6, MaxPoints: 1, Prereq: "barrage"},
+	},
+	{ // Survival
+		{Name: "monsterSlaying", Row: 0, MaxPoints: 3},
+		{Name: "humanoidSlaying", Row: 0, MaxPoints: 3},
+		{Name: "deflection", Row: 0, MaxPoints: 5},
+		{Name: "entrapment", Row: 1, MaxPoints: 5},
+		{Name: "savageStrikes", Row: 1, MaxPoints: 2},
+		{Name: "improvedWingClip", Row: 1, MaxPoints: 5},
+		{Name: "cleverTraps", Row: 2, MaxPoints: 2},
+		{Name: "survivalist", Row: 2, MaxPoints: 5},
+		{Name: "deterrence", Row: 2, MaxPoints: 1},
+		{Name: "trapMastery", Row: 3, MaxPoints: 2},
+		{Name: "surefooted", Row: 3, MaxPoints: 3},
+		{Name: "improvedFeignDeath", Row: 3, MaxPoints: 2},
+		{Name: "killerInstinct", Row: 4, MaxPoints: 3},
+		{Name: "counterattack", Row: 4, MaxPoints: 1, Prereq: "deterrence"},
+		{Name: "lightningReflexes", Row: 5, MaxPoints: 5},
+		{Name: "wyvernSting", Row: 6, MaxPoints: 1, Prereq: "killerInstinct"},
+	},
+}
+
+func init() {
+	core.RegisterTalentTrees(proto.Class_ClassHunter, TalentTrees)
+}
+
 func (hunter *Hunter) ApplyTalents() {
 	if hunter.pet != nil {
 		hunter.applyFrenzy()
@@ -0,0 +1,72 @@
+package sim
+
+import (
+	"testing"
+
+	"github.com/isfir/wowsims-turtle/sim/core"
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+)
+
+func TestInteractiveSim(t *testing.T) {
+	player := &proto.Player{
+		Name:      "Interactive",
+		Race:      proto.Race_RaceOrc,
+		Class:     proto.Class_ClassWarrior,
+		Equipment: core.GetGearSet("../ui/protection_paladin/gear_sets", "blank").GearSet,
+		Consumes:  &proto.Consumes{},
+		Rotation:  &proto.APLRotation{},
+		Spec: &proto.Player_Warrior{
+			Warrior: &proto.Warrior{
+				Options: &proto.Warrior_Options{StartingRage: 50},
+			},
+		},
+		Buffs: &proto.IndividualBuffs{},
+	}
+
+	state := core.StartInteractiveSim(&proto.InteractiveSimStartRequest{
+		Request: &proto.RaidSimRequest{
+			Raid:       core.SinglePlayerRaidProto(player, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+			Encounter:  &proto.Encounter{Duration: 20, Targets: []*proto.Target{core.NewDefaultTarget()}},
+			SimOptions: &proto.SimOptions{RandomSeed: 101},
+		},
+	})
+	if state.Error != nil {
+		t.Fatalf("Failed to start interactive sim: %s", state.Error.Message)
+	}
+	if !state.NeedsInput || state.Done {
+		t.Fatalf("Expected interactive sim to wait for input at the start")
+	}
+
+	casts := 0
+	for steps := 0; !state.Done; steps++ {
+		if steps > 1000 {
+			t.Fatalf("Interactive sim did not finish")
+		}
+
+		action := &proto.InteractiveSimAction{Action: &proto.InteractiveSimAction_WaitSeconds{WaitSeconds: 0.5}}
+		for _, option := range state.AvailableActions {
+			if option.IsReady && option.OnGcd {
+				action = &proto.InteractiveSimAction{Action: &proto.InteractiveSimAction_CastSpell{CastSpell: option.Id}}
+				casts++
+				break
+			}
+		}
+
+		state = core.StepInteractiveSim(&proto.InteractiveSimStepRequest{SessionId: state.SessionId, Action: action})
+		if state.Error != nil {
+			t.Fatalf("Interactive step failed: %s", state.Error.Message)
+		}
+	}
+
+	if casts == 0 {
+		t.Fatalf("Expected at least one spell to be castable")
+	}
+	if state.FinalResult == nil || state.FinalResult.RaidMetrics.Dps.Avg <= 0 {
+		t.Fatalf("Expected a final result with damage done")
+	}
+
+	state = core.GetInteractiveSimState(&proto.InteractiveSimStateRequest{SessionId: state.SessionId})
+	if state.Error == nil {
+		t.Fatalf("Expected finished session to be removed")
+	}
+}
@@ -5,8 +5,78 @@ import (
 	"time"
 
 	"github.com/isfir/wowsims-turtle/sim/core"
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
 )
 
+// Layout of the talent trees, in the same order as the talent string.
+var TalentTrees = core.TalentTrees{
+	{ // Arcane
+		{Name: "arcaneSubtlety", Row: 0, MaxPoints: 2},
+		{Name: "magicAbsorption", Row: 0, MaxPoints: 3},
+		{Name: "improvedArcaneMissiles", Row: 0, MaxPoints: 5},
+		{Name: "wandSpecialization", Row: 1, MaxPoints: 2},
+		{Name: "arcaneFocus", Row: 1, MaxPoints: 5},
+		{Name: "arcaneConcentration", Row: 1, MaxPoints: 5},
+		{Name: "magicAttunement", Row: 2, MaxPoints: 1},
+		{Name: "arcaneImpact", Row: 2, MaxPoints: 3},
+		{Name: "arcaneRupture", Row: 2, MaxPoints: 1},
+		{Name: "improvedManaShield", Row: 3, MaxPoints: 2},
+		{Name: "improvedCounterspell", Row: 3, MaxPoints: 2},
+		{Name: "temporalConvergence", Row: 3, MaxPoints: 3, Prereq: "arcaneRupture"},
+		{Name: "arcaneMeditation", Row: 3, MaxPoints: 3},
+		{Name: "arcaneInstability", Row: 4, MaxPoints: 3},
+		{Name: "presenceOfMind", Row: 4, MaxPoints: 1},
+		{Name: "acceleratedArcana", Row: 4, MaxPoints: 1},
+		{Name: "arcanePotency", Row: 4, MaxPoints: 2},
+		{Name: "resonanceCascade", Row: 5, MaxPoints: 5},
+		{Name: "arcanePower", Row: 6, MaxPoints: 1, Prereq: "presenceOfMind"},
+	},
+	{ // Fire
+		{Name: "improvedFireball", Row: 0, MaxPoints: 5},
+		{Name: "impact", Row: 0, MaxPoints: 5},
+		{Name: "ignite", Row: 1, MaxPoints: 5},
+		{Name: "flameThrowing", Row: 1, MaxPoints: 2},
+		{Name: "improvedFireBlast", Row: 1, MaxPoints: 3},
+		{Name: "incinerate", Row: 2, MaxPoints: 2},
+		{Name: "improvedFlamestrike", Row: 2, MaxPoints: 3},
+		{Name: "pyroblast", Row: 2, MaxPoints: 1},
+		{Name: "burningSoul", Row: 2, MaxPoints: 2},
+		{Name: "fireVulnerability", Row: 3, MaxPoints: 3},
+		{Name: "improvedFireWard", Row: 3, MaxPoints: 2},
+		{Name: "masterOfElements", Row: 3, MaxPoints: 3},
+		{Name: "blastWave", Row: 4, MaxPoints: 1},
+		{Name: "criticalMass", Row: 4, MaxPoints: 3},
+		{Name: "hotStreak", Row: 4, MaxPoints: 2, Prereq: "pyroblast"},
+		{Name: "firePower", Row: 5, MaxPoints: 5},
+		{Name: "combustion", Row: 6, MaxPoints: 1, Prereq: "criticalMass"},
+	},
+	{ // Frost
+		{Name: "frostWarding", Row: 0, MaxPoints: 2},
+		{Name: "improvedFrostbolt", Row: 0, MaxPoints: 5},
+		{Name: "elementalPrecision", Row: 0, MaxPoints: 3},
+		{Name: "piercingIce", Row: 1, MaxPoints: 3},
+		{Name: "frostbite", Row: 1, MaxPoints: 3},
+		{Name: "improvedFrostNova", Row: 1, MaxPoints: 2},
+		{Name: "permafrost", Row: 1, MaxPoints: 3},
+		{Name: "iceShards", Row: 2, MaxPoints: 5},
+		{Name: "coldSnap", Row: 2, MaxPoints: 1},
+		{Name: "improvedBlizzard", Row: 2, MaxPoints: 3},
+		{Name: "arcticReach", Row: 3, MaxPoints: 2},
+		{Name: "frostChanneling", Row: 3, MaxPoints: 3},
+		{Name: "shatter", Row: 3, MaxPoints: 5, Prereq: "improvedFrostNova"},
+		{Name: "iceBlock", Row: 4, MaxPoints: 1},
+		{Name: "icicles", Row: 4, MaxPoints: 1},
+		{Name: "improvedConeOfCold", Row: 4, MaxPoints: 3},
+		{Name: "wintersChill", Row: 5, MaxPoints: 5},
+		{Name: "flashFreeze", Row: 5, MaxPoints: 2, Prereq: "icicles"},
+		{Name: "iceBarrier", Row: 6, MaxPoints: 1, Prereq: "iceBlock"},
+	},
+}
+
+func init() {
+	core.RegisterTalentTrees(proto.Class_ClassMage, TalentTrees)
+}
+
 func (mage *Mage) ApplyTalents() {
 	mage.applyArcaneTalents()
 	mage.applyFireTalents()
@@ -8,19 +8,20 @@ import (
 
 func (paladin *Paladin) registerAvengingWrath() {
 	actionID := core.ActionID{SpellID: 407788}
+	damageMultiplier := 1.2
 
 	AvengingWrathAura := paladin.RegisterAura(core.Aura{
 		Label:    "Avenging Wrath",
 		ActionID: actionID,
 		Duration: time.Second * 20,
 		OnGain: func(aura *core.Aura, sim *core.Simulation) {
-			aura.Unit.PseudoStats.DamageDealtMultiplier *= 1.2
+			aura.Unit.PseudoStats.DamageDealtMultiplier *= damageMultiplier
 		},
 		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
-			aura.Unit.PseudoStats.DamageDealtMultiplier /= 1.2
+			aura.Unit.PseudoStats.DamageDealtMultiplier /= damageMultiplier
 		},
-	})
-	core.RegisterPercentDamageModifierEffect(AvengingWrathAura, 1.2)
+	}).AttachDamageAttribution(core.DamageAttribution{Multiplier: damageMultiplier})
+	core.RegisterPercentDamageModifierEffect(AvengingWrathAura, damageMultiplier)
 
 	AvengingWrath := paladin.RegisterSpell(core.SpellConfig{
 		ActionID: actionID,
@@ -1,6 +1,7 @@
 package paladin
 
 import (
+	"slices"
 	"time"
 
 	"github.com/isfir/wowsims-turtle/sim/core"
@@ -11,9 +12,11 @@ func (paladin *Paladin) registerDivineFavor() {
 		return
 	}
 
+	affectedSpellCodes := []int32{SpellCode_PaladinHolyShock, SpellCode_PaladinHolyShockHeal, SpellCode_PaladinHolyLight, SpellCode_PaladinFlashOfLight}
+
 	var affectedSpells []*core.Spell
 	paladin.OnSpellRegistered(func(spell *core.Spell) {
-		if spell.SpellCode == SpellCode_PaladinHolyShock {
+		if slices.Contains(affectedSpellCodes, spell.SpellCode) {
 			affectedSpells = append(affectedSpells, spell)
 		}
 	})
@@ -37,8 +40,8 @@ func (paladin *Paladin) registerDivineFavor() {
 				spell.BonusCritRating -= core.SpellCritRatingPerCritChance * 100
 			})
 		},
-		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
-			if spell.SpellCode != SpellCode_PaladinHolyShock {
+		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
+			if !slices.Contains(affectedSpellCodes, spell.SpellCode) {
 				return
 			}
 			// Remove the buff and put skill on CD
@@ -0,0 +1,118 @@
+package holy
+
+import (
+	"testing"
+
+	"github.com/isfir/wowsims-turtle/sim/core"
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+)
+
+func init() {
+	RegisterHolyPaladin()
+}
+
+func TestHoly(t *testing.T) {
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
+		{
+			Class:      proto.Class_ClassPaladin,
+			Phase:      1,
+			Race:       proto.Race_RaceHuman,
+			OtherRaces: []proto.Race{proto.Race_RaceDwarf},
+
+			Talents:     StandardTalents,
+			GearSet:     core.GetGearSet("../../../ui/holy_paladin/gear_sets", "blank"),
+			Rotation:    core.GetAplRotation("../../../ui/holy_paladin/apls", "default"),
+			Buffs:       core.FullBuffs,
+			Consumes:    FullConsumes,
+			SpecOptions: core.SpecOptionsCombo{Label: "Default", SpecOptions: PlayerOptionsDefault},
+
+			IsHealer: true,
+
+			ItemFilter:      ItemFilters,
+			EPReferenceStat: proto.Stat_StatHealingPower,
+			StatsToWeigh:    Stats,
+		},
+	}))
+}
+
+func TestHolyLightCompletes(t *testing.T) {
+	// A healer casting back to back 2.5s heals at a target dummy, which must not be
+	// interrupted by anything restarting the rotation mid-cast.
+	rotation := core.APLRotationFromJsonString(`{"type": "TypeAPL",
+		"priorityList": [
+			{"action": {"castSpell": {"spellId": {"spellId": 25292, "rank": 9}}}}
+		]}`)
+
+	result := core.RunRaidSim(&proto.RaidSimRequest{
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{{Players: []*proto.Player{{
+				Name:          "Holy",
+				Race:          proto.Race_RaceHuman,
+				Class:         proto.Class_ClassPaladin,
+				Equipment:     &proto.EquipmentSpec{},
+				Consumes:      &proto.Consumes{},
+				Spec:          PlayerOptionsDefault,
+				TalentsString: StandardTalents,
+				Rotation:      rotation,
+			}}}},
+			Buffs:         &proto.RaidBuffs{},
+			Debuffs:       &proto.Debuffs{},
+			TargetDummies: 1,
+		},
+		Encounter:  core.MakeSingleTargetEncounter(0),
+		SimOptions: &proto.SimOptions{Iterations: 10, RandomSeed: 101, IsTest: true},
+	})
+	if result.Error != nil {
+		t.Fatalf("Sim failed: %s", result.Error.Message)
+	}
+
+	var casts int32
+	for _, action := range result.RaidMetrics.Parties[0].Players[0].Actions {
+		if action.Id.GetSpellId() == 25292 {
+			for _, target := range action.Targets {
+				casts += target.Casts
+			}
+		}
+	}
+	// Without gear the paladin runs out of mana after about 10 casts per iteration.
+	if casts < 5*10 {
+		t.Fatalf("Expected Holy Light to complete at least 5 casts per iteration, got %d in total", casts)
+	}
+}
+
+var StandardTalents = "05503100521051-5"
+
+var PlayerOptionsDefault = &proto.Player_HolyPaladin{
+	HolyPaladin: &proto.HolyPaladin{
+		Options: &proto.PaladinOptions{},
+	},
+}
+
+var FullConsumes = core.ConsumesCombo{
+	Label: "Healer-Consumes",
+	Consumes: &proto.Consumes{
+		DefaultPotion: proto.Potions_MajorManaPotion,
+		Flask:         proto.Flask_FlaskOfDistilledWisdom,
+	},
+}
+
+var ItemFilters = core.ItemFilter{
+	WeaponTypes: []proto.WeaponType{
+		proto.WeaponType_WeaponTypeAxe,
+		proto.WeaponType_WeaponTypeSword,
+		proto.WeaponType_WeaponTypeMace,
+		proto.WeaponType_WeaponTypeShield,
+		proto.WeaponType_WeaponTypeOffHand,
+	},
+	RangedWeaponTypes: []proto.RangedWeaponType{
+		proto.RangedWeaponType_RangedWeaponTypeLibram,
+	},
+}
+
+var Stats = []proto.Stat{
+	proto.Stat_StatIntellect,
+	proto.Stat_StatSpirit,
+	proto.Stat_StatHealingPower,
+	proto.Stat_StatSpellCrit,
+	proto.Stat_StatMP5,
+}
@@ -17,12 +17,16 @@ func (paladin *Paladin) registerHolyShock() {
 		manaCost  float64
 		minDamage float64
 		maxDamage float64
+		healID    int32
 	}{
-		{level: 40, spellID: 20473, manaCost: 225, minDamage: 204, maxDamage: 220},
-		{level: 48, spellID: 20929, manaCost: 275, minDamage: 279, maxDamage: 301},
-		{level: 56, spellID: 20930, manaCost: 325, minDamage: 365, maxDamage: 395},
+		{level: 40, spellID: 20473, manaCost: 225, minDamage: 204, maxDamage: 220, healID: 25914},
+		{level: 48, spellID: 20929, manaCost: 275, minDamage: 279, maxDamage: 301, healID: 25913},
+		{level: 56, spellID: 20930, manaCost: 325, minDamage: 365, maxDamage: 395, healID: 25903},
 	}
 
+	// The damage and healing versions of Holy Shock share a cooldown.
+	cdTimer := paladin.NewTimer()
+
 	for i, rank := range ranks {
 		rank := rank
 		if paladin.Level < rank.level {
@@ -50,7 +54,7 @@ func (paladin *Paladin) registerHolyShock() {
 					GCD: core.GCDDefault,
 				},
 				CD: core.Cooldown{
-					Timer:    paladin.NewTimer(),
+					Timer:    cdTimer,
 					Duration: time.Second * 30,
 				},
 			},
@@ -64,5 +68,43 @@ func (paladin *Paladin) registerHolyShock() {
 				spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMagicHitAndCrit)
 			},
 		})
+
+		paladin.RegisterSpell(core.SpellConfig{
+			ActionID:    core.ActionID{SpellID: rank.healID},
+			SpellSchool: core.SpellSchoolHoly,
+			DefenseType: core.DefenseTypeMagic,
+			ProcMask:    core.ProcMaskSpellHealing,
+			Flags:       core.SpellFlagHelpful | core.SpellFlagAPL,
+
+			RequiredLevel: int(rank.level),
+			Rank:          i + 1,
+
+			SpellCode: SpellCode_PaladinHolyShockHeal,
+
+			ManaCost: core.ManaCostOptions{
+				FlatCost: rank.manaCost,
+			},
+
+			Cast: core.CastConfig{
+				DefaultCast: core.Cast{
+					GCD: core.GCDDefault,
+				},
+				CD: core.Cooldown{
+					Timer:    cdTimer,
+					Duration: time.Second * 30,
+				},
+			},
+
+			BonusCritRating: core.SpellCritRatingPerCritChance * float64(paladin.Talents.HolyPower),
+
+			DamageMultiplier: 1,
+			ThreatMultiplier: 1,
+			BonusCoefficient: 0.429,
+
+			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+				baseHealing := sim.Roll(rank.minDamage, rank.maxDamage)
+				spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+			},
+		})
 	}
 }
@@ -17,7 +17,10 @@ const (
 	SpellCode_PaladinNone = iota
 
 	SpellCode_PaladinExorcism
+	SpellCode_PaladinFlashOfLight
+	SpellCode_PaladinHolyLight
 	SpellCode_PaladinHolyShock
+	SpellCode_PaladinHolyShockHeal
 	SpellCode_PaladinHolyWrath
 	SpellCode_PaladinJudgementOfCommand
 	SpellCode_PaladinJudgementOfRighteousness
@@ -66,6 +69,8 @@ type Paladin struct {
 	holyShieldProc [3]*core.Spell
 	redoubtAura    *core.Aura
 	holyWrath      []*core.Spell
+	holyLight      []*core.Spell
+	flashOfLight   []*core.Spell
 
 	// highest rank seal spell if available
 	sealOfRighteousness *core.Spell
@@ -127,6 +132,11 @@ func (paladin *Paladin) Initialize() {
 	paladin.ResetCurrentPaladinAura()
 }
 
+func (paladin *Paladin) RegisterHealingSpells() {
+	paladin.registerHolyLight()
+	paladin.registerFlashOfLight()
+}
+
 func (paladin *Paladin) Reset(_ *core.Simulation) {
 	paladin.ResetCurrentPaladinAura()
 	paladin.ResetPrimarySeal(paladin.Options.PrimarySeal)
@@ -1,6 +1,7 @@
 package paladin
 
 import (
+	"slices"
 	"time"
 
 	"github.com/isfir/wowsims-turtle/sim/core"
@@ -8,6 +9,64 @@ import (
 	"github.com/isfir/wowsims-turtle/sim/core/stats"
 )
 
+// Layout of the talent trees, in the same order as the talent string.
+var TalentTrees = core.TalentTrees{
+	{ // Holy
+		{Name: "divineStrength", Row: 0, MaxPoints: 5},
+		{Name: "divineIntellect", Row: 0, MaxPoints: 5},
+		{Name: "spiritualFocus", Row: 1, MaxPoints: 5},
+		{Name: "improvedSealOfRighteousness", Row: 1, MaxPoints: 5},
+		{Name: "healingLight", Row: 2, MaxPoints: 3},
+		{Name: "consecration", Row: 2, MaxPoints: 1},
+		{Name: "improvedLayOnHands", Row: 2, MaxPoints: 2},
+		{Name: "unyieldingFaith", Row: 2, MaxPoints: 2},
+		{Name: "illumination", Row: 3, MaxPoints: 5},
+		{Name: "improvedBlessingOfWisdom", Row: 3, MaxPoints: 2},
+		{Name: "divineFavor", Row: 4, MaxPoints: 1, Prereq: "illumination"},
+		{Name: "lastingJudgement", Row: 4, MaxPoints: 3},
+		{Name: "holyPower", Row: 5, MaxPoints: 5},
+		{Name: "holyShock", Row: 6, MaxPoints: 1, Prereq: "divineFavor"},
+	},
+	{ // Protection
+		{Name: "improvedDevotionAura", Row: 0, MaxPoints: 5},
+		{Name: "redoubt", Row: 0, MaxPoints: 5},
+		{Name: "precision", Row: 1, MaxPoints: 3},
+		{Name: "guardiansFavor", Row: 1, MaxPoints: 2},
+		{Name: "toughness", Row: 1, MaxPoints: 5},
+		{Name: "blessingOfKings", Row: 2, MaxPoints: 1},
+		{Name: "improvedRighteousFury", Row: 2, MaxPoints: 3},
+		{Name: "shieldSpecialization", Row: 2, MaxPoints: 3, Prereq: "redoubt"},
+		{Name: "anticipation", Row: 2, MaxPoints: 5},
+		{Name: "improvedHammerOfJustice", Row: 3, MaxPoints: 3},
+		{Name: "improvedConcentrationAura", Row: 3, MaxPoints: 3},
+		{Name: "blessingOfSanctuary", Row: 4, MaxPoints: 1},
+		{Name: "reckoning", Row: 4, MaxPoints: 5},
+		{Name: "oneHandedWeaponSpecialization", Row: 5, MaxPoints: 5},
+		{Name: "holyShield", Row: 6, MaxPoints: 1, Prereq: "blessingOfSanctuary"},
+	},
+	{ // Retribution
+		{Name: "improvedBlessingOfMight", Row: 0, MaxPoints: 5},
+		{Name: "benediction", Row: 0, MaxPoints: 5},
+		{Name: "improvedJudgement", Row: 1, MaxPoints: 2},
+		{Name: "improvedSealOfTheCrusader", Row: 1, MaxPoints: 3},
+		{Name: "deflection", Row: 1, MaxPoints: 5},
+		{Name: "vindication", Row: 2, MaxPoints: 3},
+		{Name: "conviction", Row: 2, MaxPoints: 5},
+		{Name: "sealOfCommand", Row: 2, MaxPoints: 1},
+		{Name: "pursuitOfJustice", Row: 2, MaxPoints: 2},
+		{Name: "eyeForAnEye", Row: 3, MaxPoints: 2},
+		{Name: "improvedRetributionAura", Row: 3, MaxPoints: 2},
+		{Name: "twoHandedWeaponSpecialization", Row: 4, MaxPoints: 3},
+		{Name: "sanctityAura", Row: 4, MaxPoints: 1},
+		{Name: "vengeance", Row: 5, MaxPoints: 5, Prereq: "conviction"},
+		{Name: "repentance", Row: 6, MaxPoints: 1},
+	},
+}
+
+func init() {
+	core.RegisterTalentTrees(proto.Class_ClassPaladin, TalentTrees)
+}
+
 func (paladin *Paladin) ApplyTalents() {
 	paladin.AddStat(stats.MeleeHit, float64(paladin.Talents.Precision)*core.MeleeHitRatingPerHitChance)
 	// TODO: paladin.AddStat(stats.RangedHit, float64(paladin.Talents.Precision)*core.MeleeHitRatingPerHitChance)
@@ -42,12 +101,18 @@ func (paladin *Paladin) ApplyTalents() {
 	paladin.applyRedoubt()
 	paladin.applyReckoning()
 	paladin.applyImprovedLayOnHands()
+	paladin.applyIllumination()
 }
 
 func (paladin *Paladin) improvedSoR() float64 {
 	return []float64{1, 1.03, 1.06, 1.09, 1.12, 1.15}[paladin.Talents.ImprovedSealOfRighteousness]
 }
 
+// Healing Light increases the amount healed by Holy Light and Flash of Light by 4% per point.
+func (paladin *Paladin) healingLight() float64 {
+	return 1 + 0.04*float64(paladin.Talents.HealingLight)
+}
+
 func (paladin *Paladin) benediction() int32 {
 	return []int32{100, 97, 94, 91, 88, 85}[paladin.Talents.Benediction]
 }
@@ -149,7 +214,7 @@ func (paladin *Paladin) applyVengeance() {
 			aura.Unit.PseudoStats.SchoolDamageDealtMultiplier[stats.SchoolIndexHoly] /= vengeanceMultiplier
 			aura.Unit.PseudoStats.SchoolDamageDealtMultiplier[stats.SchoolIndexPhysical] /= vengeanceMultiplier
 		},
-	})
+	}).AttachDamageAttribution(core.DamageAttribution{Multiplier: vengeanceMultiplier, School: core.SpellSchoolHoly | core.SpellSchoolPhysical})
 
 	paladin.RegisterAura(core.Aura{
 		Label:    "Vengeance",
@@ -234,3 +299,27 @@ func (paladin *Paladin) applyImprovedLayOnHands() {
 		})
 	}
 }
+
+func (paladin *Paladin) applyIllumination() {
+	if paladin.Talents.Illumination == 0 {
+		return
+	}
+
+	actionID := core.ActionID{SpellID: 20215}
+	manaMetrics := paladin.NewManaMetrics(actionID)
+	procChance := 0.2 * float64(paladin.Talents.Illumination)
+	affectedSpellCodes := []int32{SpellCode_PaladinHolyLight, SpellCode_PaladinFlashOfLight, SpellCode_PaladinHolyShockHeal}
+
+	core.MakePermanent(paladin.RegisterAura(core.Aura{
+		Label:    "Illumination",
+		ActionID: actionID,
+		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if !result.DidCrit() || !slices.Contains(affectedSpellCodes, spell.SpellCode) {
+				return
+			}
+			if sim.Proc(procChance, "Illumination") {
+				paladin.AddMana(sim, spell.Cost.BaseCost, manaMetrics)
+			}
+		},
+	}))
+}
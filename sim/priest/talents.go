@@ -5,9 +5,71 @@ import (
 	"time"
 
 	"github.com/isfir/wowsims-turtle/sim/core"
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
 	"github.com/isfir/wowsims-turtle/sim/core/stats"
 )
 
+// Layout of the talent trees, in the same order as the talent string.
+var TalentTrees = core.TalentTrees{
+	{ // Discipline
+		{Name: "unbreakableWill", Row: 0, MaxPoints: 5},
+		{Name: "wandSpecialization", Row: 0, MaxPoints: 5},
+		{Name: "silentResolve", Row: 1, MaxPoints: 5},
+		{Name: "improvedPowerWordFortitude", Row: 1, MaxPoints: 2},
+		{Name: "improvedPowerWordShield", Row: 1, MaxPoints: 3},
+		{Name: "martyrdom", Row: 1, MaxPoints: 2},
+		{Name: "innerFocus", Row: 2, MaxPoints: 1},
+		{Name: "meditation", Row: 2, MaxPoints: 3},
+		{Name: "improvedInnerFire", Row: 3, MaxPoints: 3},
+		{Name: "mentalAgility", Row: 3, MaxPoints: 5},
+		{Name: "improvedManaBurn", Row: 3, MaxPoints: 2},
+		{Name: "mentalStrength", Row: 4, MaxPoints: 5},
+		{Name: "divineSpirit", Row: 4, MaxPoints: 1, Prereq: "meditation"},
+		{Name: "forceOfWill", Row: 5, MaxPoints: 5},
+		{Name: "powerInfusion", Row: 6, MaxPoints: 1, Prereq: "mentalStrength"},
+	},
+	{ // Holy
+		{Name: "healingFocus", Row: 0, MaxPoints: 2},
+		{Name: "improvedRenew", Row: 0, MaxPoints: 3},
+		{Name: "holySpecialization", Row: 0, MaxPoints: 5},
+		{Name: "spellWarding", Row: 1, MaxPoints: 5},
+		{Name: "divineFury", Row: 1, MaxPoints: 5},
+		{Name: "holyNova", Row: 2, MaxPoints: 1},
+		{Name: "blessedRecovery", Row: 2, MaxPoints: 3},
+		{Name: "inspiration", Row: 2, MaxPoints: 3},
+		{Name: "holyReach", Row: 3, MaxPoints: 2},
+		{Name: "improvedHealing", Row: 3, MaxPoints: 3},
+		{Name: "searingLight", Row: 3, MaxPoints: 2, Prereq: "divineFury"},
+		{Name: "improvedPrayerOfHealing", Row: 4, MaxPoints: 2},
+		{Name: "spiritOfRedemption", Row: 4, MaxPoints: 1},
+		{Name: "spiritualGuidance", Row: 4, MaxPoints: 5},
+		{Name: "spiritualHealing", Row: 5, MaxPoints: 5},
+		{Name: "lightwell", Row: 6, MaxPoints: 1, Prereq: "spiritOfRedemption"},
+	},
+	{ // Shadow
+		{Name: "spiritTap", Row: 0, MaxPoints: 5},
+		{Name: "blackout", Row: 0, MaxPoints: 5},
+		{Name: "shadowAffinity", Row: 1, MaxPoints: 3},
+		{Name: "improvedShadowWordPain", Row: 1, MaxPoints: 2},
+		{Name: "shadowFocus", Row: 1, MaxPoints: 5},
+		{Name: "improvedPsychicScream", Row: 2, MaxPoints: 2},
+		{Name: "improvedMindBlast", Row: 2, MaxPoints: 5},
+		{Name: "mindFlay", Row: 2, MaxPoints: 1},
+		{Name: "improvedFade", Row: 3, MaxPoints: 2},
+		{Name: "shadowReach", Row: 3, MaxPoints: 3},
+		{Name: "shadowWeaving", Row: 3, MaxPoints: 5},
+		{Name: "silence", Row: 4, MaxPoints: 1, Prereq: "improvedPsychicScream"},
+		{Name: "vampiricEmbrace", Row: 4, MaxPoints: 1},
+		{Name: "improvedVampiricEmbrace", Row: 4, MaxPoints: 2, Prereq: "vampiricEmbrace"},
+		{Name: "darkness", Row: 5, MaxPoints: 5},
+		{Name: "shadowform", Row: 6, MaxPoints: 1, Prereq: "vampiricEmbrace"},
+	},
+}
+
+func init() {
+	core.RegisterTalentTrees(proto.Class_ClassPriest, TalentTrees)
+}
+
 func (priest *Priest) ApplyTalents() {
 	// Discipline
 	priest.registerInnerFocus()
@@ -326,22 +388,24 @@ func (priest *Priest) registerShadowform() {
 
 	//To Do: Add physical damage resistance
 
+	shadowMultiplier := 1.15
+
 	priest.ShadowformAura = priest.RegisterAura(core.Aura{
 		Label:    "Shadowform",
 		ActionID: actionID,
 		Duration: core.NeverExpires,
 		OnGain: func(aura *core.Aura, sim *core.Simulation) {
-			aura.Unit.PseudoStats.SchoolDamageDealtMultiplier[stats.SchoolIndexShadow] *= 1.15
+			aura.Unit.PseudoStats.SchoolDamageDealtMultiplier[stats.SchoolIndexShadow] *= shadowMultiplier
 		},
 		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
-			aura.Unit.PseudoStats.SchoolDamageDealtMultiplier[stats.SchoolIndexShadow] /= 1.15
+			aura.Unit.PseudoStats.SchoolDamageDealtMultiplier[stats.SchoolIndexShadow] /= shadowMultiplier
 		},
 		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
 			if spell.SpellSchool.Matches(core.SpellSchoolHoly) {
 				aura.Deactivate(sim)
 			}
 		},
-	})
+	}).AttachDamageAttribution(core.DamageAttribution{Multiplier: shadowMultiplier, School: core.SpellSchoolShadow})
 
 	priest.Shadowform = priest.RegisterSpell(core.SpellConfig{
 		ActionID: actionID,
@@ -10,19 +10,19 @@ import (
 	"github.com/isfir/wowsims-turtle/sim/shaman/warden"
 
 	"github.com/isfir/wowsims-turtle/sim/druid/feral"
-	// restoDruid "github.com/isfir/wowsims-turtle/sim/druid/restoration"
-	// feralTank "github.com/isfir/wowsims-turtle/sim/druid/tank"
+	restoDruid "github.com/isfir/wowsims-turtle/sim/druid/restoration"
+	feralTank "github.com/isfir/wowsims-turtle/sim/druid/tank"
 	_ "github.com/isfir/wowsims-turtle/sim/encounters"
 	"github.com/isfir/wowsims-turtle/sim/hunter"
 	"github.com/isfir/wowsims-turtle/sim/mage"
 
-	// holyPaladin "github.com/isfir/wowsims-turtle/sim/paladin/holy"
+	holyPaladin "github.com/isfir/wowsims-turtle/sim/paladin/holy"
 	"github.com/isfir/wowsims-turtle/sim/paladin/protection"
 	// "github.com/isfir/wowsims-turtle/sim/paladin/retribution"
 	// healingPriest "github.com/isfir/wowsims-turtle/sim/priest/healing"
 	"github.com/isfir/wowsims-turtle/sim/priest/shadow"
 
-	// restoShaman "github.com/isfir/wowsims-turtle/sim/shaman/restoration"
+	restoShaman "github.com/isfir/wowsims-turtle/sim/shaman/restoration"
 	dpsWarlock "github.com/isfir/wowsims-turtle/sim/warlock/dps"
 	dpsWarrior "github.com/isfir/wowsims-turtle/sim/warrior/dps_warrior"
 	tankWarrior "github.com/isfir/wowsims-turtle/sim/warrior/tank_warrior"
@@ -38,12 +38,12 @@ func RegisterAll() {
 
 	balance.RegisterBalanceDruid()
 	feral.RegisterFeralDruid()
-	// feralTank.RegisterFeralTankDruid()
-	// restoDruid.RegisterRestorationDruid()
+	feralTank.RegisterFeralTankDruid()
+	restoDruid.RegisterRestorationDruid()
 	elemental.RegisterElementalShaman()
 	enhancement.RegisterEnhancementShaman()
 	warden.RegisterWardenShaman()
-	// restoShaman.RegisterRestorationShaman()
+	restoShaman.RegisterRestorationShaman()
 	hunter.RegisterHunter()
 	mage.RegisterMage()
 	// healingPriest.RegisterHealingPriest()
@@ -51,7 +51,7 @@ func RegisterAll() {
 	dpsrogue.RegisterDpsRogue()
 	dpsWarrior.RegisterDpsWarrior()
 	tankWarrior.RegisterTankWarrior()
-	// holyPaladin.RegisterHolyPaladin()
+	holyPaladin.RegisterHolyPaladin()
 	protection.RegisterProtectionPaladin()
 	retribution.RegisterRetributionPaladin()
 	dpsWarlock.RegisterDpsWarlock()
@@ -97,3 +97,86 @@ var Phase1Consumes = core.ConsumesCombo{
 		AttackPowerBuff: proto.AttackPowerBuff_JujuMight,
 	},
 }
+
+func TestPoisonCharges(t *testing.T) {
+	// Rank 1 Instant Poison on a fast off hand runs out of charges well before the end of the fight.
+	options := &proto.Player_Rogue{
+		Rogue: &proto.Rogue{
+			Options: &proto.RogueOptions{
+				OffHandPoison:     proto.RogueOptions_InstantPoison,
+				OffHandPoisonRank: 1,
+			},
+		},
+	}
+	noReapply := core.APLRotationFromJsonString(`{"type": "TypeAPL", "priorityList": []}`)
+	reapply := core.APLRotationFromJsonString(`{"type": "TypeAPL",
+		"priorityList": [
+			{"action": {"condition": {"not": {"val": {"auraIsActive": {"auraId": {"otherId": "OtherActionApplyPoison", "tag": 2}}}}}, "castSpell": {"spellId": {"otherId": "OtherActionApplyPoison", "tag": 2}}}}
+		]}`)
+
+	runSim := func(rotation *proto.APLRotation) *proto.UnitMetrics {
+		equipment := &proto.EquipmentSpec{}
+		for slot := proto.ItemSlot_ItemSlotHead; slot <= proto.ItemSlot_ItemSlotRanged; slot++ {
+			equipment.Items = append(equipment.Items, &proto.ItemSpec{})
+		}
+		equipment.Items[proto.ItemSlot_ItemSlotMainHand].Id = 900001
+		equipment.Items[proto.ItemSlot_ItemSlotOffHand].Id = 900002
+
+		player := &proto.Player{
+			Name:          "Rogue",
+			Race:          proto.Race_RaceHuman,
+			Class:         proto.Class_ClassRogue,
+			Equipment:     equipment,
+			Spec:          options,
+			TalentsString: CombatDaggersTalents,
+			Rotation:      rotation,
+			Database: &proto.SimDatabase{Items: []*proto.SimItem{
+				{Id: 900001, Name: "Test Dagger", Type: proto.ItemType_ItemTypeWeapon, WeaponType: proto.WeaponType_WeaponTypeDagger, HandType: proto.HandType_HandTypeOneHand, WeaponDamageMin: 50, WeaponDamageMax: 90, WeaponSpeed: 1.8},
+				{Id: 900002, Name: "Test Off Hand Dagger", Type: proto.ItemType_ItemTypeWeapon, WeaponType: proto.WeaponType_WeaponTypeDagger, HandType: proto.HandType_HandTypeOneHand, WeaponDamageMin: 30, WeaponDamageMax: 50, WeaponSpeed: 1.0},
+			}},
+		}
+		result := core.RunRaidSim(&proto.RaidSimRequest{
+			Raid:       core.SinglePlayerRaidProto(player, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+			Encounter:  core.MakeSingleTargetEncounter(0),
+			SimOptions: &proto.SimOptions{Iterations: 10, RandomSeed: 101, IsTest: true},
+		})
+		if result.Error != nil {
+			t.Fatalf("Sim failed: %s", result.Error.Message)
+		}
+		return result.RaidMetrics.Parties[0].Players[0]
+	}
+
+	poisonUptimes := func(metrics *proto.UnitMetrics) map[int32]float64 {
+		uptimes := make(map[int32]float64)
+		for _, aura := range metrics.Auras {
+			if aura.Id.GetOtherId() == proto.OtherAction_OtherActionApplyPoison {
+				uptimes[aura.Id.Tag] = aura.UptimeSecondsAvg
+			}
+		}
+		return uptimes
+	}
+
+	uptimes := poisonUptimes(runSim(noReapply))
+	if uptimes[2] >= core.LongDuration || uptimes[4] == 0 {
+		t.Fatalf("Expected the off hand poison to run out of charges, got uptimes %v", uptimes)
+	}
+	if _, ok := uptimes[1]; ok {
+		t.Fatalf("Expected no main hand poison, got uptimes %v", uptimes)
+	}
+
+	metrics := runSim(reapply)
+	var reapplyCasts int32
+	for _, action := range metrics.Actions {
+		if action.Id.GetOtherId() == proto.OtherAction_OtherActionApplyPoison && action.Id.Tag == 2 {
+			for _, target := range action.Targets {
+				reapplyCasts += target.Casts
+			}
+		}
+	}
+	if reapplyCasts == 0 {
+		t.Fatalf("Expected the off hand poison to be reapplied")
+	}
+	if reapplied := poisonUptimes(metrics); reapplied[4] >= uptimes[4] {
+		t.Fatalf("Expected reapplying to reduce the uptime lost, got %0.2fs and %0.2fs before", reapplied[4], uptimes[4])
+	}
+}
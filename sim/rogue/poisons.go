@@ -1,6 +1,7 @@
 package rogue
 
 import (
+	"fmt"
 	"strconv"
 	"time"
 
@@ -8,32 +9,64 @@ import (
 	"github.com/isfir/wowsims-turtle/sim/core/proto"
 )
 
-/**
-Instant Poison: 20% proc chance
-25: 22 +/- 3 damage, 8679 ID, 40 charges
-40: 50 +/- 6 damage, 8688 ID, 70 charges
-50: 76 +/- 9 damage, 11338 ID, 85 charges
-60: 130 =/- 18 damage, 11340 ID, 115 charges
-
-Deadly Poison: 30% proc chance, 5 stacks
-40: 52 damage, 2824 ID, 75 charges
-50: 80 damage, 11355 ID, 90 charges
-60: 108 damage, 11356 ID, 105 charges (Rank 4, Rank 5 is by book)
+// The level a poison rank is learned at, its spell and the charges it applies to a weapon.
+type poisonRank struct {
+	level   int32
+	spellID int32
+	charges int32
+}
 
-Wound Poison: 30% proc chance, 5 stacks
-25: x damage, x ID (none, first rank is level 32)
-40: -75 healing, 11325 ID, 75 charges (Rank 2)
-50: -105 healing, 13226 ID, 90 charges (Rank 3)
-60: -135 healing, 13227 ID, 105 charges (Rank 4)
-*/
+var instantPoisonRanks = []poisonRank{
+	{},
+	{level: 20, spellID: 8679, charges: 40},
+	{level: 28, spellID: 8686, charges: 55},
+	{level: 36, spellID: 8688, charges: 70},
+	{level: 44, spellID: 11338, charges: 85},
+	{level: 52, spellID: 11339, charges: 100},
+	{level: 60, spellID: 11340, charges: 115},
+}
+var instantPoisonDamage = [][2]float64{{0, 0}, {19, 25}, {30, 38}, {44, 56}, {67, 85}, {92, 118}, {112, 148}}
+
+var deadlyPoisonRanks = []poisonRank{
+	{},
+	{level: 30, spellID: 2823, charges: 60},
+	{level: 38, spellID: 2824, charges: 75},
+	{level: 46, spellID: 11355, charges: 90},
+	{level: 54, spellID: 11356, charges: 105},
+	{level: 60, spellID: 25347, charges: 120}, // Learned from a book in AQ
+}
+var deadlyPoisonTickDamage = []float64{0, 9, 13, 20, 27, 34}
+
+var woundPoisonRanks = []poisonRank{
+	{},
+	{level: 32, spellID: 13219, charges: 60},
+	{level: 40, spellID: 13225, charges: 75},
+	{level: 48, spellID: 13226, charges: 90},
+	{level: 56, spellID: 13227, charges: 105},
+}
 
-// TODO: Add charges to poisons
+func getPoisonRanks(poisonType proto.RogueOptions_PoisonType) []poisonRank {
+	switch poisonType {
+	case proto.RogueOptions_InstantPoison:
+		return instantPoisonRanks
+	case proto.RogueOptions_DeadlyPoison:
+		return deadlyPoisonRanks
+	case proto.RogueOptions_WoundPoison:
+		return woundPoisonRanks
+	}
+	return nil
+}
 
-type PoisonProcSource int
+// The poison on one of the rogue's weapons. Its remaining charges are the stacks of its
+// aura, and it can be reapplied through the APL.
+type WeaponPoison struct {
+	Type proto.RogueOptions_PoisonType
+	Rank int32
 
-const (
-	NormalProc PoisonProcSource = iota
-)
+	Aura         *core.Aura  // Active while the weapon has charges left
+	DepletedAura *core.Aura  // Active while the weapon has no charges left, to track the uptime lost
+	ReapplySpell *core.Spell // Applies the poison again with full charges
+}
 
 func (rogue *Rogue) GetInstantPoisonProcChance() float64 {
 	return 0.2 + rogue.improvedPoisons() + rogue.additivePoisonBonusChance
@@ -59,152 +92,189 @@ func (rogue *Rogue) getPoisonDamageMultiplier() float64 {
 //                               Apply Poisons
 ///////////////////////////////////////////////////////////////////////////
 
-func (rogue *Rogue) applyPoisons() {
-	rogue.applyDeadlyPoison()
-	rogue.applyInstantPoison()
-	rogue.applyWoundPoison()
+func (rogue *Rogue) registerPoisons() {
+	rogue.poisonSpells = make(map[poisonSpellKey]*core.Spell)
+	rogue.registerWoundPoisonDebuffs()
+
+	if rogue.HasMHWeapon() {
+		rogue.MainHandPoison = rogue.registerWeaponPoison(core.MainHand)
+	}
+	if rogue.HasOHWeapon() {
+		rogue.OffHandPoison = rogue.registerWeaponPoison(core.OffHand)
+	}
 }
 
-// Apply Instant Poison to weapon and enable procs
-func (rogue *Rogue) applyInstantPoison() {
-	procMask := rogue.getImbueProcMask(proto.WeaponImbue_InstantPoison)
-	if procMask == core.ProcMaskUnknown {
-		return
+// Returns the poison type and rank of a weapon, using its imbue consumable if the
+// options don't pick a poison.
+func (rogue *Rogue) getWeaponPoisonOptions(hand core.Hand) (proto.RogueOptions_PoisonType, int32) {
+	poisonType := rogue.Options.GetOffHandPoison()
+	rank := rogue.Options.GetOffHandPoisonRank()
+	imbue := rogue.Consumes.OffHandImbue
+	if hand == core.MainHand {
+		poisonType = rogue.Options.GetMainHandPoison()
+		rank = rogue.Options.GetMainHandPoisonRank()
+		imbue = rogue.Consumes.MainHandImbue
 	}
 
-	rogue.RegisterAura(core.Aura{
-		Label:    "Instant Poison",
-		Duration: core.NeverExpires,
-		OnReset: func(aura *core.Aura, sim *core.Simulation) {
-			aura.Activate(sim)
-		},
-		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
-			if !result.Landed() || !spell.ProcMask.Matches(procMask) {
-				return
-			}
+	if poisonType == proto.RogueOptions_ConsumablePoison {
+		switch imbue {
+		case proto.WeaponImbue_InstantPoison:
+			poisonType = proto.RogueOptions_InstantPoison
+		case proto.WeaponImbue_DeadlyPoison:
+			poisonType = proto.RogueOptions_DeadlyPoison
+		case proto.WeaponImbue_WoundPoison:
+			poisonType = proto.RogueOptions_WoundPoison
+		default:
+			poisonType = proto.RogueOptions_NoPoison
+		}
+	}
 
-			if sim.RandomFloat("Instant Poison") < rogue.GetInstantPoisonProcChance() {
-				rogue.InstantPoison.Cast(sim, result.Target)
+	ranks := getPoisonRanks(poisonType)
+	if ranks == nil {
+		return proto.RogueOptions_NoPoison, 0
+	}
+
+	if rank <= 0 || int(rank) >= len(ranks) {
+		rank = 1
+		for i := 2; i < len(ranks); i++ {
+			if ranks[i].level <= rogue.Level {
+				rank = int32(i)
 			}
-		},
-	})
+		}
+		if poisonType == proto.RogueOptions_DeadlyPoison && !core.IncludeAQ {
+			rank = min(rank, 4)
+		}
+	}
+	return poisonType, rank
 }
 
-// Apply Deadly Poison to weapon and enable procs
-func (rogue *Rogue) applyDeadlyPoison() {
-	procMask := rogue.getImbueProcMask(proto.WeaponImbue_DeadlyPoison)
-	if procMask == core.ProcMaskUnknown {
-		return
+func (rogue *Rogue) registerWeaponPoison(hand core.Hand) *WeaponPoison {
+	poisonType, rank := rogue.getWeaponPoisonOptions(hand)
+	if poisonType == proto.RogueOptions_NoPoison {
+		return nil
+	}
+
+	procMask := core.ProcMaskMeleeMH
+	tag := int32(1)
+	handName := "Main Hand"
+	if hand == core.OffHand {
+		procMask = core.ProcMaskMeleeOH
+		tag = 2
+		handName = "Off Hand"
+	}
+
+	var poisonName string
+	var procChance func() float64
+	switch poisonType {
+	case proto.RogueOptions_InstantPoison:
+		poisonName = "Instant Poison"
+		procChance = rogue.GetInstantPoisonProcChance
+	case proto.RogueOptions_DeadlyPoison:
+		poisonName = "Deadly Poison"
+		procChance = rogue.GetDeadlyPoisonProcChance
+	case proto.RogueOptions_WoundPoison:
+		poisonName = "Wound Poison"
+		procChance = rogue.GetWoundPoisonProcChance
+	}
+
+	procSpell := rogue.getPoisonSpell(poisonType, rank)
+	charges := getPoisonRanks(poisonType)[rank].charges
+
+	weaponPoison := &WeaponPoison{
+		Type: poisonType,
+		Rank: rank,
 	}
 
-	rogue.RegisterAura(core.Aura{
-		Label:    "Deadly Poison",
+	weaponPoison.DepletedAura = rogue.RegisterAura(core.Aura{
+		Label:    "Poison Depleted (" + handName + ")",
+		ActionID: core.ActionID{OtherID: proto.OtherAction_OtherActionApplyPoison, Tag: tag + 2},
 		Duration: core.NeverExpires,
+	})
+
+	weaponPoison.Aura = rogue.RegisterAura(core.Aura{
+		Label:     poisonName + " (" + handName + ")",
+		ActionID:  core.ActionID{OtherID: proto.OtherAction_OtherActionApplyPoison, Tag: tag},
+		Duration:  core.NeverExpires,
+		MaxStacks: charges,
 		OnReset: func(aura *core.Aura, sim *core.Simulation) {
+			// Poisons are applied out of combat, before the pull.
 			aura.Activate(sim)
+			aura.SetStacks(sim, aura.MaxStacks)
 		},
 		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
 			if !result.Landed() || !spell.ProcMask.Matches(procMask) {
 				return
 			}
-			if sim.RandomFloat("Deadly Poison") < rogue.GetDeadlyPoisonProcChance() {
-				rogue.DeadlyPoison.Cast(sim, result.Target)
+
+			if sim.RandomFloat(poisonName) < procChance() {
+				procSpell.Cast(sim, result.Target)
+
+				// Every proc uses a charge, even if the poison is resisted.
+				aura.RemoveStack(sim)
+				if aura.GetStacks() == 0 {
+					aura.Deactivate(sim)
+					weaponPoison.DepletedAura.Activate(sim)
+				}
 			}
 		},
 	})
-}
 
-// Apply Wound Poison to weapon and enable procs
-func (rogue *Rogue) applyWoundPoison() {
-	procMask := rogue.getImbueProcMask(proto.WeaponImbue_WoundPoison)
-	if procMask == core.ProcMaskUnknown {
-		return
-	}
+	weaponPoison.ReapplySpell = rogue.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{OtherID: proto.OtherAction_OtherActionApplyPoison, Tag: tag},
+		Flags:    core.SpellFlagAPL | core.SpellFlagResetAttackSwing,
 
-	rogue.RegisterAura(core.Aura{
-		Label:    "Wound Poison",
-		Duration: core.NeverExpires,
-		OnReset: func(aura *core.Aura, sim *core.Simulation) {
-			aura.Activate(sim)
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      time.Second,
+				CastTime: time.Second * 3,
+			},
+			IgnoreHaste: true,
 		},
-		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
-			if !result.Landed() || !spell.ProcMask.Matches(procMask) {
-				return
-			}
 
-			if sim.RandomFloat("Wound Poison") < rogue.GetWoundPoisonProcChance() {
-				rogue.WoundPoison.Cast(sim, result.Target)
-			}
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return weaponPoison.Aura.GetStacks() < weaponPoison.Aura.MaxStacks
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			weaponPoison.DepletedAura.Deactivate(sim)
+			weaponPoison.Aura.Activate(sim)
+			weaponPoison.Aura.SetStacks(sim, weaponPoison.Aura.MaxStacks)
 		},
 	})
+
+	return weaponPoison
 }
 
 ///////////////////////////////////////////////////////////////////////////
 //                              Register Poisons
 ///////////////////////////////////////////////////////////////////////////
 
-func (rogue *Rogue) registerInstantPoisonSpell() {
-	rogue.InstantPoison = rogue.makeInstantPoison()
+type poisonSpellKey struct {
+	poisonType proto.RogueOptions_PoisonType
+	rank       int32
 }
 
-func (rogue *Rogue) registerDeadlyPoisonSpell() {
-	baseDamageTick := map[int32]float64{
-		25: 9,
-		40: 13,
-		50: 20,
-		60: core.TernaryFloat64(core.IncludeAQ, 34, 27),
-	}[rogue.Level]
-	spellID := map[int32]int32{
-		25: 2823,
-		40: 2824,
-		50: 11355,
-		60: core.TernaryInt32(core.IncludeAQ, 25347, 11356),
-	}[rogue.Level]
-
-	rogue.deadlyPoisonTick = rogue.RegisterSpell(core.SpellConfig{
-		ActionID:    core.ActionID{SpellID: spellID, Tag: 100},
-		SpellSchool: core.SpellSchoolNature,
-		DefenseType: core.DefenseTypeMagic,
-		ProcMask:    core.ProcMaskSpellDamageProc,
-		Flags:       core.SpellFlagPoison | core.SpellFlagPassiveSpell | SpellFlagRoguePoison,
-
-		DamageMultiplier: rogue.getPoisonDamageMultiplier(),
-		ThreatMultiplier: 1,
-
-		Dot: core.DotConfig{
-			Aura: core.Aura{
-				Label:     "DeadlyPoison",
-				MaxStacks: 5,
-				Duration:  time.Second * 12,
-			},
-			NumberOfTicks: 4,
-			TickLength:    time.Second * 3,
-
-			OnSnapshot: func(sim *core.Simulation, target *core.Unit, dot *core.Dot, applyStack bool) {
-				if !applyStack {
-					return
-				}
-
-				// only the first stack snapshots the multiplier
-				if dot.GetStacks() == 1 {
-					attackTable := dot.Spell.Unit.AttackTables[target.UnitIndex][dot.Spell.CastType]
-					dot.SnapshotAttackerMultiplier = dot.Spell.AttackerDamageMultiplier(attackTable, true)
-					dot.SnapshotBaseDamage = 0
-				}
-
-				dot.SnapshotBaseDamage += baseDamageTick
-			},
-
-			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
-				dot.CalcAndDealPeriodicSnapshotDamage(sim, target, dot.OutcomeTick)
-			},
-		},
-	})
+// Returns the proc spell of a poison rank, which is shared by both weapons.
+func (rogue *Rogue) getPoisonSpell(poisonType proto.RogueOptions_PoisonType, rank int32) *core.Spell {
+	key := poisonSpellKey{poisonType: poisonType, rank: rank}
+	if spell, ok := rogue.poisonSpells[key]; ok {
+		return spell
+	}
 
-	rogue.DeadlyPoison = rogue.makeDeadlyPoison()
+	var spell *core.Spell
+	switch poisonType {
+	case proto.RogueOptions_InstantPoison:
+		spell = rogue.makeInstantPoison(rank)
+	case proto.RogueOptions_DeadlyPoison:
+		spell = rogue.makeDeadlyPoison(rank)
+	case proto.RogueOptions_WoundPoison:
+		spell = rogue.makeWoundPoison(rank)
+	}
+	rogue.poisonSpells[key] = spell
+	return spell
 }
 
-func (rogue *Rogue) registerWoundPoisonSpell() {
+func (rogue *Rogue) registerWoundPoisonDebuffs() {
 	woundPoisonDebuffAura := core.Aura{
 		Label:     "WoundPoison-" + strconv.Itoa(int(rogue.Index)),
 		ActionID:  core.ActionID{SpellID: 13219},
@@ -221,38 +291,18 @@ func (rogue *Rogue) registerWoundPoisonSpell() {
 	rogue.woundPoisonDebuffAuras = rogue.NewEnemyAuraArray(func(target *core.Unit) *core.Aura {
 		return target.RegisterAura(woundPoisonDebuffAura)
 	})
-	rogue.WoundPoison = rogue.makeWoundPoison()
 }
 
 ///////////////////////////////////////////////////////////////////////////
 //                              Make Poisons
 ///////////////////////////////////////////////////////////////////////////
 
-// Make a source based variant of Instant Poison
-func (rogue *Rogue) makeInstantPoison() *core.Spell {
-	baseDamageByLevel := map[int32]float64{
-		25: 19,
-		40: 44,
-		50: 67,
-		60: 112,
-	}[rogue.Level]
-
-	damageVariance := map[int32]float64{
-		25: 6,
-		40: 12,
-		50: 18,
-		60: 36,
-	}[rogue.Level]
-
-	spellID := map[int32]int32{
-		25: 8679,
-		40: 8688,
-		50: 11338,
-		60: 11340,
-	}[rogue.Level]
+func (rogue *Rogue) makeInstantPoison(rank int32) *core.Spell {
+	baseDamageLow := instantPoisonDamage[rank][0]
+	baseDamageHigh := instantPoisonDamage[rank][1]
 
 	return rogue.RegisterSpell(core.SpellConfig{
-		ActionID:    core.ActionID{SpellID: spellID},
+		ActionID:    core.ActionID{SpellID: instantPoisonRanks[rank].spellID},
 		SpellSchool: core.SpellSchoolNature,
 		DefenseType: core.DefenseTypeMagic,
 		ProcMask:    core.ProcMaskSpellDamageProc,
@@ -262,15 +312,58 @@ func (rogue *Rogue) makeInstantPoison() *core.Spell {
 		ThreatMultiplier: 1,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			baseDamage := sim.Roll(baseDamageByLevel, baseDamageByLevel+damageVariance)
+			baseDamage := sim.Roll(baseDamageLow, baseDamageHigh)
 			spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMagicHitAndCrit)
 		},
 	})
 }
 
-func (rogue *Rogue) makeDeadlyPoison() *core.Spell {
+func (rogue *Rogue) makeDeadlyPoison(rank int32) *core.Spell {
+	baseDamageTick := deadlyPoisonTickDamage[rank]
+	spellID := deadlyPoisonRanks[rank].spellID
+
+	tickSpell := rogue.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: spellID, Tag: 100},
+		SpellSchool: core.SpellSchoolNature,
+		DefenseType: core.DefenseTypeMagic,
+		ProcMask:    core.ProcMaskSpellDamageProc,
+		Flags:       core.SpellFlagPoison | core.SpellFlagPassiveSpell | SpellFlagRoguePoison,
+
+		DamageMultiplier: rogue.getPoisonDamageMultiplier(),
+		ThreatMultiplier: 1,
+
+		Dot: core.DotConfig{
+			Aura: core.Aura{
+				Label:     fmt.Sprintf("DeadlyPoison (Rank %d)", rank),
+				MaxStacks: 5,
+				Duration:  time.Second * 12,
+			},
+			NumberOfTicks: 4,
+			TickLength:    time.Second * 3,
+
+			OnSnapshot: func(sim *core.Simulation, target *core.Unit, dot *core.Dot, applyStack bool) {
+				if !applyStack {
+					return
+				}
+
+				// only the first stack snapshots the multiplier
+				if dot.GetStacks() == 1 {
+					attackTable := dot.Spell.Unit.AttackTables[target.UnitIndex][dot.Spell.CastType]
+					dot.SnapshotAttackerMultiplier = dot.Spell.AttackerDamageMultiplier(attackTable, true)
+					dot.SnapshotBaseDamage = 0
+				}
+
+				dot.SnapshotBaseDamage += baseDamageTick
+			},
+
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotDamage(sim, target, dot.OutcomeTick)
+			},
+		},
+	})
+
 	return rogue.RegisterSpell(core.SpellConfig{
-		ActionID: core.ActionID{SpellID: rogue.deadlyPoisonTick.SpellID},
+		ActionID: core.ActionID{SpellID: spellID},
 		Flags:    core.SpellFlagPoison | core.SpellFlagPassiveSpell | SpellFlagRoguePoison,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
@@ -280,7 +373,7 @@ func (rogue *Rogue) makeDeadlyPoison() *core.Spell {
 				return
 			}
 
-			dot := rogue.deadlyPoisonTick.Dot(target)
+			dot := tickSpell.Dot(target)
 
 			dot.ApplyOrRefresh(sim)
 			if dot.GetStacks() < dot.MaxStacks {
@@ -292,10 +385,9 @@ func (rogue *Rogue) makeDeadlyPoison() *core.Spell {
 	})
 }
 
-// Make a source based variant of Wound Poison
-func (rogue *Rogue) makeWoundPoison() *core.Spell {
+func (rogue *Rogue) makeWoundPoison(rank int32) *core.Spell {
 	return rogue.RegisterSpell(core.SpellConfig{
-		ActionID:    core.ActionID{SpellID: 13219},
+		ActionID:    core.ActionID{SpellID: woundPoisonRanks[rank].spellID},
 		SpellSchool: core.SpellSchoolNature,
 		DefenseType: core.DefenseTypeMagic,
 		ProcMask:    core.ProcMaskSpellDamageProc,
@@ -68,10 +68,9 @@ type Rogue struct {
 
 	Evasion *core.Spell
 
-	DeadlyPoison     *core.Spell
-	deadlyPoisonTick *core.Spell
-	InstantPoison    *core.Spell
-	WoundPoison      *core.Spell
+	MainHandPoison *WeaponPoison
+	OffHandPoison  *WeaponPoison
+	poisonSpells   map[poisonSpellKey]*core.Spell
 
 	additivePoisonBonusChance float64
 
@@ -119,9 +118,7 @@ func (rogue *Rogue) Initialize() {
 	rogue.registerAmbushSpell()
 
 	// Poisons
-	rogue.registerInstantPoisonSpell()
-	rogue.registerDeadlyPoisonSpell()
-	rogue.registerWoundPoisonSpell()
+	rogue.registerPoisons()
 
 	// Stealth
 	rogue.registerStealthAura()
@@ -162,7 +159,6 @@ func NewRogue(character *core.Character, options *proto.Player, rogueOptions *pr
 		Ranged:         rogue.WeaponFromRanged(),
 		AutoSwingMelee: true,
 	})
-	rogue.applyPoisons()
 
 	rogue.AddStatDependency(stats.Strength, stats.AttackPower, core.APPerStrength[character.Class])
 	rogue.AddStatDependency(stats.Agility, stats.AttackPower, 1)
@@ -201,14 +197,3 @@ func (rogue *Rogue) IsStealthed() bool {
 type RogueAgent interface {
 	GetRogue() *Rogue
 }
-
-func (rogue *Rogue) getImbueProcMask(imbue proto.WeaponImbue) core.ProcMask {
-	var mask core.ProcMask
-	if rogue.HasMHWeapon() && rogue.Consumes.MainHandImbue == imbue {
-		mask |= core.ProcMaskMeleeMH
-	}
-	if rogue.HasOHWeapon() && rogue.Consumes.OffHandImbue == imbue {
-		mask |= core.ProcMaskMeleeOH
-	}
-	return mask
-}
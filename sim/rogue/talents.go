@@ -8,6 +8,71 @@ import (
 	"github.com/isfir/wowsims-turtle/sim/core/stats"
 )
 
+// Layout of the talent trees, in the same order as the talent string.
+var TalentTrees = core.TalentTrees{
+	{ // Assassination
+		{Name: "improvedEviscerate", Row: 0, MaxPoints: 3},
+		{Name: "remorselessAttacks", Row: 0, MaxPoints: 2},
+		{Name: "malice", Row: 0, MaxPoints: 5},
+		{Name: "ruthlessness", Row: 1, MaxPoints: 3},
+		{Name: "murder", Row: 1, MaxPoints: 2},
+		{Name: "improvedSliceAndDice", Row: 1, MaxPoints: 3},
+		{Name: "relentlessStrikes", Row: 2, MaxPoints: 1},
+		{Name: "improvedExposeArmor", Row: 2, MaxPoints: 2},
+		{Name: "lethality", Row: 2, MaxPoints: 5, Prereq: "malice"},
+		{Name: "vilePoisons", Row: 3, MaxPoints: 5},
+		{Name: "improvedPoisons", Row: 3, MaxPoints: 5},
+		{Name: "coldBlood", Row: 4, MaxPoints: 1},
+		{Name: "improvedKidneyShot", Row: 4, MaxPoints: 3},
+		{Name: "sealFate", Row: 5, MaxPoints: 5, Prereq: "coldBlood"},
+		{Name: "vigor", Row: 6, MaxPoints: 1},
+	},
+	{ // Combat
+		{Name: "improvedGouge", Row: 0, MaxPoints: 3},
+		{Name: "improvedSinisterStrike", Row: 0, MaxPoints: 2},
+		{Name: "lightningReflexes", Row: 0, MaxPoints: 5},
+		{Name: "improvedBackstab", Row: 1, MaxPoints: 3},
+		{Name: "deflection", Row: 1, MaxPoints: 5},
+		{Name: "precision", Row: 1, MaxPoints: 5},
+		{Name: "endurance", Row: 2, MaxPoints: 2},
+		{Name: "riposte", Row: 2, MaxPoints: 1, Prereq: "deflection"},
+		{Name: "improvedSprint", Row: 2, MaxPoints: 2},
+		{Name: "improvedKick", Row: 3, MaxPoints: 2},
+		{Name: "daggerSpecialization", Row: 3, MaxPoints: 5},
+		{Name: "dualWieldSpecialization", Row: 3, MaxPoints: 5, Prereq: "precision"},
+		{Name: "maceSpecialization", Row: 4, MaxPoints: 5},
+		{Name: "bladeFlurry", Row: 4, MaxPoints: 1},
+		{Name: "swordSpecialization", Row: 4, MaxPoints: 5},
+		{Name: "fistWeaponSpecialization", Row: 4, MaxPoints: 5},
+		{Name: "weaponExpertise", Row: 5, MaxPoints: 2, Prereq: "bladeFlurry"},
+		{Name: "aggression", Row: 5, MaxPoints: 3},
+		{Name: "adrenalineRush", Row: 6, MaxPoints: 1},
+	},
+	{ // Subtlety
+		{Name: "masterOfDeception", Row: 0, MaxPoints: 5},
+		{Name: "opportunity", Row: 0, MaxPoints: 5},
+		{Name: "sleightOfHand", Row: 1, MaxPoints: 2},
+		{Name: "elusiveness", Row: 1, MaxPoints: 2},
+		{Name: "camouflage", Row: 1, MaxPoints: 5},
+		{Name: "initiative", Row: 2, MaxPoints: 3},
+		{Name: "ghostlyStrike", Row: 2, MaxPoints: 1},
+		{Name: "improvedAmbush", Row: 2, MaxPoints: 3},
+		{Name: "setup", Row: 3, MaxPoints: 3},
+		{Name: "improvedSap", Row: 3, MaxPoints: 3},
+		{Name: "serratedBlades", Row: 3, MaxPoints: 3},
+		{Name: "heightenedSenses", Row: 4, MaxPoints: 2},
+		{Name: "preparation", Row: 4, MaxPoints: 1},
+		{Name: "dirtyDeeds", Row: 4, MaxPoints: 2},
+		{Name: "hemorrhage", Row: 4, MaxPoints: 1, Prereq: "serratedBlades"},
+		{Name: "deadliness", Row: 5, MaxPoints: 5},
+		{Name: "premeditation", Row: 6, MaxPoints: 1, Prereq: "preparation"},
+	},
+}
+
+func init() {
+	core.RegisterTalentTrees(proto.Class_ClassRogue, TalentTrees)
+}
+
 func (rogue *Rogue) ApplyTalents() {
 	rogue.applyRuthlessness()
 	rogue.applyMurder()
@@ -312,7 +377,7 @@ func (rogue *Rogue) registerBladeFlurryCD() {
 			rogue.MultiplyMeleeSpeed(sim, 1/1.2)
 		},
 		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
-			if sim.GetNumTargets() < 2 {
+			if sim.GetNumActiveTargets() < 2 {
 				return
 			}
 
@@ -7,29 +7,19 @@ import (
 	"github.com/isfir/wowsims-turtle/sim/core"
 )
 
-func (shaman *Shaman) setActiveAirTotem(sim *core.Simulation, spell *core.Spell, aura *core.Aura) {
-	shaman.TotemExpirations[AirTotem] = sim.CurrentTime + aura.Duration
-	shaman.ActiveTotems[AirTotem] = spell
-
-	if shaman.ActiveTotemBuffs[AirTotem] != nil {
-		shaman.ActiveTotemBuffs[AirTotem].Deactivate(sim)
-	}
-
-	shaman.ActiveTotemBuffs[AirTotem] = aura
-	aura.Activate(sim)
-}
-
 const WindfuryTotemRanks = 3
 
 var WindfuryTotemSpellId = [WindfuryTotemRanks + 1]int32{0, 8512, 10613, 10614}
-var WindfuryBuffAuraId = [WindfuryTotemRanks + 1]int32{0, 8514, 10607, 10611}
-var WindfuryTotemBonusDamage = [WindfuryTotemRanks + 1]float64{0, 122, 229, 315}
 var WindfuryTotemManaCost = [WindfuryTotemRanks + 1]float64{0, 115, 175, 250}
 var WindfuryTotemLevel = [WindfuryTotemRanks + 1]int{0, 32, 42, 52}
 
+// The totem pulses its buff on the party, which outlasts the pulse period. The buff keeps
+// proccing after another air totem replaces it, until it runs out.
+const WindfuryTotemPulsePeriod = time.Second * 5
+const WindfuryTotemBuffDuration = time.Second * 10
+
 func (shaman *Shaman) registerWindfuryTotemSpell() {
 	shaman.WindfuryTotem = make([]*core.Spell, WindfuryTotemRanks+1)
-	shaman.WindfuryTotemPeriodicActions = make([]*core.PendingAction, WindfuryTotemRanks+1)
 
 	for rank := 1; rank <= WindfuryTotemRanks; rank++ {
 		config := shaman.newWindfuryTotemSpellConfig(rank)
@@ -47,41 +37,32 @@ func (shaman *Shaman) registerWindfuryTotemSpell() {
 
 func (shaman *Shaman) newWindfuryTotemSpellConfig(rank int) core.SpellConfig {
 	spellId := WindfuryTotemSpellId[rank]
-	// TODO: The sim won't respect the value of a totem dropped via the APL. It uses hard-coded values from buffs.go
-	// bonusDamage := WindfuryTotemBonusDamage[rank]
 	manaCost := WindfuryTotemManaCost[rank]
 	level := WindfuryTotemLevel[rank]
 
-	// Create a trackable aura for totem weaving
-	buffAura := shaman.RegisterAura(core.Aura{
-		ActionID: core.ActionID{SpellID: WindfuryBuffAuraId[rank]},
-		Label:    fmt.Sprintf("Windfury (Rank %d)", rank),
-		Duration: time.Second * 10,
-	})
+	duration := time.Second * 120
 
-	periodicTriggerAura := shaman.RegisterAura(core.Aura{
-		Label:    fmt.Sprintf("Windfury Trigger Dummy (Rank %d)", rank),
-		Duration: time.Minute * 2,
-		OnGain: func(_ *core.Aura, sim *core.Simulation) {
-			shaman.ActiveWindfuryTotemPeriodicAction = core.StartPeriodicAction(sim, core.PeriodicActionOptions{
-				Period:          time.Second * 5, // Totem refreshes every 5 seconds
-				TickImmediately: true,
-				OnAction: func(_ *core.Simulation) {
-					buffAura.Activate(sim)
-				},
-			})
-		},
-		OnExpire: func(_ *core.Aura, sim *core.Simulation) {
-			shaman.ActiveWindfuryTotemPeriodicAction.Cancel(sim)
-			shaman.ActiveWindfuryTotemPeriodicAction = nil
-		},
+	buffAuras := shaman.newTotemPartyAuras(func(character *core.Character) *core.Aura {
+		label := fmt.Sprintf("Windfury Totem (Rank %d) - %s", rank, shaman.Label)
+		return core.WindfuryTotemAura(character, label, int32(rank), WindfuryTotemBuffDuration)
 	})
 
 	spell := shaman.newTotemSpellConfig(manaCost, spellId)
 	spell.RequiredLevel = level
 	spell.Rank = rank
 	spell.ApplyEffects = func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
-		shaman.setActiveAirTotem(sim, spell, periodicTriggerAura)
+		// The buffs aren't part of the totem's auras, so they linger when it's replaced.
+		shaman.dropTotem(sim, AirTotem, spell, duration, nil)
+		shaman.TotemPulses[AirTotem] = core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+			Period:          WindfuryTotemPulsePeriod,
+			NumTicks:        int(duration / WindfuryTotemPulsePeriod),
+			TickImmediately: true,
+			OnAction: func(sim *core.Simulation) {
+				for _, aura := range buffAuras {
+					aura.Activate(sim)
+				}
+			},
+		})
 	}
 	return spell
 }
@@ -116,13 +97,18 @@ func (shaman *Shaman) newGraceOfAirTotemSpellConfig(rank int) core.SpellConfig {
 
 	multiplier := []float64{1, 1.08, 1.15}[shaman.Talents.EnhancingTotems]
 
-	buffAura := core.GraceOfAirTotemAura(&shaman.Unit, multiplier)
+	duration := time.Second * 120
+
+	partyAuras := shaman.newTotemPartyAuras(func(character *core.Character) *core.Aura {
+		label := fmt.Sprintf("Grace of Air Totem (Rank %d) - %s", rank, shaman.Label)
+		return core.GraceOfAirTotemAura(&character.Unit, label, int32(rank), multiplier)
+	})
 
 	spell := shaman.newTotemSpellConfig(manaCost, spellId)
 	spell.RequiredLevel = level
 	spell.Rank = rank
 	spell.ApplyEffects = func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
-		shaman.setActiveAirTotem(sim, spell, buffAura)
+		shaman.dropTotem(sim, AirTotem, spell, duration, partyAuras)
 	}
 	return spell
 }
@@ -155,11 +141,32 @@ func (shaman *Shaman) newWindwallTotemSpellConfig(rank int) core.SpellConfig {
 	manaCost := WindwallTotemManaCost[rank]
 	level := WindwallTotemLevel[rank]
 
+	duration := time.Second * 120
+
 	spell := shaman.newTotemSpellConfig(manaCost, spellId)
 	spell.RequiredLevel = level
 	spell.Rank = rank
 	spell.ApplyEffects = func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
-		shaman.setActiveAirTotem(sim, spell, nil)
+		shaman.dropTotem(sim, AirTotem, spell, duration, nil)
 	}
 	return spell
 }
+
+func (shaman *Shaman) registerTranquilAirTotemSpell() {
+	spellId := int32(25908)
+	manaCost := float64(147)
+	duration := time.Second * 120
+	level := 50
+
+	partyAuras := shaman.newTotemPartyAuras(func(character *core.Character) *core.Aura {
+		return core.TranquilAirTotemAura(&character.Unit, "Tranquil Air Totem - "+shaman.Label)
+	})
+
+	spell := shaman.newTotemSpellConfig(manaCost, spellId)
+	spell.RequiredLevel = level
+	spell.ApplyEffects = func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+		shaman.dropTotem(sim, AirTotem, spell, duration, partyAuras)
+	}
+	shaman.TranquilAirTotem = shaman.RegisterSpell(spell)
+	shaman.AirTotems = append(shaman.AirTotems, shaman.TranquilAirTotem)
+}
@@ -0,0 +1,80 @@
+package shaman
+
+import (
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core"
+)
+
+const ChainHealRanks = 3
+const ChainHealTargetCount = 3
+
+var ChainHealSpellId = [ChainHealRanks + 1]int32{0, 1064, 10622, 10623}
+var ChainHealBaseHealing = [ChainHealRanks + 1][]float64{{0}, {320, 368}, {405, 465}, {551, 629}}
+var ChainHealSpellCoef = [ChainHealRanks + 1]float64{0, .714, .714, .714}
+var ChainHealManaCost = [ChainHealRanks + 1]float64{0, 260, 315, 405}
+var ChainHealLevel = [ChainHealRanks + 1]int{0, 40, 46, 54}
+
+func (shaman *Shaman) registerChainHealSpell() {
+	shaman.ChainHeal = make([]*core.Spell, ChainHealRanks+1)
+
+	for rank := 1; rank <= ChainHealRanks; rank++ {
+		config := shaman.newChainHealSpellConfig(rank)
+
+		if config.RequiredLevel <= int(shaman.Level) {
+			shaman.ChainHeal[rank] = shaman.RegisterSpell(config)
+		}
+	}
+}
+
+func (shaman *Shaman) newChainHealSpellConfig(rank int) core.SpellConfig {
+	spellId := ChainHealSpellId[rank]
+	baseHealingMultiplier := 1 + shaman.purificationHealingModifier()
+	baseHealingLow := ChainHealBaseHealing[rank][0] * baseHealingMultiplier
+	baseHealingHigh := ChainHealBaseHealing[rank][1] * baseHealingMultiplier
+	spellCoeff := ChainHealSpellCoef[rank]
+	manaCost := ChainHealManaCost[rank]
+	level := ChainHealLevel[rank]
+
+	bounceCoeff := .5 // 50% reduction per bounce
+
+	spell := shaman.newHealingSpellConfig(
+		core.ActionID{SpellID: spellId},
+		manaCost,
+		time.Millisecond*2500,
+	)
+	spell.SpellCode = SpellCode_ShamanChainHeal
+	spell.RequiredLevel = level
+	spell.Rank = rank
+	spell.BonusCoefficient = spellCoeff
+
+	spell.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+		origMult := spell.DamageMultiplier
+		for _, bounceTarget := range shaman.chainHealTargets(target) {
+			baseHealing := sim.Roll(baseHealingLow, baseHealingHigh)
+			spell.CalcAndDealHealing(sim, bounceTarget, baseHealing, spell.OutcomeHealingCrit)
+			spell.DamageMultiplier *= bounceCoeff
+		}
+		spell.DamageMultiplier = origMult
+	}
+
+	return spell
+}
+
+// Chain Heal jumps from the primary target to other friendly units in the raid, preferring target dummies
+// since those are what healing specs are simmed against.
+func (shaman *Shaman) chainHealTargets(target *core.Unit) []*core.Unit {
+	targets := []*core.Unit{target}
+	for _, party := range shaman.Env.Raid.Parties {
+		for _, player := range party.Players {
+			if len(targets) == ChainHealTargetCount {
+				return targets
+			}
+
+			if dummy, ok := player.(*core.TargetDummy); ok && &dummy.Unit != target {
+				targets = append(targets, &dummy.Unit)
+			}
+		}
+	}
+	return targets
+}
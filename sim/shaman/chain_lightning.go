@@ -62,14 +62,15 @@ func (shaman *Shaman) newChainLightningSpellConfig(rank int, cdTimer *core.Timer
 
 	spell.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 		origMult := spell.DamageMultiplier
-		for hitIndex := range results {
+		numTargets := min(len(results), int(sim.GetNumActiveTargets()))
+		for hitIndex := range results[:numTargets] {
 			baseDamage := sim.Roll(baseDamageLow, baseDamageHigh)
 			results[hitIndex] = spell.CalcDamage(sim, target, baseDamage, spell.OutcomeMagicHitAndCrit)
 			target = sim.Environment.NextTargetUnit(target)
 			spell.DamageMultiplier *= shaman.ChainLightningBounceCoefficient
 		}
 
-		for _, result := range results {
+		for _, result := range results[:numTargets] {
 			spell.DealDamage(sim, result)
 		}
 
@@ -1,6 +1,7 @@
 package shaman
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/isfir/wowsims-turtle/sim/core"
@@ -37,16 +38,16 @@ func (shaman *Shaman) newStrengthOfEarthTotemSpellConfig(rank int) core.SpellCon
 	duration := time.Second * 120
 	multiplier := []float64{1, 1.08, 1.15}[shaman.Talents.EnhancingTotems]
 
-	buffAura := core.StrengthOfEarthTotemAura(&shaman.Unit, multiplier)
+	partyAuras := shaman.newTotemPartyAuras(func(character *core.Character) *core.Aura {
+		label := fmt.Sprintf("Strength of Earth Totem (Rank %d) - %s", rank, shaman.Label)
+		return core.StrengthOfEarthTotemAura(&character.Unit, label, int32(rank), multiplier)
+	})
 
 	spell := shaman.newTotemSpellConfig(manaCost, spellId)
 	spell.RequiredLevel = level
 	spell.Rank = rank
 	spell.ApplyEffects = func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
-		shaman.TotemExpirations[EarthTotem] = sim.CurrentTime + duration
-		shaman.ActiveTotems[EarthTotem] = spell
-
-		buffAura.Activate(sim)
+		shaman.dropTotem(sim, EarthTotem, spell, duration, partyAuras)
 	}
 	return spell
 }
@@ -81,14 +82,17 @@ func (shaman *Shaman) newStoneskinTotemSpellConfig(rank int) core.SpellConfig {
 
 	duration := time.Second * 120
 
+	partyAuras := shaman.newTotemPartyAuras(func(character *core.Character) *core.Aura {
+		// Every rank uses the same value, so they share their aura.
+		label := fmt.Sprintf("Stoneskin Totem - %s", shaman.Label)
+		return core.StoneskinTotemAura(&character.Unit, label, shaman.Talents.GuardianTotems)
+	})
+
 	spell := shaman.newTotemSpellConfig(manaCost, spellId)
 	spell.RequiredLevel = level
 	spell.Rank = rank
 	spell.ApplyEffects = func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
-		shaman.TotemExpirations[EarthTotem] = sim.CurrentTime + duration
-		shaman.ActiveTotems[EarthTotem] = spell
-
-		core.StoneskinTotemAura(&shaman.Unit, shaman.Talents.GuardianTotems).Activate(sim)
+		shaman.dropTotem(sim, EarthTotem, spell, duration, partyAuras)
 	}
 	return spell
 }
@@ -102,8 +106,7 @@ func (shaman *Shaman) registerTremorTotemSpell() {
 	spell := shaman.newTotemSpellConfig(manaCost, spellId)
 	spell.RequiredLevel = level
 	spell.ApplyEffects = func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
-		shaman.TotemExpirations[EarthTotem] = sim.CurrentTime + duration
-		shaman.ActiveTotems[EarthTotem] = spell
+		shaman.dropTotem(sim, EarthTotem, spell, duration, nil)
 	}
 	shaman.TremorTotem = shaman.RegisterSpell(spell)
 	shaman.EarthTotems = append(shaman.EarthTotems, shaman.TremorTotem)
@@ -0,0 +1,36 @@
+package shaman
+
+import (
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core"
+)
+
+// Shared precomputation logic for HW, LHW and CH.
+func (shaman *Shaman) newHealingSpellConfig(actionID core.ActionID, baseCost float64, baseCastTime time.Duration) core.SpellConfig {
+	return core.SpellConfig{
+		ActionID:    actionID,
+		SpellSchool: core.SpellSchoolNature,
+		DefenseType: core.DefenseTypeMagic,
+		ProcMask:    core.ProcMaskSpellHealing,
+		Flags:       SpellFlagShaman | core.SpellFlagHelpful | core.SpellFlagAPL,
+
+		ManaCost: core.ManaCostOptions{
+			FlatCost: baseCost,
+		},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				CastTime: baseCastTime,
+				GCD:      core.GCDDefault,
+			},
+			ModifyCast: func(sim *core.Simulation, spell *core.Spell, cast *core.Cast) {
+				castTime := shaman.ApplyCastSpeedForSpell(cast.CastTime, spell)
+				shaman.AutoAttacks.StopMeleeUntil(sim, sim.CurrentTime+castTime, false)
+			},
+		},
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+	}
+}
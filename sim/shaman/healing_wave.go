@@ -0,0 +1,56 @@
+package shaman
+
+import (
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core"
+)
+
+const HealingWaveRanks = 10
+
+var HealingWaveSpellId = [HealingWaveRanks + 1]int32{0, 331, 332, 547, 913, 939, 959, 8005, 10395, 10396, 25357}
+var HealingWaveBaseHealing = [HealingWaveRanks + 1][]float64{{0}, {36, 47}, {69, 83}, {136, 163}, {279, 328}, {389, 454}, {552, 639}, {759, 874}, {1040, 1191}, {1389, 1583}, {1620, 1850}}
+var HealingWaveSpellCoef = [HealingWaveRanks + 1]float64{0, .123, .271, .5, .793, .857, .857, .857, .857, .857, .857}
+var HealingWaveCastTime = [HealingWaveRanks + 1]int32{0, 1500, 2000, 2500, 3000, 3000, 3000, 3000, 3000, 3000, 3000}
+var HealingWaveManaCost = [HealingWaveRanks + 1]float64{0, 25, 45, 80, 155, 200, 265, 340, 440, 560, 620}
+var HealingWaveLevel = [HealingWaveRanks + 1]int{0, 1, 6, 12, 18, 24, 32, 40, 48, 56, 60}
+
+func (shaman *Shaman) registerHealingWaveSpell() {
+	shaman.HealingWave = make([]*core.Spell, HealingWaveRanks+1)
+
+	for rank := 1; rank <= HealingWaveRanks; rank++ {
+		config := shaman.newHealingWaveSpellConfig(rank)
+
+		if config.RequiredLevel <= int(shaman.Level) {
+			shaman.HealingWave[rank] = shaman.RegisterSpell(config)
+		}
+	}
+}
+
+func (shaman *Shaman) newHealingWaveSpellConfig(rank int) core.SpellConfig {
+	spellId := HealingWaveSpellId[rank]
+	baseHealingMultiplier := 1 + shaman.purificationHealingModifier()
+	baseHealingLow := HealingWaveBaseHealing[rank][0] * baseHealingMultiplier
+	baseHealingHigh := HealingWaveBaseHealing[rank][1] * baseHealingMultiplier
+	spellCoeff := HealingWaveSpellCoef[rank]
+	castTime := HealingWaveCastTime[rank]
+	manaCost := HealingWaveManaCost[rank]
+	level := HealingWaveLevel[rank]
+
+	spell := shaman.newHealingSpellConfig(
+		core.ActionID{SpellID: spellId},
+		manaCost,
+		time.Millisecond*time.Duration(castTime)-time.Millisecond*100*time.Duration(shaman.Talents.ImprovedHealingWave),
+	)
+	spell.SpellCode = SpellCode_ShamanHealingWave
+	spell.RequiredLevel = level
+	spell.Rank = rank
+	spell.BonusCoefficient = spellCoeff
+
+	spell.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+		baseHealing := sim.Roll(baseHealingLow, baseHealingHigh)
+		spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+	}
+
+	return spell
+}
@@ -0,0 +1,54 @@
+package shaman
+
+import (
+	"time"
+
+	"github.com/isfir/wowsims-turtle/sim/core"
+)
+
+const LesserHealingWaveRanks = 6
+
+var LesserHealingWaveSpellId = [LesserHealingWaveRanks + 1]int32{0, 8004, 8008, 8010, 10466, 10467, 10468}
+var LesserHealingWaveBaseHealing = [LesserHealingWaveRanks + 1][]float64{{0}, {170, 195}, {257, 292}, {347, 391}, {458, 514}, {607, 680}, {756, 856}}
+var LesserHealingWaveSpellCoef = [LesserHealingWaveRanks + 1]float64{0, .429, .429, .429, .429, .429, .429}
+var LesserHealingWaveManaCost = [LesserHealingWaveRanks + 1]float64{0, 105, 145, 185, 235, 305, 380}
+var LesserHealingWaveLevel = [LesserHealingWaveRanks + 1]int{0, 20, 28, 36, 44, 52, 60}
+
+func (shaman *Shaman) registerLesserHealingWaveSpell() {
+	shaman.LesserHealingWave = make([]*core.Spell, LesserHealingWaveRanks+1)
+
+	for rank := 1; rank <= LesserHealingWaveRanks; rank++ {
+		config := shaman.newLesserHealingWaveSpellConfig(rank)
+
+		if config.RequiredLevel <= int(shaman.Level) {
+			shaman.LesserHealingWave[rank] = shaman.RegisterSpell(config)
+		}
+	}
+}
+
+func (shaman *Shaman) newLesserHealingWaveSpellConfig(rank int) core.SpellConfig {
+	spellId := LesserHealingWaveSpellId[rank]
+	baseHealingMultiplier := 1 + shaman.purificationHealingModifier()
+	baseHealingLow := LesserHealingWaveBaseHealing[rank][0] * baseHealingMultiplier
+	baseHealingHigh := LesserHealingWaveBaseHealing[rank][1] * baseHealingMultiplier
+	spellCoeff := LesserHealingWaveSpellCoef[rank]
+	manaCost := LesserHealingWaveManaCost[rank]
+	level := LesserHealingWaveLevel[rank]
+
+	spell := shaman.newHealingSpellConfig(
+		core.ActionID{SpellID: spellId},
+		manaCost,
+		time.Millisecond*1500,
+	)
+	spell.SpellCode = SpellCode_ShamanLesserHealingWave
+	spell.RequiredLevel = level
+	spell.Rank = rank
+	spell.BonusCoefficient = spellCoeff
+
+	spell.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+		baseHealing := sim.Roll(baseHealingLow, baseHealingHigh)
+		spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+	}
+
+	return spell
+}
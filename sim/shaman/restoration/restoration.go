@@ -24,26 +24,10 @@ func RegisterRestorationShaman() {
 }
 
 func NewRestorationShaman(character *core.Character, options *proto.Player) *RestorationShaman {
-	restoShamOptions := options.GetRestorationShaman()
-
-	selfBuffs := shaman.SelfBuffs{
-		Shield: restoShamOptions.Options.Shield,
-	}
-
-	totems := &proto.ShamanTotems{}
-	if restoShamOptions.Options.Totems != nil {
-		totems = restoShamOptions.Options.Totems
-	}
+	_ = options.GetRestorationShaman()
 
 	resto := &RestorationShaman{
-		Shaman: shaman.NewShaman(character, options.TalentsString, totems, selfBuffs, false),
-	}
-
-	if resto.HasMHWeapon() {
-		resto.ApplyEarthlivingImbueToItem(resto.GetMHWeapon())
-	}
-	if resto.HasOHWeapon() {
-		resto.ApplyEarthlivingImbueToItem(resto.GetOHWeapon())
+		Shaman: shaman.NewShaman(character, options.TalentsString),
 	}
 
 	return resto
@@ -57,11 +41,7 @@ func (resto *RestorationShaman) GetShaman() *shaman.Shaman {
 	return resto.Shaman
 }
 
-func (resto *RestorationShaman) Reset(sim *core.Simulation) {
-	resto.Shaman.Reset(sim)
-}
 func (resto *RestorationShaman) GetMainTarget() *core.Unit {
-	// TODO: make this just grab first player that isn't self.
 	target := resto.Env.Raid.GetFirstTargetDummy()
 	if target == nil {
 		return &resto.Unit
@@ -72,17 +52,10 @@ func (resto *RestorationShaman) GetMainTarget() *core.Unit {
 
 func (resto *RestorationShaman) Initialize() {
 	resto.CurrentTarget = resto.GetMainTarget()
-
-	// Has to be here because earthliving can cast hots and needs Env to be set to create the hots.
-	procMask := core.ProcMaskUnknown
-	if resto.HasMHWeapon() {
-		procMask |= core.ProcMaskMeleeMH
-	}
-	if resto.HasOHWeapon() {
-		procMask |= core.ProcMaskMeleeOH
-	}
-	resto.RegisterEarthlivingImbue(procMask)
-
 	resto.Shaman.Initialize()
 	resto.Shaman.RegisterHealingSpells()
 }
+
+func (resto *RestorationShaman) Reset(sim *core.Simulation) {
+	resto.Shaman.Reset(sim)
+}
@@ -0,0 +1,148 @@
+package restoration
+
+import (
+	"testing"
+
+	_ "github.com/isfir/wowsims-turtle/sim/common"
+	"github.com/isfir/wowsims-turtle/sim/core"
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
+	"github.com/isfir/wowsims-turtle/sim/shaman"
+)
+
+func init() {
+	RegisterRestorationShaman()
+}
+
+func TestRestoration(t *testing.T) {
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
+		{
+			Class:      proto.Class_ClassShaman,
+			Phase:      1,
+			Race:       proto.Race_RaceTroll,
+			OtherRaces: []proto.Race{proto.Race_RaceOrc},
+
+			Talents:     StandardTalents,
+			GearSet:     core.GetGearSet("../../../ui/restoration_shaman/gear_sets", "blank"),
+			Rotation:    core.GetAplRotation("../../../ui/restoration_shaman/apls", "default"),
+			Buffs:       core.FullBuffs,
+			Consumes:    FullConsumes,
+			SpecOptions: core.SpecOptionsCombo{Label: "Default", SpecOptions: PlayerOptionsDefault},
+
+			IsHealer: true,
+
+			ItemFilter:      ItemFilters,
+			EPReferenceStat: proto.Stat_StatHealingPower,
+			StatsToWeigh:    Stats,
+		},
+	}))
+}
+
+func TestPartyTotems(t *testing.T) {
+	// Only the first shaman has Mana Tide Totem and drops Tranquil Air, but both totems
+	// are party wide, so the second shaman should receive both effects as well.
+	rotation := core.APLRotationFromJsonString(`{"type": "TypeAPL",
+		"priorityList": [
+			{"action": {"autocastOtherCooldowns": {}}},
+			{"action": {"condition": {"not": {"val": {"auraIsActive": {"auraId": {"spellId": 25909}}}}}, "castSpell": {"spellId": {"spellId": 25908}}}}
+		]}`)
+	idle := core.APLRotationFromJsonString(`{"type": "TypeAPL", "priorityList": []}`)
+
+	newPlayer := func(name string, talents string, rotation *proto.APLRotation) *proto.Player {
+		return &proto.Player{
+			Name:          name,
+			Race:          proto.Race_RaceTroll,
+			Class:         proto.Class_ClassShaman,
+			Equipment:     &proto.EquipmentSpec{},
+			Spec:          PlayerOptionsDefault,
+			TalentsString: talents,
+			Rotation:      rotation,
+		}
+	}
+
+	result := core.RunRaidSim(&proto.RaidSimRequest{
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{
+				{
+					Players: []*proto.Player{
+						newPlayer("Mana Tide", StandardTalents, rotation),
+						newPlayer("Party Member", "", idle),
+					},
+					// Replaced by the simmed shaman's own Mana Tide Totem.
+					Buffs: &proto.PartyBuffs{ManaTideTotems: 1},
+				},
+			},
+			Buffs:   &proto.RaidBuffs{},
+			Debuffs: &proto.Debuffs{},
+		},
+		Encounter:  core.MakeSingleTargetEncounter(0),
+		SimOptions: &proto.SimOptions{Iterations: 10, RandomSeed: 101, IsTest: true},
+	})
+	if result.Error != nil {
+		t.Fatalf("Sim failed: %s", result.Error.Message)
+	}
+
+	for _, player := range result.RaidMetrics.Parties[0].Players {
+		var manaTideGain float64
+		for _, resource := range player.Resources {
+			if resource.Id.GetSpellId() == core.ManaTideTotemActionID.SpellID && resource.Id.Tag == -1 {
+				t.Fatalf("Expected no approximated Mana Tide Totem for %s", player.Name)
+			}
+			if resource.Id.GetSpellId() == shaman.ManaTideTotemRestoreId[shaman.ManaTideTotemRanks] && resource.Id.Tag == 0 {
+				manaTideGain += resource.Gain
+			}
+		}
+		if manaTideGain == 0 {
+			t.Fatalf("Expected %s to gain mana from the first shaman's Mana Tide Totem", player.Name)
+		}
+
+		var tranquilAirUptime float64
+		for _, aura := range player.Auras {
+			if aura.Id.GetSpellId() == core.TranquilAirTotemBuffSpellId {
+				tranquilAirUptime += aura.UptimeSecondsAvg
+			}
+		}
+		if tranquilAirUptime == 0 {
+			t.Fatalf("Expected %s to have Tranquil Air", player.Name)
+		}
+	}
+}
+
+var StandardTalents = "-5-550353513053151"
+
+var PlayerOptionsDefault = &proto.Player_RestorationShaman{
+	RestorationShaman: &proto.RestorationShaman{
+		Options: &proto.RestorationShaman_Options{},
+	},
+}
+
+var FullConsumes = core.ConsumesCombo{
+	Label: "Healer-Consumes",
+	Consumes: &proto.Consumes{
+		DefaultPotion: proto.Potions_MajorManaPotion,
+		Flask:         proto.Flask_FlaskOfDistilledWisdom,
+	},
+}
+
+var ItemFilters = core.ItemFilter{
+	WeaponTypes: []proto.WeaponType{
+		proto.WeaponType_WeaponTypeAxe,
+		proto.WeaponType_WeaponTypeDagger,
+		proto.WeaponType_WeaponTypeFist,
+		proto.WeaponType_WeaponTypeMace,
+		proto.WeaponType_WeaponTypeOffHand,
+		proto.WeaponType_WeaponTypeShield,
+		proto.WeaponType_WeaponTypeStaff,
+	},
+	ArmorType: proto.ArmorType_ArmorTypeMail,
+	RangedWeaponTypes: []proto.RangedWeaponType{
+		proto.RangedWeaponType_RangedWeaponTypeTotem,
+	},
+}
+
+var Stats = []proto.Stat{
+	proto.Stat_StatIntellect,
+	proto.Stat_StatSpirit,
+	proto.Stat_StatHealingPower,
+	proto.Stat_StatSpellCrit,
+	proto.Stat_StatMP5,
+}
@@ -102,10 +102,12 @@ type Shaman struct {
 	LightningShieldProcs []*core.Spell // The damage component of lightning shield is a separate spell
 	MagmaTotem           []*core.Spell
 	ManaSpringTotem      []*core.Spell
+	ManaTideTotem        []*core.Spell
 	SearingTotem         []*core.Spell
 	StoneskinTotem       []*core.Spell
 	Stormstrike          *core.Spell
 	StrengthOfEarthTotem []*core.Spell
+	TranquilAirTotem     *core.Spell
 	TremorTotem          *core.Spell
 	WindfuryTotem        []*core.Spell
 	WindfuryWeaponMH     *core.Spell
@@ -118,8 +120,9 @@ type Shaman struct {
 
 	// Totems
 	ActiveTotems     [4]*core.Spell
-	ActiveTotemBuffs [4]*core.Aura
-	TotemExpirations [4]time.Duration // The expiration time of each totem (earth, air, fire, water).
+	ActiveTotemAuras [4][]*core.Aura        // The party auras of each active totem, removed when it's replaced.
+	TotemPulses      [4]*core.PendingAction // Periodic actions of totems which pulse their buff.
+	TotemExpirations [4]time.Duration       // The expiration time of each totem (earth, air, fire, water).
 
 	EarthTotems []*core.Spell
 	FireTotems  []*core.Spell
@@ -127,9 +130,6 @@ type Shaman struct {
 	AirTotems   []*core.Spell
 	Totems      *proto.ShamanTotems
 
-	WindfuryTotemPeriodicActions      []*core.PendingAction
-	ActiveWindfuryTotemPeriodicAction *core.PendingAction
-
 	// Shield
 	ActiveShield     *core.Spell // Tracks the Shaman's active shield spell
 	ActiveShieldAura *core.Aura
@@ -153,6 +153,13 @@ func (shaman *Shaman) AddRaidBuffs(_ *proto.RaidBuffs) {
 	// Buffs are handled explicitly through APLs now
 }
 
+func (shaman *Shaman) AddPartyBuffs(partyBuffs *proto.PartyBuffs) {
+	// A simmed shaman casts its own Mana Tide Totem, which replaces one of the party's approximated ones.
+	if shaman.Talents.ManaTideTotem && partyBuffs.ManaTideTotems > 0 {
+		partyBuffs.ManaTideTotems--
+	}
+}
+
 func (shaman *Shaman) Initialize() {
 	// Core abilities
 	shaman.registerChainLightningSpell()
@@ -180,6 +187,13 @@ func (shaman *Shaman) Initialize() {
 	shaman.registerWindfuryTotemSpell()
 	shaman.registerGraceOfAirTotemSpell()
 	shaman.registerWindwallTotemSpell()
+	shaman.registerTranquilAirTotemSpell()
+}
+
+func (shaman *Shaman) RegisterHealingSpells() {
+	shaman.registerChainHealSpell()
+	shaman.registerHealingWaveSpell()
+	shaman.registerLesserHealingWaveSpell()
 }
 
 func (shaman *Shaman) Reset(_ *core.Simulation) {
@@ -189,6 +203,7 @@ func (shaman *Shaman) Reset(_ *core.Simulation) {
 	for i := range []int{EarthTotem, FireTotem, WaterTotem, AirTotem} {
 		shaman.ActiveTotems[i] = nil
 		shaman.TotemExpirations[i] = 0
-		shaman.ActiveTotemBuffs[i] = nil
+		shaman.ActiveTotemAuras[i] = nil
+		shaman.TotemPulses[i] = nil
 	}
 }
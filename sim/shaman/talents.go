@@ -6,9 +6,70 @@ import (
 	"time"
 
 	"github.com/isfir/wowsims-turtle/sim/core"
+	"github.com/isfir/wowsims-turtle/sim/core/proto"
 	"github.com/isfir/wowsims-turtle/sim/core/stats"
 )
 
+// Layout of the talent trees, in the same order as the talent string.
+var TalentTrees = core.TalentTrees{
+	{ // Elemental
+		{Name: "convection", Row: 0, MaxPoints: 5},
+		{Name: "concussion", Row: 0, MaxPoints: 5},
+		{Name: "earthsGrasp", Row: 1, MaxPoints: 2},
+		{Name: "elementalWarding", Row: 1, MaxPoints: 3},
+		{Name: "callOfFlame", Row: 1, MaxPoints: 3},
+		{Name: "elementalFocus", Row: 2, MaxPoints: 1},
+		{Name: "reverberation", Row: 2, MaxPoints: 5},
+		{Name: "callOfThunder", Row: 2, MaxPoints: 5},
+		{Name: "improvedFireTotems", Row: 3, MaxPoints: 2},
+		{Name: "eyeOfTheStorm", Row: 3, MaxPoints: 3},
+		{Name: "elementalDevastation", Row: 3, MaxPoints: 3},
+		{Name: "stormReach", Row: 4, MaxPoints: 2},
+		{Name: "elementalFury", Row: 4, MaxPoints: 1},
+		{Name: "lightningMastery", Row: 5, MaxPoints: 5, Prereq: "callOfThunder"},
+		{Name: "elementalMastery", Row: 6, MaxPoints: 1, Prereq: "elementalFury"},
+	},
+	{ // Enhancement
+		{Name: "ancestralKnowledge", Row: 0, MaxPoints: 5},
+		{Name: "shieldSpecialization", Row: 0, MaxPoints: 5},
+		{Name: "guardianTotems", Row: 1, MaxPoints: 2},
+		{Name: "thunderingStrikes", Row: 1, MaxPoints: 5},
+		{Name: "improvedGhostWolf", Row: 1, MaxPoints: 2},
+		{Name: "improvedLightningShield", Row: 1, MaxPoints: 3},
+		{Name: "enhancingTotems", Row: 2, MaxPoints: 2},
+		{Name: "twoHandedAxesAndMaces", Row: 2, MaxPoints: 1},
+		{Name: "anticipation", Row: 2, MaxPoints: 5},
+		{Name: "flurry", Row: 3, MaxPoints: 5, Prereq: "thunderingStrikes"},
+		{Name: "toughness", Row: 3, MaxPoints: 5},
+		{Name: "improvedWeaponTotems", Row: 4, MaxPoints: 2},
+		{Name: "elementalWeapons", Row: 4, MaxPoints: 3},
+		{Name: "parry", Row: 4, MaxPoints: 1},
+		{Name: "weaponMastery", Row: 5, MaxPoints: 5},
+		{Name: "stormstrike", Row: 6, MaxPoints: 1, Prereq: "elementalWeapons"},
+	},
+	{ // Restoration
+		{Name: "improvedHealingWave", Row: 0, MaxPoints: 5},
+		{Name: "tidalFocus", Row: 0, MaxPoints: 5},
+		{Name: "improvedReincarnation", Row: 1, MaxPoints: 2},
+		{Name: "ancestralHealing", Row: 1, MaxPoints: 3},
+		{Name: "totemicFocus", Row: 1, MaxPoints: 5},
+		{Name: "naturesGuidance", Row: 2, MaxPoints: 3},
+		{Name: "healingFocus", Row: 2, MaxPoints: 5},
+		{Name: "totemicMastery", Row: 2, MaxPoints: 1},
+		{Name: "healingGrace", Row: 2, MaxPoints: 3},
+		{Name: "restorativeTotems", Row: 3, MaxPoints: 5},
+		{Name: "tidalMastery", Row: 3, MaxPoints: 5},
+		{Name: "healingWay", Row: 4, MaxPoints: 3},
+		{Name: "naturesSwiftness", Row: 4, MaxPoints: 1},
+		{Name: "purification", Row: 5, MaxPoints: 5},
+		{Name: "manaTideTotem", Row: 6, MaxPoints: 1, Prereq: "restorativeTotems"},
+	},
+}
+
+func init() {
+	core.RegisterTalentTrees(proto.Class_ClassShaman, TalentTrees)
+}
+
 func (shaman *Shaman) ApplyTalents() {
 	// Elemental Talents
 	shaman.applyConcussion()
@@ -47,7 +108,7 @@ func (shaman *Shaman) ApplyTalents() {
 	// TODO: Healing Way
 	// TODO: Ancestral Healing
 	shaman.registerNaturesSwiftnessCD()
-	// shaman.registerManaTideTotemCD()
+	shaman.registerManaTideTotemCD()
 
 	if shaman.Talents.TidalFocus > 0 {
 		shaman.OnSpellRegistered(func(spell *core.Spell) {
@@ -457,47 +518,29 @@ func (shaman *Shaman) purificationHealingModifier() float64 {
 	return .02 * float64(shaman.Talents.Purification)
 }
 
-// func (shaman *Shaman) registerManaTideTotemCD() {
-// 	if !shaman.Talents.ManaTideTotem {
-// 		return
-// 	}
-
-// 	mttAura := core.ManaTideTotemAura(shaman.GetCharacter(), shaman.Index)
-// 	mttSpell := shaman.RegisterSpell(core.SpellConfig{
-// 		ActionID: core.ManaTideTotemActionID,
-// 		Flags:    core.SpellFlagNoOnCastComplete,
-// 		Cast: core.CastConfig{
-// 			DefaultCast: core.Cast{
-// 				GCD: time.Second,
-// 			},
-// 			IgnoreHaste: true,
-// 			CD: core.Cooldown{
-// 				Timer:    shaman.NewTimer(),
-// 				Duration: time.Minute * 5,
-// 			},
-// 		},
-// 		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
-// 			mttAura.Activate(sim)
-
-// 			// If healing stream is active, cancel it while mana tide is up.
-// 			if shaman.HealingStreamTotem.Hot(&shaman.Unit).IsActive() {
-// 				for _, agent := range shaman.Party.Players {
-// 					shaman.HealingStreamTotem.Hot(&agent.GetCharacter().Unit).Cancel(sim)
-// 				}
-// 			}
-
-// 			// TODO: Current water totem buff needs to be removed from party/raid.
-// 			if shaman.Totems.Water != proto.WaterTotem_NoWaterTotem {
-// 				shaman.TotemExpirations[WaterTotem] = sim.CurrentTime + time.Second*12
-// 			}
-// 		},
-// 	})
-
-// 	shaman.AddMajorCooldown(core.MajorCooldown{
-// 		Spell: mttSpell,
-// 		Type:  core.CooldownTypeDPS,
-// 		ShouldActivate: func(sim *core.Simulation, character *core.Character) bool {
-// 			return sim.CurrentTime > time.Second*30
-// 		},
-// 	})
-// }
+func (shaman *Shaman) registerManaTideTotemCD() {
+	if !shaman.Talents.ManaTideTotem {
+		return
+	}
+
+	shaman.registerManaTideTotemSpell()
+
+	spells := core.FilterSlice(shaman.ManaTideTotem, func(spell *core.Spell) bool { return spell != nil })
+	if len(spells) == 0 {
+		return
+	}
+
+	var initialDelay time.Duration
+	shaman.Env.RegisterPostFinalizeEffect(func() {
+		// Use the first one at 60s, or halfway through the fight, whichever comes first.
+		initialDelay = min(shaman.Env.BaseDuration/2, time.Second*60)
+	})
+
+	shaman.AddMajorCooldown(core.MajorCooldown{
+		Spell: spells[len(spells)-1],
+		Type:  core.CooldownTypeMana,
+		ShouldActivate: func(sim *core.Simulation, character *core.Character) bool {
+			return sim.CurrentTime >= initialDelay
+		},
+	})
+}
@@ -1,6 +1,8 @@
 package shaman
 
 import (
+	"time"
+
 	"github.com/isfir/wowsims-turtle/sim/core"
 )
 
@@ -21,3 +23,32 @@ func (shaman *Shaman) newTotemSpellConfig(flatCost float64, spellID int32) core.
 		},
 	}
 }
+
+// Registers an aura on each player of the shaman's party, for the buff of a totem.
+func (shaman *Shaman) newTotemPartyAuras(registerAura func(character *core.Character) *core.Aura) []*core.Aura {
+	auras := make([]*core.Aura, 0, len(shaman.Party.Players))
+	for _, agent := range shaman.Party.Players {
+		auras = append(auras, registerAura(agent.GetCharacter()))
+	}
+	return auras
+}
+
+// Drops a totem, which replaces the active totem of the same element. The auras of the
+// replaced totem are removed and the new totem's party auras are activated.
+func (shaman *Shaman) dropTotem(sim *core.Simulation, element int, spell *core.Spell, duration time.Duration, auras []*core.Aura) {
+	for _, aura := range shaman.ActiveTotemAuras[element] {
+		aura.Deactivate(sim)
+	}
+	if shaman.TotemPulses[element] != nil {
+		shaman.TotemPulses[element].Cancel(sim)
+		shaman.TotemPulses[element] = nil
+	}
+
+	shaman.ActiveTotems[element] = spell
+	shaman.ActiveTotemAuras[element] = auras
+	shaman.TotemExpirations[element] = sim.CurrentTime + duration
+
+	for _, aura := range auras {
+		aura.Activate(sim)
+	}
+}
@@ -81,3 +81,44 @@ var Stats = []proto.Stat{
 	proto.Stat_StatBlockValue,
 	proto.Stat_StatDefense,
 }
+
+func TestWindfuryTotemTwisting(t *testing.T) {
+	// Drop Windfury Totem before the pull, and replace it with Grace of Air right away.
+	rotation := core.APLRotationFromJsonString(`{"type": "TypeAPL",
+		"prepullActions": [{"action": {"castSpell": {"spellId": {"spellId": 10614, "rank": 3}}}, "doAtValue": {"const": {"val": "-1.5s"}}}],
+		"priorityList": [
+			{"action": {"condition": {"not": {"val": {"auraIsActive": {"auraId": {"spellId": 25359, "rank": 3}}}}}, "castSpell": {"spellId": {"spellId": 25359, "rank": 3}}}}
+		]}`)
+
+	player := &proto.Player{
+		Name:          "Warden",
+		Race:          proto.Race_RaceTroll,
+		Class:         proto.Class_ClassShaman,
+		Equipment:     &proto.EquipmentSpec{},
+		Consumes:      Phase1Consumes.Consumes,
+		Spec:          PlayerOptionsBasic,
+		TalentsString: DefaultTalents,
+		Rotation:      rotation,
+	}
+	result := core.RunRaidSim(&proto.RaidSimRequest{
+		Raid:       core.SinglePlayerRaidProto(player, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter:  core.MakeSingleTargetEncounter(0),
+		SimOptions: &proto.SimOptions{Iterations: 10, RandomSeed: 101, IsTest: true},
+	})
+	if result.Error != nil {
+		t.Fatalf("Sim failed: %s", result.Error.Message)
+	}
+
+	uptimes := make(map[int32]float64)
+	for _, aura := range result.RaidMetrics.Parties[0].Players[0].Auras {
+		uptimes[aura.Id.GetSpellId()] = aura.UptimeSecondsAvg
+	}
+
+	// The Windfury buff outlasts its totem by the rest of its last pulse.
+	if uptime := uptimes[core.WindfuryTotemBuffSpellId[3]]; uptime < 8 || uptime > 9 {
+		t.Fatalf("Expected Windfury to linger 8.5s into the fight, got %0.2fs", uptime)
+	}
+	if uptimes[core.GraceOfAirTotemBuffSpellId[3]] == 0 {
+		t.Fatalf("Expected Grace of Air to be active, got %v", uptimes)
+	}
+}
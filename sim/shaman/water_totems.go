@@ -36,7 +36,7 @@ func (shaman *Shaman) registerHealingStreamTotemSpell() {
 func (shaman *Shaman) newHealingStreamTotemSpellConfig(rank int) core.SpellConfig {
 	spellId := HealingStreamTotemSpellId[rank]
 	healId := HealingStreamTotemHealId[rank]
-	baseHealing := HealingStreamTotemBaseHealing[rank]*shaman.purificationHealingModifier() + shaman.restorativeTotemsModifier()
+	baseHealing := HealingStreamTotemBaseHealing[rank] * (1 + shaman.purificationHealingModifier() + shaman.restorativeTotemsModifier())
 	spellCoeff := HealingStreamTotemSpellCoeff[rank]
 	manaCost := HealingStreamTotemManaCost[rank]
 	level := HealingStreamTotemLevel[rank]
@@ -75,12 +75,11 @@ func (shaman *Shaman) newHealingStreamTotemSpellConfig(rank int) core.SpellConfi
 	}
 
 	config.ApplyEffects = func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
-		shaman.TotemExpirations[WaterTotem] = sim.CurrentTime + duration
-		shaman.ActiveTotems[WaterTotem] = spell
-
+		hotAuras := make([]*core.Aura, 0, len(shaman.Party.Players))
 		for _, agent := range shaman.Party.Players {
-			spell.Hot(&agent.GetCharacter().Unit).Activate(sim)
+			hotAuras = append(hotAuras, spell.Hot(&agent.GetCharacter().Unit).Aura)
 		}
+		shaman.dropTotem(sim, WaterTotem, spell, duration, hotAuras)
 	}
 
 	return config
@@ -89,7 +88,6 @@ func (shaman *Shaman) newHealingStreamTotemSpellConfig(rank int) core.SpellConfi
 const ManaSpringTotemRanks = 4
 
 var ManaSpringTotemSpellId = [ManaSpringTotemRanks + 1]int32{0, 5675, 10495, 10496, 10497}
-var ManaSpringTotemManaRestore = [ManaSpringTotemRanks + 1]int32{0, 4, 6, 8, 10}
 var ManaSpringTotemManaCost = [ManaSpringTotemRanks + 1]float64{0, 40, 60, 80, 100}
 var ManaSpringTotemLevel = [ManaSpringTotemRanks + 1]int{0, 26, 36, 46, 56}
 
@@ -112,19 +110,94 @@ func (shaman *Shaman) registerManaSpringTotemSpell() {
 
 func (shaman *Shaman) newManaSpringTotemSpellConfig(rank int) core.SpellConfig {
 	spellId := ManaSpringTotemSpellId[rank]
-	// TODO: The sim won't respect the value of a totem dropped via the APL. It uses hard-coded values from buffs.go
-	// manaRestoreBase := ManaSpringTotemManaRestore[rank]
 	manaCost := ManaSpringTotemManaCost[rank]
 	level := ManaSpringTotemLevel[rank]
 
 	duration := time.Second * 60
+	multiplier := 1 + shaman.restorativeTotemsModifier()
+
+	partyAuras := shaman.newTotemPartyAuras(func(character *core.Character) *core.Aura {
+		label := fmt.Sprintf("Mana Spring Totem (Rank %d) - %s", rank, shaman.Label)
+		return core.ManaSpringTotemAura(&character.Unit, label, int32(rank), multiplier)
+	})
 
 	spell := shaman.newTotemSpellConfig(manaCost, spellId)
 	spell.RequiredLevel = level
 	spell.Rank = rank
 	spell.ApplyEffects = func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
-		shaman.TotemExpirations[WaterTotem] = sim.CurrentTime + duration
-		shaman.ActiveTotems[WaterTotem] = spell
+		shaman.dropTotem(sim, WaterTotem, spell, duration, partyAuras)
+	}
+	return spell
+}
+
+const ManaTideTotemRanks = 3
+
+var ManaTideTotemSpellId = [ManaTideTotemRanks + 1]int32{0, 16190, 17354, 17359}
+var ManaTideTotemRestoreId = [ManaTideTotemRanks + 1]int32{0, 16191, 17355, 17360}
+var ManaTideTotemManaRestore = [ManaTideTotemRanks + 1]float64{0, 170, 230, 290}
+var ManaTideTotemManaCost = [ManaTideTotemRanks + 1]float64{0, 40, 60, 80}
+var ManaTideTotemLevel = [ManaTideTotemRanks + 1]int{0, 40, 48, 58}
+
+func (shaman *Shaman) registerManaTideTotemSpell() {
+	shaman.ManaTideTotem = make([]*core.Spell, ManaTideTotemRanks+1)
+
+	cdTimer := shaman.NewTimer()
+
+	for rank := 1; rank <= ManaTideTotemRanks; rank++ {
+		config := shaman.newManaTideTotemSpellConfig(rank, cdTimer)
+
+		if config.RequiredLevel <= int(shaman.Level) {
+			shaman.ManaTideTotem[rank] = shaman.RegisterSpell(config)
+		}
+	}
+
+	shaman.WaterTotems = append(
+		shaman.WaterTotems,
+		core.FilterSlice(shaman.ManaTideTotem, func(spell *core.Spell) bool { return spell != nil })...,
+	)
+}
+
+func (shaman *Shaman) newManaTideTotemSpellConfig(rank int, cdTimer *core.Timer) core.SpellConfig {
+	spellId := ManaTideTotemSpellId[rank]
+	restoreId := ManaTideTotemRestoreId[rank]
+	manaRestore := ManaTideTotemManaRestore[rank]
+	manaCost := ManaTideTotemManaCost[rank]
+	level := ManaTideTotemLevel[rank]
+
+	duration := core.ManaTideTotemDuration
+	numTicks := 4
+
+	// The mana is attributed to this shaman in the metrics of each party member.
+	metricsActionID := core.ActionID{SpellID: restoreId, Tag: shaman.Index}
+	party := shaman.Party.Players
+	metrics := make([]*core.ResourceMetrics, len(party))
+	for i, agent := range party {
+		if character := agent.GetCharacter(); character.HasManaBar() {
+			metrics[i] = character.NewManaMetrics(metricsActionID)
+		}
+	}
+
+	spell := shaman.newTotemSpellConfig(manaCost, spellId)
+	spell.RequiredLevel = level
+	spell.Rank = rank
+	spell.Cast.CD = core.Cooldown{
+		Timer:    cdTimer,
+		Duration: core.ManaTideTotemCD,
+	}
+	spell.ApplyEffects = func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+		// The party is restored by the totem's pulses, which stop when another water totem replaces it.
+		shaman.dropTotem(sim, WaterTotem, spell, duration, nil)
+		shaman.TotemPulses[WaterTotem] = core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+			Period:   duration / time.Duration(numTicks),
+			NumTicks: numTicks,
+			OnAction: func(sim *core.Simulation) {
+				for i, agent := range party {
+					if metrics[i] != nil {
+						agent.GetCharacter().AddMana(sim, manaRestore, metrics[i])
+					}
+				}
+			},
+		})
 	}
 	return spell
 }
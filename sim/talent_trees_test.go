@@ -0,0 +1,86 @@
+package sim
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/isfir/wowsims-turtle/sim/core"
+	"github.com/isfir/wowsims-turtle/sim/druid"
+	"github.com/isfir/wowsims-turtle/sim/hunter"
+	"github.com/isfir/wowsims-turtle/sim/mage"
+	"github.com/isfir/wowsims-turtle/sim/paladin"
+	"github.com/isfir/wowsims-turtle/sim/priest"
+	"github.com/isfir/wowsims-turtle/sim/rogue"
+	"github.com/isfir/wowsims-turtle/sim/shaman"
+	"github.com/isfir/wowsims-turtle/sim/warlock"
+	"github.com/isfir/wowsims-turtle/sim/warrior"
+)
+
+type uiTalentLocation struct {
+	RowIdx int `json:"rowIdx"`
+	ColIdx int `json:"colIdx"`
+}
+
+type uiTalentTree struct {
+	Name    string `json:"name"`
+	Talents []struct {
+		FieldName      string            `json:"fieldName"`
+		Location       uiTalentLocation  `json:"location"`
+		MaxPoints      int               `json:"maxPoints"`
+		PrereqLocation *uiTalentLocation `json:"prereqLocation"`
+	} `json:"talents"`
+}
+
+// The sim's talent tree layouts are a copy of the UI's tree configs, which are the
+// source of truth for the talent string format.
+func TestTalentTreesMatchUI(t *testing.T) {
+	for class, trees := range map[string]core.TalentTrees{
+		"druid":   druid.TalentTrees,
+		"hunter":  hunter.TalentTrees,
+		"mage":    mage.TalentTrees,
+		"paladin": paladin.TalentTrees,
+		"priest":  priest.TalentTrees,
+		"rogue":   rogue.TalentTrees,
+		"shaman":  shaman.TalentTrees,
+		"warlock": warlock.TalentTrees,
+		"warrior": warrior.TalentTrees,
+	} {
+		data, err := os.ReadFile("../ui/core/talents/trees/" + class + ".json")
+		if err != nil {
+			t.Fatalf("Failed to read %s talent trees: %s", class, err)
+		}
+		var uiTrees []uiTalentTree
+		if err := json.Unmarshal(data, &uiTrees); err != nil {
+			t.Fatalf("Failed to parse %s talent trees: %s", class, err)
+		}
+		if len(uiTrees) != len(trees) {
+			t.Fatalf("%s has %d trees in the UI, but %d in the sim", class, len(uiTrees), len(trees))
+		}
+
+		for i, uiTree := range uiTrees {
+			names := make(map[uiTalentLocation]string)
+			for _, talent := range uiTree.Talents {
+				names[talent.Location] = talent.FieldName
+			}
+
+			var expected []core.TalentConfig
+			for _, talent := range uiTree.Talents {
+				config := core.TalentConfig{Name: talent.FieldName, Row: talent.Location.RowIdx, MaxPoints: talent.MaxPoints}
+				if talent.PrereqLocation != nil {
+					config.Prereq = names[*talent.PrereqLocation]
+				}
+				expected = append(expected, config)
+			}
+
+			if len(expected) != len(trees[i]) {
+				t.Fatalf("%s %s has %d talents in the UI, but %d in the sim", class, uiTree.Name, len(expected), len(trees[i]))
+			}
+			for j := range expected {
+				if expected[j] != trees[i][j] {
+					t.Errorf("%s %s talent %d is %+v in the UI, but %+v in the sim", class, uiTree.Name, j, expected[j], trees[i][j])
+				}
+			}
+		}
+	}
+}
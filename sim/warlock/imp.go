@@ -71,16 +71,29 @@ func (warlock *Warlock) makeImp() *WarlockPet {
 func (wp *WarlockPet) registerImpFireboltSpell() {
 	warlockLevel := wp.owner.Level
 	// assuming max rank available
-	rank := map[int32]int{25: 3, 40: 5, 50: 6, 60: 7}[warlockLevel]
+	maxRank := map[int32]int{25: 3, 40: 5, 50: 6, 60: 7}[warlockLevel]
 
-	if rank == 0 {
-		rank = 1
+	if maxRank == 0 {
+		maxRank = 1
 	}
 
+	rank := maxRank
 	if wp.owner.Options.MaxFireboltRank != proto.WarlockOptions_NoMaximum {
 		rank = min(rank, int(wp.owner.Options.MaxFireboltRank))
 	}
 
+	// Every known rank is registered so pet rotations can choose one, but the default
+	// rotation only casts the highest rank allowed by the options.
+	cdTimer := wp.NewTimer()
+	for r := 1; r <= maxRank; r++ {
+		spell := wp.newImpFireboltSpell(r, cdTimer)
+		if r == rank {
+			wp.primaryAbility = spell
+		}
+	}
+}
+
+func (wp *WarlockPet) newImpFireboltSpell(rank int, cdTimer *core.Timer) *core.Spell {
 	spellCoeff := [8]float64{0, .164, .314, .529, .571, .571, .571, .571}[rank]
 	baseDamage := [8][]float64{{0, 0}, {7, 10}, {14, 16}, {25, 29}, {36, 41}, {52, 59}, {72, 80}, {85, 96}}[rank]
 	spellId := [8]int32{0, 3110, 7799, 7800, 7801, 7802, 11762, 11763}[rank]
@@ -91,7 +104,7 @@ func (wp *WarlockPet) registerImpFireboltSpell() {
 	baseDamage[0] *= improvedImp
 	baseDamage[1] *= improvedImp
 
-	wp.primaryAbility = wp.RegisterSpell(core.SpellConfig{
+	return wp.RegisterSpell(core.SpellConfig{
 		ActionID:      core.ActionID{SpellID: spellId},
 		SpellSchool:   core.SpellSchoolFire,
 		DefenseType:   core.DefenseTypeMagic,
@@ -109,7 +122,7 @@ func (wp *WarlockPet) registerImpFireboltSpell() {
 			},
 			// Adding an artificial CD to account for real delay in imp casts in-game
 			CD: core.Cooldown{
-				Timer:    wp.NewTimer(),
+				Timer:    cdTimer,
 				Duration: time.Millisecond * 200,
 			},
 		},
@@ -9,6 +9,70 @@ import (
 	"github.com/isfir/wowsims-turtle/sim/core/stats"
 )
 
+// Layout of the talent trees, in the same order as the talent string.
+var TalentTrees = core.TalentTrees{
+	{ // Affliction
+		{Name: "suppression", Row: 0, MaxPoints: 5},
+		{Name: "improvedCorruption", Row: 0, MaxPoints: 5},
+		{Name: "improvedCurseOfWeakness", Row: 1, MaxPoints: 3},
+		{Name: "improvedDrainSoul", Row: 1, MaxPoints: 2},
+		{Name: "improvedLifeTap", Row: 1, MaxPoints: 2},
+		{Name: "improvedDrainLife", Row: 1, MaxPoints: 5},
+		{Name: "improvedCurseOfAgony", Row: 2, MaxPoints: 3},
+		{Name: "felConcentration", Row: 2, MaxPoints: 5},
+		{Name: "amplifyCurse", Row: 2, MaxPoints: 1},
+		{Name: "grimReach", Row: 3, MaxPoints: 2},
+		{Name: "nightfall", Row: 3, MaxPoints: 2},
+		{Name: "improvedDrainMana", Row: 3, MaxPoints: 2},
+		{Name: "siphonLife", Row: 4, MaxPoints: 1},
+		{Name: "curseOfExhaustion", Row: 4, MaxPoints: 1, Prereq: "amplifyCurse"},
+		{Name: "improvedCurseOfExhaustion", Row: 4, MaxPoints: 4, Prereq: "curseOfExhaustion"},
+		{Name: "shadowMastery", Row: 5, MaxPoints: 5, Prereq: "siphonLife"},
+		{Name: "darkPact", Row: 6, MaxPoints: 1},
+	},
+	{ // Demonology
+		{Name: "improvedHealthstone", Row: 0, MaxPoints: 2},
+		{Name: "improvedImp", Row: 0, MaxPoints: 3},
+		{Name: "demonicEmbrace", Row: 0, MaxPoints: 5},
+		{Name: "improvedHealthFunnel", Row: 1, MaxPoints: 2},
+		{Name: "improvedVoidwalker", Row: 1, MaxPoints: 3},
+		{Name: "felIntellect", Row: 1, MaxPoints: 5},
+		{Name: "improvedSayaad", Row: 2, MaxPoints: 3},
+		{Name: "felDomination", Row: 2, MaxPoints: 1},
+		{Name: "felStamina", Row: 2, MaxPoints: 5},
+		{Name: "masterSummoner", Row: 3, MaxPoints: 2, Prereq: "felDomination"},
+		{Name: "unholyPower", Row: 3, MaxPoints: 5},
+		{Name: "improvedSubjugateDemon", Row: 4, MaxPoints: 5},
+		{Name: "demonicSacrifice", Row: 4, MaxPoints: 1},
+		{Name: "improvedFirestone", Row: 4, MaxPoints: 2},
+		{Name: "masterDemonologist", Row: 5, MaxPoints: 5, Prereq: "unholyPower"},
+		{Name: "soulLink", Row: 6, MaxPoints: 1, Prereq: "demonicSacrifice"},
+		{Name: "improvedSpellstone", Row: 6, MaxPoints: 2},
+	},
+	{ // Destruction
+		{Name: "improvedShadowBolt", Row: 0, MaxPoints: 5},
+		{Name: "cataclysm", Row: 0, MaxPoints: 5},
+		{Name: "bane", Row: 1, MaxPoints: 5},
+		{Name: "aftermath", Row: 1, MaxPoints: 5},
+		{Name: "improvedFirebolt", Row: 2, MaxPoints: 2},
+		{Name: "improvedLashOfPain", Row: 2, MaxPoints: 2},
+		{Name: "devastation", Row: 2, MaxPoints: 5},
+		{Name: "shadowburn", Row: 2, MaxPoints: 1},
+		{Name: "intensity", Row: 3, MaxPoints: 2},
+		{Name: "destructiveReach", Row: 3, MaxPoints: 2},
+		{Name: "improvedSearingPain", Row: 3, MaxPoints: 5},
+		{Name: "pyroclasm", Row: 4, MaxPoints: 2, Prereq: "intensity"},
+		{Name: "improvedImmolate", Row: 4, MaxPoints: 5},
+		{Name: "ruin", Row: 4, MaxPoints: 1, Prereq: "devastation"},
+		{Name: "emberstorm", Row: 5, MaxPoints: 5},
+		{Name: "conflagrate", Row: 6, MaxPoints: 1, Prereq: "improvedImmolate"},
+	},
+}
+
+func init() {
+	core.RegisterTalentTrees(proto.Class_ClassWarlock, TalentTrees)
+}
+
 func (warlock *Warlock) ApplyTalents() {
 	warlock.applyWeaponImbue()
 
@@ -71,7 +71,7 @@ func (warrior *Warrior) registerSweepingStrikesCD() {
 				spellToUse = hitSchoolDamagWithValue
 			}
 
-			if numTargets > 1 {
+			if numTargets > 1 && sim.GetNumActiveTargets() > 1 {
 				target := warrior.Env.NextTargetUnit(result.Target)
 				spellToUse.Cast(sim, target)
 				spellToUse.SpellMetrics[target.UnitIndex].Casts--
@@ -105,7 +105,7 @@ func (warrior *Warrior) registerSweepingStrikesCD() {
 		Spell: SweepingStrikes.Spell,
 		Type:  core.CooldownTypeDPS,
 		ShouldActivate: func(sim *core.Simulation, character *core.Character) bool {
-			return sim.GetNumTargets() >= 2
+			return sim.GetNumActiveTargets() >= 2
 		},
 	})
 }
@@ -9,6 +9,72 @@ import (
 	"github.com/isfir/wowsims-turtle/sim/core/stats"
 )
 
+// Layout of the talent trees, in the same order as the talent string.
+var TalentTrees = core.TalentTrees{
+	{ // Arms
+		{Name: "improvedHeroicStrike", Row: 0, MaxPoints: 3},
+		{Name: "deflection", Row: 0, MaxPoints: 5},
+		{Name: "improvedRend", Row: 0, MaxPoints: 3},
+		{Name: "improvedCharge", Row: 1, MaxPoints: 2},
+		{Name: "tacticalMastery", Row: 1, MaxPoints: 5},
+		{Name: "improvedThunderClap", Row: 1, MaxPoints: 3},
+		{Name: "improvedOverpower", Row: 2, MaxPoints: 2},
+		{Name: "angerManagement", Row: 2, MaxPoints: 1, Prereq: "tacticalMastery"},
+		{Name: "deepWounds", Row: 2, MaxPoints: 3, Prereq: "improvedRend"},
+		{Name: "twoHandedWeaponSpecialization", Row: 3, MaxPoints: 5},
+		{Name: "impale", Row: 3, MaxPoints: 2, Prereq: "deepWounds"},
+		{Name: "axeSpecialization", Row: 4, MaxPoints: 5},
+		{Name: "sweepingStrikes", Row: 4, MaxPoints: 1},
+		{Name: "maceSpecialization", Row: 4, MaxPoints: 5},
+		{Name: "swordSpecialization", Row: 4, MaxPoints: 5},
+		{Name: "polearmSpecialization", Row: 5, MaxPoints: 5},
+		{Name: "improvedHamstring", Row: 5, MaxPoints: 3},
+		{Name: "mortalStrike", Row: 6, MaxPoints: 1, Prereq: "sweepingStrikes"},
+	},
+	{ // Fury
+		{Name: "boomingVoice", Row: 0, MaxPoints: 5},
+		{Name: "cruelty", Row: 0, MaxPoints: 5},
+		{Name: "improvedDemoralizingShout", Row: 1, MaxPoints: 5},
+		{Name: "unbridledWrath", Row: 1, MaxPoints: 5},
+		{Name: "improvedCleave", Row: 2, MaxPoints: 3},
+		{Name: "piercingHowl", Row: 2, MaxPoints: 1},
+		{Name: "bloodCraze", Row: 2, MaxPoints: 3},
+		{Name: "improvedBattleShout", Row: 2, MaxPoints: 5},
+		{Name: "dualWieldSpecialization", Row: 3, MaxPoints: 5},
+		{Name: "improvedExecute", Row: 3, MaxPoints: 2},
+		{Name: "enrage", Row: 3, MaxPoints: 5},
+		{Name: "improvedSlam", Row: 4, MaxPoints: 5},
+		{Name: "deathWish", Row: 4, MaxPoints: 1},
+		{Name: "improvedIntercept", Row: 4, MaxPoints: 2},
+		{Name: "improvedBerserkerRage", Row: 5, MaxPoints: 2},
+		{Name: "flurry", Row: 5, MaxPoints: 5, Prereq: "enrage"},
+		{Name: "bloodthirst", Row: 6, MaxPoints: 1, Prereq: "deathWish"},
+	},
+	{ // Protection
+		{Name: "shieldSpecialization", Row: 0, MaxPoints: 5},
+		{Name: "anticipation", Row: 0, MaxPoints: 5},
+		{Name: "improvedBloodrage", Row: 1, MaxPoints: 2},
+		{Name: "toughness", Row: 1, MaxPoints: 5},
+		{Name: "ironWill", Row: 1, MaxPoints: 5},
+		{Name: "lastStand", Row: 2, MaxPoints: 1, Prereq: "improvedBloodrage"},
+		{Name: "improvedShieldBlock", Row: 2, MaxPoints: 3, Prereq: "shieldSpecialization"},
+		{Name: "improvedRevenge", Row: 2, MaxPoints: 3},
+		{Name: "defiance", Row: 2, MaxPoints: 5},
+		{Name: "improvedSunderArmor", Row: 3, MaxPoints: 3},
+		{Name: "improvedDisarm", Row: 3, MaxPoints: 3},
+		{Name: "improvedTaunt", Row: 3, MaxPoints: 2},
+		{Name: "improvedShieldWall", Row: 4, MaxPoints: 2},
+		{Name: "concussionBlow", Row: 4, MaxPoints: 1},
+		{Name: "improvedShieldBash", Row: 4, MaxPoints: 2},
+		{Name: "oneHandedWeaponSpecialization", Row: 5, MaxPoints: 5},
+		{Name: "shieldSlam", Row: 6, MaxPoints: 1, Prereq: "concussionBlow"},
+	},
+}
+
+func init() {
+	core.RegisterTalentTrees(proto.Class_ClassWarrior, TalentTrees)
+}
+
 func (warrior *Warrior) ToughnessArmorMultiplier() float64 {
 	return 1.0 + 0.02*float64(warrior.Talents.Toughness)
 }
@@ -205,18 +271,20 @@ func (warrior *Warrior) applyEnrage() {
 		return
 	}
 
+	damageMultiplier := 1 + 0.05*float64(warrior.Talents.Enrage)
+
 	warrior.EnrageAura = warrior.GetOrRegisterAura(core.Aura{
 		Label:     "Enrage",
 		ActionID:  core.ActionID{SpellID: 13048},
 		Duration:  time.Second * 12,
 		MaxStacks: 12,
 		OnGain: func(aura *core.Aura, sim *core.Simulation) {
-			warrior.PseudoStats.SchoolDamageDealtMultiplier[stats.SchoolIndexPhysical] *= 1 + 0.05*float64(warrior.Talents.Enrage)
+			warrior.PseudoStats.SchoolDamageDealtMultiplier[stats.SchoolIndexPhysical] *= damageMultiplier
 		},
 		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
-			warrior.PseudoStats.SchoolDamageDealtMultiplier[stats.SchoolIndexPhysical] /= 1 + 0.05*float64(warrior.Talents.Enrage)
+			warrior.PseudoStats.SchoolDamageDealtMultiplier[stats.SchoolIndexPhysical] /= damageMultiplier
 		},
-	})
+	}).AttachDamageAttribution(core.DamageAttribution{Multiplier: damageMultiplier, School: core.SpellSchoolPhysical})
 
 	warrior.EnrageAura.NewExclusiveEffect("Enrage", true, core.ExclusiveEffect{Priority: 5 * float64(warrior.Talents.Enrage)})
 
@@ -405,21 +473,22 @@ func (warrior *Warrior) registerDeathWishCD() {
 	}
 
 	actionID := core.ActionID{SpellID: 12328}
+	damageMultiplier := 1.2
 
 	deathWishAura := warrior.RegisterAura(core.Aura{
 		Label:    "Death Wish",
 		ActionID: actionID,
 		Duration: time.Second * 30,
 		OnGain: func(aura *core.Aura, sim *core.Simulation) {
-			warrior.PseudoStats.SchoolDamageDealtMultiplier[stats.SchoolIndexPhysical] *= 1.2
+			warrior.PseudoStats.SchoolDamageDealtMultiplier[stats.SchoolIndexPhysical] *= damageMultiplier
 			warrior.PseudoStats.ArmorMultiplier *= 0.8
 		},
 		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
-			warrior.PseudoStats.SchoolDamageDealtMultiplier[stats.SchoolIndexPhysical] /= 1.2
+			warrior.PseudoStats.SchoolDamageDealtMultiplier[stats.SchoolIndexPhysical] /= damageMultiplier
 			warrior.PseudoStats.ArmorMultiplier /= 0.8
 		},
-	})
-	core.RegisterPercentDamageModifierEffect(deathWishAura, 1.2)
+	}).AttachDamageAttribution(core.DamageAttribution{Multiplier: damageMultiplier, School: core.SpellSchoolPhysical})
+	core.RegisterPercentDamageModifierEffect(deathWishAura, damageMultiplier)
 
 	warrior.DeathWish = warrior.RegisterSpell(AnyStance, core.SpellConfig{
 		ActionID: actionID,
@@ -46,12 +46,13 @@ func (warrior *Warrior) registerThunderClapSpell() {
 		ThreatMultiplier: 2.5,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			for idx := range results {
+			numTargets := min(len(results), int(sim.GetNumActiveTargets()))
+			for idx := range results[:numTargets] {
 				results[idx] = spell.CalcDamage(sim, target, baseDamage, spell.OutcomeMagicHitAndCrit)
 				target = sim.Environment.NextTargetUnit(target)
 			}
 
-			for _, result := range results {
+			for _, result := range results[:numTargets] {
 				spell.DealDamage(sim, result)
 				if result.Landed() {
 					warrior.ThunderClapAuras.Get(result.Target).Activate(sim)
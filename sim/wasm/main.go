@@ -36,8 +36,17 @@ func main() {
 	js.Global().Set("statWeightRequests", js.FuncOf(statWeightRequests))
 	js.Global().Set("statWeightCompute", js.FuncOf(statWeightCompute))
 	js.Global().Set("statWeightsAsync", js.FuncOf(statWeightsAsync))
+	js.Global().Set("buffValues", js.FuncOf(buffValues))
+	js.Global().Set("buffValuesAsync", js.FuncOf(buffValuesAsync))
+	js.Global().Set("compare", js.FuncOf(compare))
+	js.Global().Set("compareAsync", js.FuncOf(compareAsync))
+	js.Global().Set("statScaling", js.FuncOf(statScaling))
+	js.Global().Set("statScalingAsync", js.FuncOf(statScalingAsync))
 	js.Global().Set("bulkSimAsync", js.FuncOf(bulkSimAsync))
 	js.Global().Set("abortById", js.FuncOf(abortById))
+	js.Global().Set("interactiveSimStart", js.FuncOf(interactiveSimStart))
+	js.Global().Set("interactiveSimStep", js.FuncOf(interactiveSimStep))
+	js.Global().Set("interactiveSimState", js.FuncOf(interactiveSimState))
 	js.Global().Call("wasmready")
 	<-c
 }
@@ -262,6 +271,123 @@ func statWeightCompute(this js.Value, args []js.Value) interface{} {
 	return outArray
 }
 
+func buffValues(this js.Value, args []js.Value) interface{} {
+	req := &proto.BuffValuesRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), req); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+	result := core.BuffValues(req)
+
+	outbytes, err := googleProto.Marshal(result)
+	if err != nil {
+		log.Printf("[ERROR] Failed to marshal result: %s", err.Error())
+		return nil
+	}
+
+	outArray := js.Global().Get("Uint8Array").New(len(outbytes))
+	js.CopyBytesToJS(outArray, outbytes)
+
+	return outArray
+}
+
+func buffValuesAsync(this js.Value, args []js.Value) interface{} {
+	req := &proto.BuffValuesRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), req); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+
+	requestId := args[2].String()
+	if strings.HasPrefix(requestId, "<T") {
+		requestId = "" // Make it return the error for an empty id
+	}
+
+	reporter := make(chan *proto.ProgressMetrics, 100)
+
+	go core.BuffValuesAsync(req, reporter, requestId)
+	go processAsyncProgress(args[1], reporter)
+	return js.Undefined()
+}
+
+func compare(this js.Value, args []js.Value) interface{} {
+	req := &proto.CompareRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), req); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+	result := core.Compare(req)
+
+	outbytes, err := googleProto.Marshal(result)
+	if err != nil {
+		log.Printf("[ERROR] Failed to marshal result: %s", err.Error())
+		return nil
+	}
+
+	outArray := js.Global().Get("Uint8Array").New(len(outbytes))
+	js.CopyBytesToJS(outArray, outbytes)
+
+	return outArray
+}
+
+func compareAsync(this js.Value, args []js.Value) interface{} {
+	req := &proto.CompareRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), req); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+
+	requestId := args[2].String()
+	if strings.HasPrefix(requestId, "<T") {
+		requestId = "" // Make it return the error for an empty id
+	}
+
+	reporter := make(chan *proto.ProgressMetrics, 100)
+
+	go core.CompareAsync(req, reporter, requestId)
+	go processAsyncProgress(args[1], reporter)
+	return js.Undefined()
+}
+
+func statScaling(this js.Value, args []js.Value) interface{} {
+	req := &proto.StatScalingRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), req); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+	result := core.StatScaling(req)
+
+	outbytes, err := googleProto.Marshal(result)
+	if err != nil {
+		log.Printf("[ERROR] Failed to marshal result: %s", err.Error())
+		return nil
+	}
+
+	outArray := js.Global().Get("Uint8Array").New(len(outbytes))
+	js.CopyBytesToJS(outArray, outbytes)
+
+	return outArray
+}
+
+func statScalingAsync(this js.Value, args []js.Value) interface{} {
+	req := &proto.StatScalingRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), req); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+
+	requestId := args[2].String()
+	if strings.HasPrefix(requestId, "<T") {
+		requestId = "" // Make it return the error for an empty id
+	}
+
+	reporter := make(chan *proto.ProgressMetrics, 100)
+
+	go core.StatScalingAsync(req, reporter, requestId)
+	go processAsyncProgress(args[1], reporter)
+	return js.Undefined()
+}
+
 func bulkSimAsync(this js.Value, args []js.Value) interface{} {
 	rsr := &proto.BulkSimRequest{}
 	if err := googleProto.Unmarshal(getArgsBinary(args[0]), rsr); err != nil {
@@ -359,6 +485,45 @@ func abortById(this js.Value, args []js.Value) interface{} {
 	return outArray
 }
 
+func interactiveSimStart(this js.Value, args []js.Value) interface{} {
+	req := &proto.InteractiveSimStartRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), req); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+	return marshalInteractiveState(core.StartInteractiveSim(req))
+}
+
+func interactiveSimStep(this js.Value, args []js.Value) interface{} {
+	req := &proto.InteractiveSimStepRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), req); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+	return marshalInteractiveState(core.StepInteractiveSim(req))
+}
+
+func interactiveSimState(this js.Value, args []js.Value) interface{} {
+	req := &proto.InteractiveSimStateRequest{}
+	if err := googleProto.Unmarshal(getArgsBinary(args[0]), req); err != nil {
+		log.Printf("Failed to parse request: %s", err)
+		return nil
+	}
+	return marshalInteractiveState(core.GetInteractiveSimState(req))
+}
+
+func marshalInteractiveState(state *proto.InteractiveSimState) interface{} {
+	outbytes, err := googleProto.Marshal(state)
+	if err != nil {
+		log.Printf("[ERROR] Failed to marshal InteractiveSimState: %s", err.Error())
+		return nil
+	}
+	outArray := js.Global().Get("Uint8Array").New(len(outbytes))
+	js.CopyBytesToJS(outArray, outbytes)
+
+	return outArray
+}
+
 // Assumes args[0] is a Uint8Array
 func getArgsBinary(value js.Value) []byte {
 	data := make([]byte, value.Get("length").Int())
@@ -388,7 +553,7 @@ func processAsyncProgress(progFunc js.Value, reporter chan *proto.ProgressMetric
 			js.CopyBytesToJS(outArray, outbytes)
 			progFunc.Invoke(outArray)
 
-			if progMetric.FinalWeightResult != nil || progMetric.FinalRaidResult != nil || progMetric.FinalBulkResult != nil {
+			if progMetric.FinalWeightResult != nil || progMetric.FinalRaidResult != nil || progMetric.FinalBulkResult != nil || progMetric.FinalBuffValuesResult != nil || progMetric.FinalCompareResult != nil || progMetric.FinalStatScalingResult != nil {
 				return
 			}
 		}
@@ -103,9 +103,27 @@ var handlers = map[string]apiHandler{
 	"/statWeightCompute": {msg: func() googleProto.Message { return &proto.StatWeightsCalcRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
 		return core.StatWeightCompute(msg.(*proto.StatWeightsCalcRequest))
 	}},
+	"/buffValues": {msg: func() googleProto.Message { return &proto.BuffValuesRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.BuffValues(msg.(*proto.BuffValuesRequest))
+	}},
+	"/compare": {msg: func() googleProto.Message { return &proto.CompareRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.Compare(msg.(*proto.CompareRequest))
+	}},
+	"/statScaling": {msg: func() googleProto.Message { return &proto.StatScalingRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.StatScaling(msg.(*proto.StatScalingRequest))
+	}},
 	"/computeStats": {msg: func() googleProto.Message { return &proto.ComputeStatsRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
 		return core.ComputeStats(msg.(*proto.ComputeStatsRequest))
 	}},
+	"/interactiveSim/start": {msg: func() googleProto.Message { return &proto.InteractiveSimStartRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.StartInteractiveSim(msg.(*proto.InteractiveSimStartRequest))
+	}},
+	"/interactiveSim/step": {msg: func() googleProto.Message { return &proto.InteractiveSimStepRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.StepInteractiveSim(msg.(*proto.InteractiveSimStepRequest))
+	}},
+	"/interactiveSim/state": {msg: func() googleProto.Message { return &proto.InteractiveSimStateRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.GetInteractiveSimState(msg.(*proto.InteractiveSimStateRequest))
+	}},
 	"/abortById": {msg: func() googleProto.Message { return &proto.AbortRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
 		requestId := msg.(*proto.AbortRequest).RequestId
 		triggered := simsignals.AbortById(requestId)
@@ -120,6 +138,15 @@ var asyncAPIHandlers = map[string]asyncAPIHandler{
 	"/statWeightsAsync": {msg: func() googleProto.Message { return &proto.StatWeightsRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics, requestId string) {
 		core.StatWeightsAsync(msg.(*proto.StatWeightsRequest), reporter, requestId)
 	}},
+	"/buffValuesAsync": {msg: func() googleProto.Message { return &proto.BuffValuesRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics, requestId string) {
+		core.BuffValuesAsync(msg.(*proto.BuffValuesRequest), reporter, requestId)
+	}},
+	"/compareAsync": {msg: func() googleProto.Message { return &proto.CompareRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics, requestId string) {
+		core.CompareAsync(msg.(*proto.CompareRequest), reporter, requestId)
+	}},
+	"/statScalingAsync": {msg: func() googleProto.Message { return &proto.StatScalingRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics, requestId string) {
+		core.StatScalingAsync(msg.(*proto.StatScalingRequest), reporter, requestId)
+	}},
 	"/bulkSimAsync": {msg: func() googleProto.Message { return &proto.BulkSimRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics, requestId string) {
 		core.RunBulkSimAsync(msg.(*proto.BulkSimRequest), reporter, requestId)
 	}},
@@ -203,7 +230,7 @@ func (s *server) handleAsyncAPI(w http.ResponseWriter, r *http.Request) {
 					return
 				}
 				simProgress.latestProgress.Store(progMetric)
-				if progMetric.FinalRaidResult != nil || progMetric.FinalWeightResult != nil || progMetric.FinalBulkResult != nil {
+				if progMetric.FinalRaidResult != nil || progMetric.FinalWeightResult != nil || progMetric.FinalBulkResult != nil || progMetric.FinalBuffValuesResult != nil || progMetric.FinalCompareResult != nil || progMetric.FinalStatScalingResult != nil {
 					return
 				}
 			}
@@ -261,7 +288,7 @@ func (s *server) setupAsyncServer() {
 		}
 
 		// If this was the last result, delete the cache for this simulation.
-		if latest.FinalRaidResult != nil || latest.FinalWeightResult != nil || latest.FinalBulkResult != nil {
+		if latest.FinalRaidResult != nil || latest.FinalWeightResult != nil || latest.FinalBulkResult != nil || latest.FinalBuffValuesResult != nil || latest.FinalCompareResult != nil || latest.FinalStatScalingResult != nil {
 			s.progMut.Lock()
 			delete(s.asyncProgresses, msg.ProgressId)
 			s.progMut.Unlock()
@@ -179,6 +179,10 @@ class AdvancedEncounterModal extends BaseModal {
 			changedEvent: (encounter: Encounter) => encounter.targetsChangeEmitter,
 			getValue: (encounter: Encounter) => encounter.targets,
 			setValue: (eventID: EventID, encounter: Encounter, newValue: Array<TargetProto>) => {
+				// Preset phases reference targets by index, so drop them once the target list changes shape.
+				if (newValue.length != encounter.targets.length) {
+					encounter.phases = [];
+				}
 				encounter.targets = newValue;
 				encounter.targetsChangeEmitter.emit(eventID);
 			},
@@ -228,6 +232,9 @@ class TargetPicker extends Input<Encounter, TargetProto> {
 	private readonly levelPicker: Input<null, number>;
 	private readonly mobTypePicker: Input<null, number>;
 	private readonly tankIndexPicker: Input<null, number>;
+	private readonly spawnTimePicker: Input<null, number>;
+	private readonly despawnTimePicker: Input<null, number>;
+	private readonly despawnOnDeathPicker: Input<null, boolean>;
 	private readonly statPickers: Array<Input<null, number>>;
 	private readonly swingSpeedPicker: Input<null, number>;
 	private readonly minBaseDamagePicker: Input<null, number>;
@@ -369,6 +376,43 @@ class TargetPicker extends Input<Encounter, TargetProto> {
 				encounter.targetsChangeEmitter.emit(eventID);
 			},
 		});
+		this.spawnTimePicker = new NumberPicker(section1, null, {
+			id: 'target-picker-spawn-time',
+			label: 'Spawn Time',
+			labelTooltip: 'Time in seconds at which this enemy joins the fight. Set to 0 to have it present from the start.',
+			float: true,
+			changedEvent: () => encounter.targetsChangeEmitter,
+			getValue: () => this.getTarget().spawnTime,
+			setValue: (eventID: EventID, _: null, newValue: number) => {
+				this.getTarget().spawnTime = newValue;
+				encounter.targetsChangeEmitter.emit(eventID);
+			},
+		});
+		this.despawnTimePicker = new NumberPicker(section1, null, {
+			id: 'target-picker-despawn-time',
+			label: 'Despawn Time',
+			labelTooltip: 'Time in seconds at which this enemy leaves the fight. Set to 0 to keep it until the end.',
+			float: true,
+			changedEvent: () => encounter.targetsChangeEmitter,
+			getValue: () => this.getTarget().despawnTime,
+			setValue: (eventID: EventID, _: null, newValue: number) => {
+				this.getTarget().despawnTime = newValue;
+				encounter.targetsChangeEmitter.emit(eventID);
+			},
+		});
+		this.despawnOnDeathPicker = new BooleanPicker(section1, null, {
+			id: 'target-picker-despawn-on-death',
+			label: 'Dies at 0 Health',
+			labelTooltip: 'If checked, this enemy leaves the fight once it has taken its Health worth of damage.',
+			inline: true,
+			reverse: true,
+			changedEvent: () => encounter.targetsChangeEmitter,
+			getValue: () => this.getTarget().despawnOnDeath,
+			setValue: (eventID: EventID, _: null, newValue: boolean) => {
+				this.getTarget().despawnOnDeath = newValue;
+				encounter.targetsChangeEmitter.emit(eventID);
+			},
+		});
 
 		this.targetInputPickers = makeTargetInputsPicker(section1, encounter, this.targetIndex);
 
@@ -498,6 +542,9 @@ class TargetPicker extends Input<Encounter, TargetProto> {
 			level: this.levelPicker.getInputValue(),
 			mobType: this.mobTypePicker.getInputValue(),
 			tankIndex: this.tankIndexPicker.getInputValue(),
+			spawnTime: this.spawnTimePicker.getInputValue(),
+			despawnTime: this.despawnTimePicker.getInputValue(),
+			despawnOnDeath: this.despawnOnDeathPicker.getInputValue(),
 			swingSpeed: this.swingSpeedPicker.getInputValue(),
 			minBaseDamage: this.minBaseDamagePicker.getInputValue(),
 			dualWield: this.dualWieldPicker.getInputValue(),
@@ -521,6 +568,9 @@ class TargetPicker extends Input<Encounter, TargetProto> {
 		this.levelPicker.setInputValue(newValue.level);
 		this.mobTypePicker.setInputValue(newValue.mobType);
 		this.tankIndexPicker.setInputValue(newValue.tankIndex);
+		this.spawnTimePicker.setInputValue(newValue.spawnTime);
+		this.despawnTimePicker.setInputValue(newValue.despawnTime);
+		this.despawnOnDeathPicker.setInputValue(newValue.despawnOnDeath);
 		this.swingSpeedPicker.setInputValue(newValue.swingSpeed);
 		this.minBaseDamagePicker.setInputValue(newValue.minBaseDamage);
 		this.dualWieldPicker.setInputValue(newValue.dualWield);
@@ -23,6 +23,7 @@ import {
 	APLValueCurrentCastSpeedMultiplier,
 	APLValueCurrentComboPoints,
 	APLValueCurrentEnergy,
+	APLValueCurrentFocus,
 	APLValueCurrentHealth,
 	APLValueCurrentHealthPercent,
 	APLValueCurrentMana,
@@ -662,6 +663,14 @@ const valueKindFactories: { [f in NonNullable<APLValueKind>]: ValueKindConfig<AP
 		fields: [],
 		includeIf: (player: Player<any>, _isPrepull: boolean) => player.getClass() === Class.ClassRogue || player.getClass() === Class.ClassDruid,
 	}),
+	currentFocus: inputBuilder({
+		label: 'Focus',
+		submenu: ['Resources'],
+		shortDescription: 'Amount of currently available Focus.',
+		newValue: APLValueCurrentFocus.create,
+		fields: [AplHelpers.unitFieldConfig('sourceUnit', 'aura_sources')],
+		includeIf: (player: Player<any>, _isPrepull: boolean) => player.getClass() === Class.ClassHunter,
+	}),
 	timeToEnergyTick: inputBuilder({
 		label: 'Time to Next Energy Tick',
 		submenu: ['Resources'],
@@ -567,7 +567,7 @@ export class BulkTab extends SimTab {
 		new BooleanPicker<BulkTab>(settingsBlock.bodyElement, this, {
 			id: 'bulk-fast-mode',
 			label: 'Fast Mode',
-			labelTooltip: 'Fast mode reduces accuracy but will run faster.',
+			labelTooltip: 'Fast mode starts every combo with few iterations and only keeps simming the ones which could still be the best, so results below the top are less accurate.',
 			changedEvent: (_obj: BulkTab) => this.itemsChangedEmitter,
 			getValue: _obj => this.fastMode,
 			setValue: (_, obj: BulkTab, value: boolean) => {
@@ -1,5 +1,5 @@
 import { UnitMetadataList } from './player.js';
-import { Encounter as EncounterProto, PresetEncounter, PresetTarget, Target as TargetProto } from './proto/common.js';
+import { Encounter as EncounterProto, EncounterPhase, PresetEncounter, PresetTarget, Target as TargetProto } from './proto/common.js';
 import { Sim } from './sim.js';
 import { EventID, TypedEvent } from './typed_event.js';
 
@@ -22,6 +22,8 @@ export class Encounter {
 
 	targets!: Array<TargetProto>;
 	targetsMetadata: UnitMetadataList;
+	// Scripted fight phases. These come from encounter presets and are not editable in the UI.
+	phases: Array<EncounterPhase> = [];
 	presetTargets!: Array<PresetTarget>;
 
 	readonly targetsChangeEmitter = new TypedEvent<void>();
@@ -115,6 +117,7 @@ export class Encounter {
 
 	applyPreset(eventID: EventID, preset: PresetEncounter) {
 		this.targets = preset.targets.map(presetTarget => presetTarget.target || TargetProto.create());
+		this.phases = preset.phases;
 		this.targetsChangeEmitter.emit(eventID);
 	}
 
@@ -132,6 +135,7 @@ export class Encounter {
 			executeProportion35: this.executeProportion35,
 			useHealth: this.useHealth,
 			targets: this.targets,
+			phases: this.phases,
 		});
 	}
 
@@ -144,6 +148,7 @@ export class Encounter {
 			this.setExecuteProportion35(eventID, proto.executeProportion35);
 			this.setUseHealth(eventID, proto.useHealth);
 			this.targets = proto.targets;
+			this.phases = proto.phases;
 			this.targetsChangeEmitter.emit(eventID);
 		});
 	}
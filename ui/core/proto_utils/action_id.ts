@@ -133,6 +133,15 @@ export class ActionId {
 				baseName = 'Defensive Equipment';
 				iconUrl = `${BASE_PATH}assets/icons/inv_trinket_naxxramas05.jpg`;
 				break;
+			case OtherAction.OtherActionApplyPoison:
+				name = tag === 3 || tag === 4 ? 'Poison Depleted' : 'Apply Poison';
+				iconUrl = `${BASE_PATH}assets/icons/ability_poisons.jpg`;
+				if (tag === 1 || tag === 3) {
+					name += ' (Main-Hand)';
+				} else if (tag === 2 || tag === 4) {
+					name += ' (Off-Hand)';
+				}
+				break;
 		}
 		this.baseName = baseName;
 		this.name = name || baseName;
@@ -303,6 +312,7 @@ export class ActionId {
 				break;
 			// For targetted buffs, tag is the source player's raid index or -1 if none.
 			case 'Innervate':
+			case 'Mana Tide':
 			case 'Mana Tide Totem':
 			case 'Power Infusion':
 				if (this.tag !== -1) {
@@ -456,7 +456,8 @@ export class UnitMetrics {
 	}
 
 	get totalDamageTaken() {
-		return this.dtps.avg * this.duration;
+		// Targets which aren't in the whole fight report dtps over their own lifetime.
+		return this.dtps.avg * (this.isTarget ? this.metrics.activeSecondsAvg : this.duration);
 	}
 
 	getPlayerAndPetActions(): Array<ActionMetrics> {
@@ -954,6 +955,14 @@ export class ActionMetrics {
 		return this.combinedMetrics.critHealing / this.iterations;
 	}
 
+	get overhealing() {
+		return this.combinedMetrics.overhealing;
+	}
+
+	get avgOverhealing() {
+		return this.combinedMetrics.overhealing / this.iterations;
+	}
+
 	get hps() {
 		return this.combinedMetrics.hps;
 	}
@@ -1509,6 +1518,14 @@ export class TargetedActionMetrics {
 		return this.data.critHealing / this.iterations;
 	}
 
+	get overhealing() {
+		return this.data.overhealing;
+	}
+
+	get avgOverhealing() {
+		return this.data.overhealing / this.iterations;
+	}
+
 	get shielding() {
 		return this.data.shielding;
 	}
@@ -1770,6 +1787,7 @@ export class TargetedActionMetrics {
 				threat: sum(actions.map(a => a.data.threat)),
 				healing: sum(actions.map(a => a.data.healing)),
 				critHealing: sum(actions.map(a => a.data.critHealing)),
+				overhealing: sum(actions.map(a => a.data.overhealing)),
 				shielding: sum(actions.map(a => a.data.shielding)),
 				castTimeMs: sum(actions.map(a => a.data.castTimeMs)),
 			}),
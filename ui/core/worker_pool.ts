@@ -3,18 +3,28 @@ import { BASE_PATH, REPO_NAME } from './constants/other.js';
 import {
 	AbortRequest,
 	AbortResponse,
+	BuffValuesRequest,
+	BuffValuesResult,
+	CompareRequest,
+	CompareResult,
 	//BulkSimCombosRequest,
 	//BulkSimCombosResult,
 	BulkSimRequest,
 	BulkSimResult,
 	ComputeStatsRequest,
 	ComputeStatsResult,
+	InteractiveSimStartRequest,
+	InteractiveSimState,
+	InteractiveSimStateRequest,
+	InteractiveSimStepRequest,
 	ProgressMetrics,
 	RaidSimRequest,
 	RaidSimRequestSplitRequest,
 	RaidSimRequestSplitResult,
 	RaidSimResult,
 	RaidSimResultCombinationRequest,
+	StatScalingRequest,
+	StatScalingResult,
 	StatWeightRequestsData,
 	StatWeightsCalcRequest,
 	StatWeightsRequest,
@@ -88,6 +98,34 @@ export class WorkerPool {
 		return ComputeStatsResult.fromBinary(result);
 	}
 
+	// Interactive sessions live inside a single worker, so always use the first one.
+	async interactiveSimStart(request: InteractiveSimStartRequest): Promise<InteractiveSimState> {
+		const result = await this.workers[0].doApiCall(
+			SimRequest.interactiveSimStart,
+			InteractiveSimStartRequest.toBinary(request),
+			generateRequestId(SimRequest.interactiveSimStart),
+		);
+		return InteractiveSimState.fromBinary(result);
+	}
+
+	async interactiveSimStep(request: InteractiveSimStepRequest): Promise<InteractiveSimState> {
+		const result = await this.workers[0].doApiCall(
+			SimRequest.interactiveSimStep,
+			InteractiveSimStepRequest.toBinary(request),
+			generateRequestId(SimRequest.interactiveSimStep),
+		);
+		return InteractiveSimState.fromBinary(result);
+	}
+
+	async interactiveSimState(request: InteractiveSimStateRequest): Promise<InteractiveSimState> {
+		const result = await this.workers[0].doApiCall(
+			SimRequest.interactiveSimState,
+			InteractiveSimStateRequest.toBinary(request),
+			generateRequestId(SimRequest.interactiveSimState),
+		);
+		return InteractiveSimState.fromBinary(result);
+	}
+
 	private getProgressName(id: string) {
 		return `${id}progress`;
 	}
@@ -118,6 +156,54 @@ export class WorkerPool {
 		return StatWeightsResult.fromBinary(result);
 	}
 
+	async buffValuesAsync(request: BuffValuesRequest, onProgress: WorkerProgressCallback, signals: SimSignals): Promise<BuffValuesResult> {
+		const worker = this.getLeastBusyWorker();
+		worker.log('Buff values request: ' + BuffValuesRequest.toJsonString(request));
+		const id = generateRequestId(SimRequest.buffValuesAsync);
+
+		signals.abort.onTrigger(async () => {
+			await worker.sendAbortById(id);
+		});
+
+		const iterations = request.raidSimRequest?.simOptions?.iterations ?? 30000;
+		const result = await this.doAsyncRequest(SimRequest.buffValuesAsync, BuffValuesRequest.toBinary(request), id, worker, onProgress, iterations);
+
+		worker.log('Buff values result: ' + BuffValuesResult.toJsonString(result.finalBuffValuesResult!));
+		return result.finalBuffValuesResult!;
+	}
+
+	async compareAsync(request: CompareRequest, onProgress: WorkerProgressCallback, signals: SimSignals): Promise<CompareResult> {
+		const worker = this.getLeastBusyWorker();
+		worker.log('Compare request: ' + CompareRequest.toJsonString(request));
+		const id = generateRequestId(SimRequest.compareAsync);
+
+		signals.abort.onTrigger(async () => {
+			await worker.sendAbortById(id);
+		});
+
+		const iterations = request.baseRequest?.simOptions?.iterations ?? 30000;
+		const result = await this.doAsyncRequest(SimRequest.compareAsync, CompareRequest.toBinary(request), id, worker, onProgress, iterations);
+
+		worker.log('Compare result: ' + CompareResult.toJsonString(result.finalCompareResult!));
+		return result.finalCompareResult!;
+	}
+
+	async statScalingAsync(request: StatScalingRequest, onProgress: WorkerProgressCallback, signals: SimSignals): Promise<StatScalingResult> {
+		const worker = this.getLeastBusyWorker();
+		worker.log('Stat scaling request: ' + StatScalingRequest.toJsonString(request));
+		const id = generateRequestId(SimRequest.statScalingAsync);
+
+		signals.abort.onTrigger(async () => {
+			await worker.sendAbortById(id);
+		});
+
+		const iterations = request.simOptions?.iterations ?? 30000;
+		const result = await this.doAsyncRequest(SimRequest.statScalingAsync, StatScalingRequest.toBinary(request), id, worker, onProgress, iterations);
+
+		worker.log('Stat scaling result: ' + StatScalingResult.toJsonString(result.finalStatScalingResult!));
+		return result.finalStatScalingResult!;
+	}
+
 	async bulkSimAsync(request: BulkSimRequest, onProgress: WorkerProgressCallback, signals: SimSignals): Promise<BulkSimResult> {
 		const worker = this.getLeastBusyWorker();
 		worker.log('bulk sim request: ' + BulkSimRequest.toJsonString(request, { enumAsInteger: true }));
@@ -194,7 +280,7 @@ export class WorkerPool {
 	 * @returns The final ProgressMetrics.
 	 */
 	private async doAsyncRequest(
-		requestName: SimRequest.raidSimAsync | SimRequest.bulkSimAsync | SimRequest.statWeightsAsync,
+		requestName: SimRequest.raidSimAsync | SimRequest.bulkSimAsync | SimRequest.statWeightsAsync | SimRequest.buffValuesAsync | SimRequest.compareAsync | SimRequest.statScalingAsync,
 		request: Uint8Array,
 		id: string,
 		worker: SimWorker,
@@ -229,7 +315,7 @@ export class WorkerPool {
 			onProgress(progress);
 			worker.updateSimTask(id, Math.max(1, progress.totalIterations - progress.completedIterations));
 			// If we are done, stop adding the handler.
-			if (progress.finalRaidResult != null || progress.finalWeightResult != null || progress.finalBulkResult != null) {
+			if (progress.finalRaidResult != null || progress.finalWeightResult != null || progress.finalBulkResult != null || progress.finalBuffValuesResult != null || progress.finalCompareResult != null || progress.finalStatScalingResult != null) {
 				onFinal(progress);
 				return;
 			}
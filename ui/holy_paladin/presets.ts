@@ -2,6 +2,7 @@ import * as PresetUtils from '../core/preset_utils.js';
 import { Consumes, Flask, Food } from '../core/proto/common.js';
 import { PaladinAura, PaladinOptions as HolyPaladinOptions } from '../core/proto/paladin.js';
 import { SavedTalents } from '../core/proto/ui.js';
+import DefaultApl from './apls/default.apl.json';
 import BlankGear from './gear_sets/blank.gear.json';
 
 // Preset options for this spec.
@@ -16,10 +17,12 @@ export const DefaultGear = PresetUtils.makePresetGear('Blank', BlankGear);
 export const StandardTalents = {
 	name: 'Standard',
 	data: SavedTalents.create({
-		talentsString: '50350151020013053100515221-50023131203',
+		talentsString: '05503100521051-5',
 	}),
 };
 
+export const DefaultAPL = PresetUtils.makePresetAPLRotation('Default', DefaultApl);
+
 export const DefaultOptions = HolyPaladinOptions.create({
 	aura: PaladinAura.DevotionAura,
 });
@@ -87,13 +87,13 @@ const SPEC_CONFIG = registerSpecConfig(Spec.SpecHolyPaladin, {
 	presets: {
 		// Preset talents that the user can quickly select.
 		talents: [Presets.StandardTalents],
-		rotations: [],
+		rotations: [Presets.DefaultAPL],
 		// Preset gear configurations that the user can quickly select.
 		gear: [Presets.DefaultGear],
 	},
 
 	autoRotation: (_player: Player<Spec.SpecHolyPaladin>): APLRotation => {
-		return APLRotation.create();
+		return Presets.DefaultAPL.rotation.rotation!;
 	},
 
 	raidSimPresets: [
@@ -2,6 +2,7 @@ import * as PresetUtils from '../core/preset_utils.js';
 import { Consumes, Debuffs, Flask, Food, IndividualBuffs, PartyBuffs, RaidBuffs, TristateEffect, UnitReference } from '../core/proto/common.js';
 import { RestorationDruid_Options as RestorationDruidOptions } from '../core/proto/druid.js';
 import { SavedTalents } from '../core/proto/ui.js';
+import DefaultApl from './apls/default.apl.json';
 import BlankGear from './gear_sets/blank.gear.json';
 
 // Preset options for this spec.
@@ -12,19 +13,15 @@ export const DefaultGear = PresetUtils.makePresetGear('Blank', BlankGear);
 
 // Default talents. Uses the wowhead calculator format, make the talents on
 // https://wowhead.com/talent-calc and copy the numbers in the url.
-export const CelestialFocusTalents = {
-	name: 'Celestial Focus',
+export const StandardTalents = {
+	name: 'Standard',
 	data: SavedTalents.create({
-		talentsString: '05320031103--230023312131502331050313051',
-	}),
-};
-export const ThiccRestoTalents = {
-	name: 'Thicc Resto',
-	data: SavedTalents.create({
-		talentsString: '05320001--230023312331502531053313051',
+		talentsString: '50000500001--055103105315051',
 	}),
 };
 
+export const DefaultAPL = PresetUtils.makePresetAPLRotation('Default', DefaultApl);
+
 export const DefaultOptions = RestorationDruidOptions.create({
 	innervateTarget: UnitReference.create(),
 });
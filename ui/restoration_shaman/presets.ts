@@ -2,6 +2,7 @@ import * as PresetUtils from '../core/preset_utils.js';
 import { Consumes, Flask, Food, WeaponImbue } from '../core/proto/common.js';
 import { RestorationShaman_Options as RestorationShamanOptions } from '../core/proto/shaman.js';
 import { SavedTalents } from '../core/proto/ui.js';
+import DefaultApl from './apls/default.apl.json';
 import BlankGear from './gear_sets/blank.gear.json';
 
 // Preset options for this spec.
@@ -12,19 +13,15 @@ export const DefaultGear = PresetUtils.makePresetGear('Blank', BlankGear);
 
 // Default talents. Uses the wowhead calculator format, make the talents on
 // https://wowhead.com/talent-calc and copy the numbers in the url.
-export const TankHealingTalents = {
-	name: 'Tank Healing',
-	data: SavedTalents.create({
-		talentsString: '-30205033-05005331335010501122331251',
-	}),
-};
 export const RaidHealingTalents = {
 	name: 'Raid Healing',
 	data: SavedTalents.create({
-		talentsString: '-3020503-50005331335310501122331251',
+		talentsString: '-5-550353513053151',
 	}),
 };
 
+export const DefaultAPL = PresetUtils.makePresetAPLRotation('Default', DefaultApl);
+
 export const DefaultOptions = RestorationShamanOptions.create({
 	earthShieldPPM: 0,
 });
@@ -84,14 +84,14 @@ const SPEC_CONFIG = registerSpecConfig(Spec.SpecRestorationShaman, {
 
 	presets: {
 		// Preset talents that the user can quickly select.
-		talents: [Presets.RaidHealingTalents, Presets.TankHealingTalents],
-		rotations: [],
+		talents: [Presets.RaidHealingTalents],
+		rotations: [Presets.DefaultAPL],
 		// Preset gear configurations that the user can quickly select.
 		gear: [Presets.DefaultGear],
 	},
 
 	autoRotation: (_player: Player<Spec.SpecRestorationShaman>): APLRotation => {
-		return APLRotation.create();
+		return Presets.DefaultAPL.rotation.rotation!;
 	},
 
 	raidSimPresets: [
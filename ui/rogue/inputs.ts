@@ -1,6 +1,56 @@
 import * as InputHelpers from '../core/components/input_helpers.js';
 import { Player } from '../core/player.js';
 import { ItemSlot, Spec } from '../core/proto/common.js';
+import { RogueOptions_PoisonType as PoisonType } from '../core/proto/rogue.js';
+import { TypedEvent } from '../core/typed_event.js';
 
 // Configuration for spec-specific UI elements on the settings tab.
 // These don't need to be in a separate file but it keeps things cleaner.
+
+const poisonValues = [
+	{ name: 'Weapon Imbue', value: PoisonType.ConsumablePoison },
+	{ name: 'None', value: PoisonType.NoPoison },
+	{ name: 'Instant Poison', value: PoisonType.InstantPoison },
+	{ name: 'Deadly Poison', value: PoisonType.DeadlyPoison },
+	{ name: 'Wound Poison', value: PoisonType.WoundPoison },
+];
+
+const poisonTooltip = 'Poison applied to the weapon. Weapon Imbue uses the poison picked in the consumables, if any.';
+const poisonRankTooltip =
+	'Rank of the poison, 0 for the highest rank available. Each rank applies more charges, and the poison can be reapplied in the rotation once they run out.';
+
+export const MainHandPoison = InputHelpers.makeSpecOptionsEnumInput<Spec.SpecRogue>({
+	fieldName: 'mainHandPoison',
+	label: 'Main Hand Poison',
+	labelTooltip: poisonTooltip,
+	values: poisonValues,
+	showWhen: (player: Player<Spec.SpecRogue>) => !!player.getEquippedItem(ItemSlot.ItemSlotMainHand),
+	changeEmitter: (player: Player<Spec.SpecRogue>) => TypedEvent.onAny([player.gearChangeEmitter, player.specOptionsChangeEmitter]),
+});
+
+export const MainHandPoisonRank = InputHelpers.makeSpecOptionsNumberInput<Spec.SpecRogue>({
+	fieldName: 'mainHandPoisonRank',
+	label: 'Main Hand Poison Rank',
+	labelTooltip: poisonRankTooltip,
+	showWhen: (player: Player<Spec.SpecRogue>) =>
+		!!player.getEquippedItem(ItemSlot.ItemSlotMainHand) && player.getSpecOptions().mainHandPoison != PoisonType.NoPoison,
+	changeEmitter: (player: Player<Spec.SpecRogue>) => TypedEvent.onAny([player.gearChangeEmitter, player.specOptionsChangeEmitter]),
+});
+
+export const OffHandPoison = InputHelpers.makeSpecOptionsEnumInput<Spec.SpecRogue>({
+	fieldName: 'offHandPoison',
+	label: 'Off Hand Poison',
+	labelTooltip: poisonTooltip,
+	values: poisonValues,
+	showWhen: (player: Player<Spec.SpecRogue>) => !!player.getEquippedItem(ItemSlot.ItemSlotOffHand),
+	changeEmitter: (player: Player<Spec.SpecRogue>) => TypedEvent.onAny([player.gearChangeEmitter, player.specOptionsChangeEmitter]),
+});
+
+export const OffHandPoisonRank = InputHelpers.makeSpecOptionsNumberInput<Spec.SpecRogue>({
+	fieldName: 'offHandPoisonRank',
+	label: 'Off Hand Poison Rank',
+	labelTooltip: poisonRankTooltip,
+	showWhen: (player: Player<Spec.SpecRogue>) =>
+		!!player.getEquippedItem(ItemSlot.ItemSlotOffHand) && player.getSpecOptions().offHandPoison != PoisonType.NoPoison,
+	changeEmitter: (player: Player<Spec.SpecRogue>) => TypedEvent.onAny([player.gearChangeEmitter, player.specOptionsChangeEmitter]),
+});
@@ -6,6 +6,7 @@ import { Player } from '../core/player.js';
 import { Class, Faction, ItemSlot, PartyBuffs, PseudoStat, Race, Spec, Stat, Target, WeaponType } from '../core/proto/common.js';
 import { Stats } from '../core/proto_utils/stats.js';
 import { getSpecIcon } from '../core/proto_utils/utils.js';
+import * as RogueInputs from './inputs.js';
 import * as Presets from './presets.js';
 
 const SPEC_CONFIG = registerSpecConfig(Spec.SpecRogue, {
@@ -112,7 +113,7 @@ const SPEC_CONFIG = registerSpecConfig(Spec.SpecRogue, {
 	},
 
 	playerInputs: {
-		inputs: [],
+		inputs: [RogueInputs.MainHandPoison, RogueInputs.MainHandPoisonRank, RogueInputs.OffHandPoison, RogueInputs.OffHandPoisonRank],
 	},
 	// IconInputs to include in the 'Player' section on the settings tab.
 	playerIconInputs: [],
@@ -6,7 +6,13 @@ type SimRequestSync = (data: Uint8Array) => Uint8Array;
 // Functions provided or used by the wasm lib.
 declare global {
 	function wasmready(): void;
+	const buffValues: SimRequestSync;
+	const buffValuesAsync: SimRequestAsync;
 	const bulkSimAsync: SimRequestAsync;
+	const compare: SimRequestSync;
+	const compareAsync: SimRequestAsync;
+	const statScaling: SimRequestSync;
+	const statScalingAsync: SimRequestAsync;
 	const bulkSimCombos: SimRequestSync;
 	const computeStats: SimRequestSync;
 	const computeStatsJson: SimRequestSync;
@@ -20,13 +26,22 @@ declare global {
 	const raidSimResultCombination: SimRequestSync;
 	const raidSimRequestSplit: SimRequestSync;
 	const abortById: SimRequestSync;
+	const interactiveSimStart: SimRequestSync;
+	const interactiveSimStep: SimRequestSync;
+	const interactiveSimState: SimRequestSync;
 }
 
 // Wasm binary calls this function when its done loading.
 // eslint-disable-next-line @typescript-eslint/no-unused-vars
 globalThis.wasmready = function() {
 	new WorkerInterface({
+		buffValues: buffValues,
+		buffValuesAsync: buffValuesAsync,
 		bulkSimAsync: bulkSimAsync,
+		compare: compare,
+		compareAsync: compareAsync,
+		statScaling: statScaling,
+		statScalingAsync: statScalingAsync,
 		//bulkSimCombos: bulkSimCombos,
 		computeStats: computeStats,
 		computeStatsJson: computeStatsJson,
@@ -40,6 +55,9 @@ globalThis.wasmready = function() {
 		raidSimRequestSplit: raidSimRequestSplit,
 		raidSimResultCombination: raidSimResultCombination,
 		abortById: abortById,
+		'interactiveSim/start': interactiveSimStart,
+		'interactiveSim/step': interactiveSimStep,
+		'interactiveSim/state': interactiveSimState,
 	}).ready(true);
 };
 
@@ -2,8 +2,14 @@
  * API endpoints and exposed wasm function names. Also used as request identifier.
  */
 export enum SimRequest {
+	buffValues = 'buffValues',
+	buffValuesAsync = 'buffValuesAsync',
 	bulkSimAsync = 'bulkSimAsync',
 	//bulkSimCombos = 'bulkSimCombos',
+	compare = 'compare',
+	compareAsync = 'compareAsync',
+	statScaling = 'statScaling',
+	statScalingAsync = 'statScalingAsync',
 	computeStats = 'computeStats',
 	computeStatsJson = 'computeStatsJson',
 	raidSim = 'raidSim',
@@ -16,6 +22,9 @@ export enum SimRequest {
 	raidSimRequestSplit = 'raidSimRequestSplit',
 	raidSimResultCombination = 'raidSimResultCombination',
 	abortById = 'abortById',
+	interactiveSimStart = 'interactiveSim/start',
+	interactiveSimStep = 'interactiveSim/step',
+	interactiveSimState = 'interactiveSim/state',
 }
 
 /**
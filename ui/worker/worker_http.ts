@@ -47,8 +47,14 @@ export const setupHttpWorker = (baseURL: string) => {
 	};
 
 	new WorkerInterface({
+		buffValues: syncHandler,
+		buffValuesAsync: asyncHandler,
 		bulkSimAsync: asyncHandler,
 		//bulkSimCombos: syncHandler,
+		compare: syncHandler,
+		compareAsync: asyncHandler,
+		statScaling: syncHandler,
+		statScalingAsync: asyncHandler,
 		computeStats: syncHandler,
 		computeStatsJson: syncHandler,
 		raidSim: syncHandler,
@@ -61,5 +67,8 @@ export const setupHttpWorker = (baseURL: string) => {
 		raidSimRequestSplit: noWasmConcurrency,
 		raidSimResultCombination: noWasmConcurrency,
 		abortById: syncHandler,
+		'interactiveSim/start': syncHandler,
+		'interactiveSim/step': syncHandler,
+		'interactiveSim/state': syncHandler,
 	}).ready(false);
 };